@@ -122,6 +122,7 @@ type ForwardAuthConfig struct {
 	RequestHeaders  []string `json:"request_headers,omitempty"`
 	UpstreamHeaders []string `json:"upstream_headers,omitempty"`
 	ClientHeaders   []string `json:"client_headers,omitempty"`
+	RequestMethod   string   `json:"request_method,omitempty"`
 }
 
 // BasicAuthConfig is the rule config for basic-auth plugin.
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ const (
 	TypeSSL            = "ssl"
 	TypeGlobalRule     = "global_rule"
 	TypePluginMetadata = "plugin_metadata"
+	TypeConsumerGroup  = "consumer_group"
 )
 
 type Object interface {
@@ -78,6 +80,17 @@ func (r *Resources) MarshalLog() any {
 	}
 }
 
+// AllConsumers returns the consumers of r, those nested in its consumer groups included.
+func (r *Resources) AllConsumers() []*Consumer {
+	consumers := slices.Clone(r.Consumers)
+	for _, group := range r.ConsumerGroups {
+		for i := range group.Consumers {
+			consumers = append(consumers, &group.Consumers[i])
+		}
+	}
+	return consumers
+}
+
 type GlobalRule Plugins
 
 func (g *GlobalRule) DeepCopy() GlobalRule {
@@ -116,6 +129,10 @@ type Consumer struct {
 	Credentials []Credential `json:"credentials,omitempty" yaml:"credentials,omitempty"`
 	Plugins     Plugins      `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	Username    string       `json:"username" yaml:"username"`
+
+	// GroupID is the ID of the consumer group the cache keeps the consumer in. ADC has no
+	// such field: it nests the consumers of a group in the group instead.
+	GroupID string `json:"-" yaml:"-"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -208,8 +225,9 @@ type UpstreamHealthCheck struct {
 // ClientTLS is tls cert and key use in mTLS
 // +k8s:deepcopy-gen=true
 type ClientTLS struct {
-	Cert string `json:"client_cert,omitempty" yaml:"client_cert,omitempty"`
-	Key  string `json:"client_key,omitempty" yaml:"client_key,omitempty"`
+	Cert   string `json:"client_cert,omitempty" yaml:"client_cert,omitempty"`
+	Key    string `json:"client_key,omitempty" yaml:"client_key,omitempty"`
+	Verify bool   `json:"verify,omitempty" yaml:"verify,omitempty"`
 }
 
 // UpstreamActiveHealthCheck defines the active upstream health check configuration.
@@ -649,6 +667,18 @@ const (
 	PluginResponseRewrite string = "response-rewrite"
 	PluginProxyMirror     string = "proxy-mirror"
 	PluginCORS            string = "cors"
+	// PluginForwardAuth authenticates a request against an external
+	// authorization service before it is proxied.
+	PluginForwardAuth string = "forward-auth"
+	// PluginFaultInjection aborts a request with a fixed response, used to
+	// reject the requests of a rule whose auth service is unavailable.
+	PluginFaultInjection string = "fault-injection"
+	// PluginServerlessPreFunction runs Lua functions before the built-in
+	// plugins, used to read or mint the session persistence token.
+	PluginServerlessPreFunction string = "serverless-pre-function"
+	// PluginServerlessPostFunction runs Lua functions after the built-in
+	// plugins, used to issue the session persistence token.
+	PluginServerlessPostFunction string = "serverless-post-function"
 )
 
 // RewriteConfig is the rule config for proxy-rewrite plugin.
@@ -681,6 +711,7 @@ type FaultInjectionConfig struct {
 
 type FaultInjectionAbortConfig struct {
 	HTTPStatus int           `json:"http_status" yaml:"http_status"`
+	Body       string        `json:"body,omitempty" yaml:"body,omitempty"`
 	Vars       [][]expr.Expr `json:"vars,omitempty" yaml:"vars,omitempty"`
 }
 
@@ -693,6 +724,18 @@ type ResponseHeaders struct {
 // RequestMirror is the rule config for proxy-mirror plugin.
 type RequestMirror struct {
 	Host string `json:"host" yaml:"host"`
+	// SampleRatio is the share of requests mirrored, from 0.00001 to 1. All
+	// requests are mirrored when it is unset.
+	SampleRatio float64 `json:"sample_ratio,omitempty" yaml:"sample_ratio,omitempty"`
+}
+
+// ServerlessConfig is the rule config for serverless-pre-function and
+// serverless-post-function plugins.
+type ServerlessConfig struct {
+	// Phase is the request processing phase the functions run in,
+	// e.g. `rewrite`, `access` or `header_filter`.
+	Phase     string   `json:"phase,omitempty" yaml:"phase,omitempty"`
+	Functions []string `json:"functions" yaml:"functions"`
 }
 
 // RedirectConfig is the rule config for redirect plugin.
@@ -806,6 +849,14 @@ type Config struct {
 	Token       string
 	TlsVerify   bool
 	BackendType string
+	// Executor selects the ADCExecutor that pushes this config, `adc` or `native`.
+	Executor string
+
+	// Rollout stages a push across ServerAddrs, nil pushes to all of them at once.
+	Rollout *Rollout
+	// DryRun overrides whether the client diffs this config instead of pushing it, nil
+	// leaves it to the client.
+	DryRun *bool
 
 	// BypassCache makes the ADC server drop the in-memory baseline it holds for this
 	// cacheKey and re-derive it from the data plane before computing the diff. It is a
@@ -820,13 +871,33 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		Name        string   `json:"name"`
 		ServerAddrs []string `json:"serverAddrs"`
 		TlsVerify   bool     `json:"tlsVerify"`
+		Executor    string   `json:"executor,omitempty"`
+		Rollout     *Rollout `json:"rollout,omitempty"`
+		DryRun      *bool    `json:"dryRun,omitempty"`
 	}{
 		Name:        c.Name,
 		ServerAddrs: c.ServerAddrs,
 		TlsVerify:   c.TlsVerify,
+		Executor:    c.Executor,
+		Rollout:     c.Rollout,
+		DryRun:      c.DryRun,
 	})
 }
 
+// Rollout is the translated GatewayProxy rollout strategy.
+type Rollout struct {
+	// Canary is how many of ServerAddrs receive a push before the rest.
+	Canary int `json:"canary"`
+	// HealthCheck, when set, is probed on every canary endpoint after it accepted a push.
+	HealthCheck *RolloutHealthCheck `json:"healthCheck,omitempty"`
+}
+
+type RolloutHealthCheck struct {
+	Port    int32         `json:"port"`
+	Path    string        `json:"path"`
+	Timeout time.Duration `json:"timeout"`
+}
+
 var (
 	ResolveGranularity = struct {
 		Endpoint string
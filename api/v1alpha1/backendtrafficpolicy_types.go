@@ -18,6 +18,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,9 +37,10 @@ type BackendTrafficPolicy struct {
 
 type BackendTrafficPolicySpec struct {
 	// TargetRef identifies an API object to apply policy to.
-	// Currently, Backends (i.e. Service, ServiceImport, or any
-	// implementation-specific backendRef) are the only valid API
-	// target references.
+	// A Service sets the policy of its backends. A Gateway, or one of its
+	// listeners named by `sectionName`, sets the defaults of the backends of
+	// the routes attached to it, which the policy of a Service overrides
+	// field by field.
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
 	TargetRefs []BackendPolicyTargetReferenceWithSectionName `json:"targetRefs"`
@@ -52,7 +54,6 @@ type BackendTrafficPolicySpec struct {
 	// The L4 values apply to stream routes only; using them for an HTTP route
 	// makes the upstream unreachable.
 	// +kubebuilder:validation:Enum=http;https;grpc;grpcs;tcp;tls;udp;
-	// +kubebuilder:default=http
 	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
 
 	// Retries specify the number of times the gateway should retry sending
@@ -71,7 +72,6 @@ type BackendTrafficPolicySpec struct {
 	// * `rewrite`: set to a custom host via `upstreamHost`
 	//
 	// +kubebuilder:validation:Enum=pass;node;rewrite;
-	// +kubebuilder:default=pass
 	PassHost string `json:"passHost,omitempty" yaml:"passHost,omitempty"`
 
 	// UpstreamHost specifies the host of the Upstream request. Used only if
@@ -84,6 +84,74 @@ type BackendTrafficPolicySpec struct {
 	// unhealthy nodes.
 	// +optional
 	HealthCheck *HealthCheck `json:"healthCheck,omitempty" yaml:"healthCheck,omitempty"`
+
+	// SessionPersistence pins a client to the same backend node for the
+	// lifetime of a session. When set, it overrides the `loadbalancer`
+	// configuration with a `chash` load balancer keyed on the session
+	// token. Only applies to backends of HTTPRoute rules, it is ignored
+	// for other route kinds.
+	// +optional
+	SessionPersistence *SessionPersistence `json:"sessionPersistence,omitempty" yaml:"sessionPersistence,omitempty"`
+
+	// TLS configures TLS to the upstream, including the client certificate
+	// that the gateway presents for mutual TLS. The upstream is reached over
+	// `https`, `grpcs`, or `tls`, depending on `scheme`.
+	// +optional
+	TLS *BackendTLS `json:"tls,omitempty" yaml:"tls,omitempty"`
+}
+
+// BackendTLS configures TLS between the gateway and the upstream.
+type BackendTLS struct {
+	// SecretRef references the Secret, in the namespace of the policy, whose
+	// `tls.crt` and `tls.key` the gateway presents as its client certificate.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty" yaml:"secretRef,omitempty"`
+	// CACertificateRef references the Secret, in the namespace of the policy,
+	// whose `ca.crt` holds the CA certificates of the upstream. The data plane
+	// does not support a CA certificate per upstream, so it is only validated.
+	// +optional
+	CACertificateRef *corev1.LocalObjectReference `json:"caCertificateRef,omitempty" yaml:"caCertificateRef,omitempty"`
+	// SNI is the server name that the gateway sends in the TLS handshake.
+	// APISIX takes it from the upstream host, so it is also the Host header
+	// that the upstream sees, overriding `passHost` and `upstreamHost`.
+	// +optional
+	SNI Hostname `json:"sni,omitempty" yaml:"sni,omitempty"`
+	// Verify is whether the gateway verifies the certificate of the upstream.
+	// Default is `false`.
+	// +optional
+	Verify bool `json:"verify,omitempty" yaml:"verify,omitempty"`
+}
+
+// SessionPersistence describes how client sessions are bound to a backend node.
+// +kubebuilder:validation:XValidation:rule="!(has(self.idleTimeout) && self.type == 'Cookie' && self.cookieLifetimeType == 'Session')",message="idleTimeout requires cookieLifetimeType Permanent"
+type SessionPersistence struct {
+	// Type is the kind of session token, either `Cookie` or `Header`.
+	// Default is `Cookie`.
+	// +kubebuilder:validation:Enum=Cookie;Header
+	// +kubebuilder:default=Cookie
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// SessionName is the name of the cookie or header that carries the
+	// session token. Default is `apisix_session`.
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	// +optional
+	SessionName string `json:"sessionName,omitempty" yaml:"sessionName,omitempty"`
+	// AbsoluteTimeout is the time after which a session expires regardless of activity.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	AbsoluteTimeout *metav1.Duration `json:"absoluteTimeout,omitempty" yaml:"absoluteTimeout,omitempty"`
+	// IdleTimeout is the time after which an unused session expires.
+	// Applies to `Cookie` sessions with the `Permanent` lifetime type only.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty" yaml:"idleTimeout,omitempty"`
+	// CookieLifetimeType is the lifetime of the session cookie.
+	// `Session` cookies are discarded when the browser closes, `Permanent`
+	// cookies carry a Max-Age derived from the timeouts.
+	// Default is `Session`.
+	// +kubebuilder:validation:Enum=Permanent;Session
+	// +kubebuilder:default=Session
+	CookieLifetimeType string `json:"cookieLifetimeType,omitempty" yaml:"cookieLifetimeType,omitempty"`
 }
 
 // LoadBalancer describes the load balancing parameters.
@@ -18,6 +18,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -37,6 +38,19 @@ type Consumer struct {
 
 type ConsumerStatus struct {
 	Status `json:",inline"`
+	// Credentials reports whether each credential of the consumer is active, and when it
+	// expires.
+	Credentials []CredentialStatus `json:"credentials,omitempty"`
+}
+
+type CredentialStatus struct {
+	// Name is the name of the credential.
+	Name string `json:"name"`
+	// Active is whether the credential is accepted, between its `notBefore` and `notAfter`.
+	Active bool `json:"active"`
+	// ExpiresAt is when the credential stops being accepted. Unset for a credential
+	// without `notAfter`.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 type ConsumerSpec struct {
 	// GatewayRef specifies the gateway details.
@@ -45,6 +59,9 @@ type ConsumerSpec struct {
 	Credentials []Credential `json:"credentials,omitempty"`
 	// Plugins define the plugins associated with a consumer.
 	Plugins []Plugin `json:"plugins,omitempty"`
+	// GroupRef references the ConsumerGroup, in the same namespace, that the consumer
+	// belongs to.
+	GroupRef *corev1.LocalObjectReference `json:"groupRef,omitempty"`
 }
 
 type GatewayRef struct {
@@ -62,6 +79,8 @@ type GatewayRef struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.notBefore) || !has(self.notAfter) || self.notBefore < self.notAfter",message="notBefore must be before notAfter"
+// +kubebuilder:validation:XValidation:rule="!has(self.secretRef) || !has(self.secretRef.keys) || self.type == 'key-auth'",message="secretRef.keys is only supported for key-auth credentials"
 type Credential struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=jwt-auth;basic-auth;key-auth;hmac-auth;
@@ -74,6 +93,12 @@ type Credential struct {
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
 	// Name is the name of the credential.
 	Name string `json:"name,omitempty"`
+	// NotBefore is when the credential starts being accepted. Several credentials with
+	// overlapping validity let clients move from one to the next without a hard cut-over.
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	// NotAfter is when the credential stops being accepted. An expired credential is removed
+	// from the consumer.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 type SecretReference struct {
@@ -81,6 +106,13 @@ type SecretReference struct {
 	Name string `json:"name"`
 	// Namespace is the namespace of the secret.
 	Namespace *string `json:"namespace,omitempty"`
+	// Keys lists the keys of the secret that hold the key of a key-auth credential: the
+	// current one first, followed by the previous ones that are still accepted while clients
+	// move to the current one. Each becomes a credential of its own, named after the
+	// credential and, for a previous one, the key of the secret. When empty, each key of the
+	// secret is a field of the credential.
+	// +kubebuilder:validation:MaxItems=8
+	Keys []string `json:"keys,omitempty"`
 }
 
 // +kubebuilder:object:root=true
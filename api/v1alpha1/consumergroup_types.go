@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Members",type=integer,JSONPath=`.status.memberCount`
+// ConsumerGroup defines plugins shared by the consumers that refer to it.
+type ConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ConsumerGroupSpec defines the plugins of a consumer group.
+	Spec   ConsumerGroupSpec   `json:"spec,omitempty"`
+	Status ConsumerGroupStatus `json:"status,omitempty"`
+}
+
+type ConsumerGroupSpec struct {
+	// Plugins define the plugins applied to every consumer in the group. A plugin that the
+	// consumer configures itself takes precedence.
+	Plugins []Plugin `json:"plugins,omitempty"`
+}
+
+type ConsumerGroupStatus struct {
+	Status `json:",inline"`
+	// MemberCount is the number of consumers in the group.
+	MemberCount int32 `json:"memberCount,omitempty"`
+	// InvalidMembers lists the consumers that refer to the group but are not in it.
+	InvalidMembers []ConsumerGroupMember `json:"invalidMembers,omitempty"`
+}
+
+type ConsumerGroupMember struct {
+	// Kind is the kind of the consumer, `Consumer` or `ApisixConsumer`.
+	Kind string `json:"kind"`
+	// Name is the name of the consumer, in the namespace of the group.
+	Name string `json:"name"`
+	// Reason explains why the consumer is not in the group.
+	Reason string `json:"reason"`
+}
+
+// +kubebuilder:object:root=true
+type ConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ConsumerGroup{}, &ConsumerGroupList{})
+}
@@ -30,6 +30,10 @@ type GatewayProxySpec struct {
 	// PublishService specifies the LoadBalancer-type Service whose external address the controller uses to
 	// update the status of Ingress resources.
 	PublishService string `json:"publishService,omitempty"`
+	// ManagePublishService allows the controller to update the PublishService with the
+	// infrastructure labels and annotations of the Gateways that use this GatewayProxy.
+	// +optional
+	ManagePublishService bool `json:"managePublishService,omitempty"`
 	// StatusAddress specifies the external IP addresses that the controller uses to populate the status field
 	// of GatewayProxy or Ingress resources for developers to access.
 	StatusAddress []string `json:"statusAddress,omitempty"`
@@ -126,6 +130,21 @@ type ControlPlaneProvider struct {
 	//
 	// +kubebuilder:validation:Optional
 	Mode string `json:"mode,omitempty"`
+	// Executor specifies how configuration is pushed to the control plane.
+	// Can be `adc`, which goes through the ADC server, or `native`, which talks to the
+	// APISIX Admin API, or the standalone config API, directly.
+	// Defaults to the executor the controller is configured with. The api7ee
+	// provider always goes through the ADC server and ignores `native`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=adc;native
+	Executor string `json:"executor,omitempty"`
+	// DryRun, when true, stops the controller from writing to the control plane: every
+	// change is validated and compared with what the control plane holds, and the
+	// difference is reported instead of pushed.
+	// Defaults to the dry run setting the controller is configured with.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
 	// Endpoints specifies the list of control plane endpoints.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinItems=1
@@ -139,6 +158,44 @@ type ControlPlaneProvider struct {
 	// Auth specifies the authentication configuration.
 	// +kubebuilder:validation:Required
 	Auth ControlPlaneAuth `json:"auth"`
+
+	// Rollout stages every configuration change across the control plane endpoints:
+	// a change reaches a canary subset of them first, and the rest only once the canary
+	// has accepted it. Without it, a change is pushed to every endpoint at once.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+}
+
+// RolloutStrategy defines how a configuration change is staged across control plane endpoints.
+type RolloutStrategy struct {
+	// Canary is the number of endpoints that receive a change first.
+	// A change that one of them rejects, or that leaves one of them failing the health
+	// check, rolls them back to the last configuration every endpoint accepted and is not
+	// pushed any further. With no more endpoints than this, nothing is staged.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Canary int32 `json:"canary,omitempty"`
+	// HealthCheck specifies the probe a canary endpoint must pass once it has accepted a change.
+	// +optional
+	HealthCheck *RolloutHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// RolloutHealthCheck defines the HTTP probe sent to a canary endpoint after a push.
+// It is sent to the host of the endpoint and succeeds on a 2xx response.
+type RolloutHealthCheck struct {
+	// Port is the port of the probe, such as 7085 for the APISIX status API.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Path is the path of the probe.
+	// +kubebuilder:default="/status/ready"
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Timeout is how long the probe waits for a response.
+	// +kubebuilder:default="5s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 type ProviderService struct {
@@ -152,6 +209,7 @@ type ProviderService struct {
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // GatewayProxy defines configuration for the gateway proxy instances used to route traffic to services.
 type GatewayProxy struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -159,9 +217,28 @@ type GatewayProxy struct {
 
 	// GatewayProxySpec defines configuration of gateway proxy instances,
 	// including networking settings, global plugins, and plugin metadata.
-	Spec GatewayProxySpec `json:"spec,omitempty"`
+	Spec   GatewayProxySpec   `json:"spec,omitempty"`
+	Status GatewayProxyStatus `json:"status,omitempty"`
 }
 
+// GatewayProxyStatus defines the observed state of GatewayProxy.
+type GatewayProxyStatus struct {
+	Status `json:",inline"`
+}
+
+const (
+	// ConditionTypeDataPlaneAvailable reports whether configuration is pushed to the data
+	// plane of a GatewayProxy, or held back by the circuit breaker after it failed to answer.
+	ConditionTypeDataPlaneAvailable = "DataPlaneAvailable"
+
+	// ConditionReasonCircuitClosed means configuration is pushed as usual.
+	ConditionReasonCircuitClosed = "CircuitClosed"
+	// ConditionReasonCircuitOpen means pushes are skipped until the next probe.
+	ConditionReasonCircuitOpen = "CircuitOpen"
+	// ConditionReasonCircuitHalfOpen means one push probes whether the data plane answers again.
+	ConditionReasonCircuitHalfOpen = "CircuitHalfOpen"
+)
+
 // +kubebuilder:object:root=true
 // GatewayProxyList contains a list of GatewayProxy.
 type GatewayProxyList struct {
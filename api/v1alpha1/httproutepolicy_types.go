@@ -18,6 +18,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -26,6 +27,8 @@ import (
 // HTTPRoutePolicySpec defines the desired state of HTTPRoutePolicy.
 type HTTPRoutePolicySpec struct {
 	// TargetRef identifies an API object (i.e. HTTPRoute, Ingress) to apply HTTPRoutePolicy to.
+	// A Gateway, or one of its listeners named by `sectionName`, sets the defaults of the
+	// HTTPRoutes attached to it, which the policies of an HTTPRoute override.
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
 	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
@@ -34,6 +37,14 @@ type HTTPRoutePolicySpec struct {
 	Priority *int64 `json:"priority,omitempty" yaml:"priority,omitempty"`
 	// Vars sets the request matching conditions.
 	Vars []apiextensionsv1.JSON `json:"vars,omitempty" yaml:"vars,omitempty"`
+	// Plugins define the plugins applied to the targeted HTTPRoute rules, so that they
+	// do not need an ExtensionRef filter on each rule. A plugin configured by a filter
+	// of the rule, including an ExtensionRef PluginConfig, takes precedence, and so does
+	// a plugin of an older HTTPRoutePolicy. Not applied to Ingress targets.
+	Plugins []Plugin `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+	// PluginConfigRef references a PluginConfig, in the same namespace, whose plugins are
+	// applied like `plugins`, which take precedence over them.
+	PluginConfigRef *corev1.LocalObjectReference `json:"pluginConfigRef,omitempty" yaml:"pluginConfigRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -45,7 +56,7 @@ type HTTPRoutePolicy struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// HTTPRoutePolicySpec defines configuration of a HTTPRoutePolicy,
-	// including route priority and request matching conditions.
+	// including route priority, request matching conditions and plugins.
 	Spec   HTTPRoutePolicySpec `json:"spec,omitempty"`
 	Status PolicyStatus        `json:"status,omitempty"`
 }
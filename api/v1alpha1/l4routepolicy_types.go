@@ -26,10 +26,12 @@ import (
 type L4RoutePolicySpec struct {
 	// TargetRefs identifies the L4 route resources (TCPRoute, UDPRoute, or TLSRoute)
 	// to which this policy applies. Only same-namespace targets are supported.
+	// A Gateway, or one of its listeners named by `sectionName`, sets the default
+	// plugins of the L4 routes attached to it, which the policy of a route overrides.
 	//
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
-	// +kubebuilder:validation:XValidation:rule="self.all(r, r.kind == 'TCPRoute' || r.kind == 'UDPRoute' || r.kind == 'TLSRoute')",message="targetRefs kind must be TCPRoute, UDPRoute, or TLSRoute"
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.kind == 'TCPRoute' || r.kind == 'UDPRoute' || r.kind == 'TLSRoute' || r.kind == 'Gateway')",message="targetRefs kind must be TCPRoute, UDPRoute, TLSRoute, or Gateway"
 	// +kubebuilder:validation:XValidation:rule="self.all(r, r.group == 'gateway.networking.k8s.io')",message="targetRefs group must be gateway.networking.k8s.io"
 	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
 
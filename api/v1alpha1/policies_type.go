@@ -21,5 +21,5 @@ import gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 type PolicyStatus gatewayv1.PolicyStatus
 
-// +kubebuilder:validation:XValidation:rule="self.kind == 'Service' && self.group == \"\""
+// +kubebuilder:validation:XValidation:rule="(self.kind == 'Service' && self.group == \"\") || (self.kind == 'Gateway' && self.group == 'gateway.networking.k8s.io')"
 type BackendPolicyTargetReferenceWithSectionName gatewayv1.LocalPolicyTargetReferenceWithSectionName
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -158,6 +159,31 @@ func (in *BackendPolicyTargetReferenceWithSectionName) DeepCopy() *BackendPolicy
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLS) DeepCopyInto(out *BackendTLS) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CACertificateRef != nil {
+		in, out := &in.CACertificateRef, &out.CACertificateRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLS.
+func (in *BackendTLS) DeepCopy() *BackendTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendTrafficPolicy) DeepCopyInto(out *BackendTrafficPolicy) {
 	*out = *in
@@ -247,6 +273,16 @@ func (in *BackendTrafficPolicySpec) DeepCopyInto(out *BackendTrafficPolicySpec)
 		*out = new(HealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SessionPersistence != nil {
+		in, out := &in.SessionPersistence, &out.SessionPersistence
+		*out = new(SessionPersistence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BackendTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTrafficPolicySpec.
@@ -286,6 +322,123 @@ func (in *Consumer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroup) DeepCopyInto(out *ConsumerGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroup.
+func (in *ConsumerGroup) DeepCopy() *ConsumerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupList) DeepCopyInto(out *ConsumerGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsumerGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupList.
+func (in *ConsumerGroupList) DeepCopy() *ConsumerGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupMember) DeepCopyInto(out *ConsumerGroupMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupMember.
+func (in *ConsumerGroupMember) DeepCopy() *ConsumerGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSpec) DeepCopyInto(out *ConsumerGroupSpec) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]Plugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSpec.
+func (in *ConsumerGroupSpec) DeepCopy() *ConsumerGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupStatus) DeepCopyInto(out *ConsumerGroupStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.InvalidMembers != nil {
+		in, out := &in.InvalidMembers, &out.InvalidMembers
+		*out = make([]ConsumerGroupMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupStatus.
+func (in *ConsumerGroupStatus) DeepCopy() *ConsumerGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsumerList) DeepCopyInto(out *ConsumerList) {
 	*out = *in
@@ -336,6 +489,11 @@ func (in *ConsumerSpec) DeepCopyInto(out *ConsumerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GroupRef != nil {
+		in, out := &in.GroupRef, &out.GroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerSpec.
@@ -352,6 +510,13 @@ func (in *ConsumerSpec) DeepCopy() *ConsumerSpec {
 func (in *ConsumerStatus) DeepCopyInto(out *ConsumerStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]CredentialStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerStatus.
@@ -387,6 +552,11 @@ func (in *ControlPlaneAuth) DeepCopy() *ControlPlaneAuth {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneProvider) DeepCopyInto(out *ControlPlaneProvider) {
 	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Endpoints != nil {
 		in, out := &in.Endpoints, &out.Endpoints
 		*out = make([]string, len(*in))
@@ -403,6 +573,11 @@ func (in *ControlPlaneProvider) DeepCopyInto(out *ControlPlaneProvider) {
 		**out = **in
 	}
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneProvider.
@@ -424,6 +599,14 @@ func (in *Credential) DeepCopyInto(out *Credential) {
 		*out = new(SecretReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
@@ -436,12 +619,32 @@ func (in *Credential) DeepCopy() *Credential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
+func (in *CredentialStatus) DeepCopy() *CredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayProxy) DeepCopyInto(out *GatewayProxy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxy.
@@ -569,6 +772,22 @@ func (in *GatewayProxySpec) DeepCopy() *GatewayProxySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayProxyStatus) DeepCopyInto(out *GatewayProxyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxyStatus.
+func (in *GatewayProxyStatus) DeepCopy() *GatewayProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayRef) DeepCopyInto(out *GatewayRef) {
 	*out = *in
@@ -680,6 +899,18 @@ func (in *HTTPRoutePolicySpec) DeepCopyInto(out *HTTPRoutePolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]Plugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PluginConfigRef != nil {
+		in, out := &in.PluginConfigRef, &out.PluginConfigRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRoutePolicySpec.
@@ -1018,6 +1249,46 @@ func (in *ProviderService) DeepCopy() *ProviderService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutHealthCheck) DeepCopyInto(out *RolloutHealthCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutHealthCheck.
+func (in *RolloutHealthCheck) DeepCopy() *RolloutHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(RolloutHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
 	*out = *in
@@ -1041,6 +1312,11 @@ func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
@@ -1053,6 +1329,31 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionPersistence) DeepCopyInto(out *SessionPersistence) {
+	*out = *in
+	if in.AbsoluteTimeout != nil {
+		in, out := &in.AbsoluteTimeout, &out.AbsoluteTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionPersistence.
+func (in *SessionPersistence) DeepCopy() *SessionPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
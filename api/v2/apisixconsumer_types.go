@@ -36,6 +36,11 @@ type ApisixConsumerSpec struct {
 	// These plugins are applied alongside any authentication plugin derived from AuthParameter.
 	// An enabled plugin with the same name as the auth plugin derived from AuthParameter takes precedence.
 	Plugins []ApisixRoutePlugin `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+
+	// GroupRef references the ConsumerGroup, in the same namespace, that this consumer belongs to.
+	// Plugins configured on this consumer take precedence over those of the group.
+	// +kubebuilder:validation:Optional
+	GroupRef *corev1.LocalObjectReference `json:"groupRef,omitempty" yaml:"groupRef,omitempty"`
 }
 
 // ApisixConsumerStatus defines the observed state of ApisixConsumer.
@@ -28,6 +28,7 @@ import (
 	"gopkg.in/yaml.v3"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -65,6 +66,7 @@ func (f *GatewayConfigsFlag) Type() string {
 func NewRootCmd() *cobra.Command {
 	root := newAPISIXIngressController()
 	root.AddCommand(newVersionCmd())
+	root.AddCommand(newRenderCmd())
 	return root
 }
 
@@ -87,6 +89,67 @@ func newVersionCmd() *cobra.Command {
 
 }
 
+func newRenderCmd() *cobra.Command {
+	var (
+		configPath string
+		logLevel   string
+		opts       = manager.RenderOptions{Output: manager.RenderOutputADC}
+	)
+	cmd := &cobra.Command{
+		Use:   "render [flags] PATH...",
+		Short: "render manifests into the configuration the controller would sync, without a cluster",
+		Long: "Render translates the objects of the given manifest files and directories the way the controller " +
+			"would on a cluster holding just them, and prints the configuration of each GatewayProxy. " +
+			"What became of each object, with its conditions, is reported on the standard error.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configPath != "" {
+				c, err := config.NewConfigFromFile(configPath)
+				if err != nil {
+					return err
+				}
+				config.SetControllerConfig(c)
+			}
+			if err := config.ControllerConfig.Validate(); err != nil {
+				return err
+			}
+
+			// The report says what went wrong, the logs of the controllers only add to it
+			// when asked for.
+			logger := logr.Discard()
+			if logLevel != "" {
+				level, err := zapcore.ParseLevel(logLevel)
+				if err != nil {
+					return err
+				}
+				core := zapcore.NewCore(
+					zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+					zapcore.AddSync(zapcore.Lock(os.Stderr)),
+					level,
+				)
+				logger = zapr.NewLogger(zap.New(core, zap.AddCaller()))
+			}
+			ctrl.SetLogger(logger.WithName("controller-runtime"))
+
+			opts.Paths = args
+			opts.Out = cmd.OutOrStdout()
+			opts.Report = cmd.ErrOrStderr()
+			cmd.SilenceUsage = true
+			return manager.Render(ctrl.LoggerInto(cmd.Context(), logger), logger, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&configPath, "config-path", "c", "",
+		"configuration file path for apisix-ingress-controller, whose controller name, listener port match mode and provider type are rendered for")
+	cmd.Flags().StringVar(&config.ControllerConfig.ControllerName, "controller-name", config.DefaultControllerName,
+		"The name of the controller")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output,
+		"The format of the rendered configuration: adc, or apisix-standalone for an apisix.yaml")
+	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", "",
+		"The directory to write a file per GatewayProxy to, instead of the standard output")
+	cmd.Flags().StringVar(&logLevel, "log-level", "", "The log level of the controllers while rendering, no logs when empty")
+	return cmd
+}
+
 func newAPISIXIngressController() *cobra.Command {
 	cfg := config.ControllerConfig
 	var configPath string
@@ -31,6 +31,8 @@ type Cache interface {
 	InsertService(*types.Service) error
 	// InsertConsumer adds or updates consumer to cache.
 	InsertConsumer(*types.Consumer) error
+	// InsertConsumerGroup adds or updates consumer group to cache.
+	InsertConsumerGroup(*types.ConsumerGroup) error
 	// InsertGlobalRule adds or updates global rule to cache.
 	InsertGlobalRule(*types.GlobalRuleItem) error
 
@@ -40,6 +42,8 @@ type Cache interface {
 	GetService(string) (*types.Service, error)
 	// GetConsumer finds the consumer from cache according to the primary index (username).
 	GetConsumer(string) (*types.Consumer, error)
+	// GetConsumerGroup finds the consumer group from cache according to the primary index (id).
+	GetConsumerGroup(string) (*types.ConsumerGroup, error)
 	// GetGlobalRule finds the global rule from cache according to the primary index (id).
 	GetGlobalRule(string) (*types.GlobalRuleItem, error)
 
@@ -49,6 +53,8 @@ type Cache interface {
 	DeleteService(*types.Service) error
 	// DeleteConsumer deletes the specified consumer in cache.
 	DeleteConsumer(*types.Consumer) error
+	// DeleteConsumerGroup deletes the specified consumer group in cache.
+	DeleteConsumerGroup(*types.ConsumerGroup) error
 	// DeleteGlobalRule deletes the specified global rule in cache.
 	DeleteGlobalRule(*types.GlobalRuleItem) error
 
@@ -58,6 +64,8 @@ type Cache interface {
 	ListServices(...ListOption) ([]*types.Service, error)
 	// ListConsumers lists all consumer objects in cache.
 	ListConsumers(...ListOption) ([]*types.Consumer, error)
+	// ListConsumerGroups lists all consumer group objects in cache.
+	ListConsumerGroups(...ListOption) ([]*types.ConsumerGroup, error)
 	// ListGlobalRules lists all global rule objects in cache.
 	ListGlobalRules(...ListOption) ([]*types.GlobalRuleItem, error)
 }
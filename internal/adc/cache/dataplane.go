@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// DataPlaneChange is one object a sync would write to, or delete from, a data plane
+// endpoint.
+type DataPlaneChange struct {
+	ServerAddr string
+	// Collection is where APISIX keeps the object: routes, services, ssls, consumers, ...
+	Collection string
+	ID         string
+	// Change is "added", "removed" or "changed".
+	Change string
+	// Labels are those of the object, which name the Kubernetes object it was translated
+	// from.
+	Labels map[string]string
+}
+
+// DataPlaneDiff is what a sync of one config would change on its data plane, as found
+// without making it.
+type DataPlaneDiff struct {
+	Timestamp time.Time
+	Changes   []DataPlaneChange
+	// Error is why the data plane could not be compared, if it could not.
+	Error string
+}
+
+// Equal reports whether d and other found the same, whenever they found it.
+func (d DataPlaneDiff) Equal(other DataPlaneDiff) bool {
+	return d.Error == other.Error &&
+		slices.EqualFunc(d.Changes, other.Changes, func(a, b DataPlaneChange) bool {
+			return a.ServerAddr == b.ServerAddr && a.Collection == b.Collection && a.ID == b.ID &&
+				a.Change == b.Change && maps.Equal(a.Labels, b.Labels)
+		})
+}
+
+// RecordDryRun records diff as what the latest dry run of the config named name found. It
+// reports whether that is something else than the dry run before found, so that the same
+// difference is reported once however many syncs find it again.
+func (s *Store) RecordDryRun(name string, diff DataPlaneDiff) bool {
+	return s.recordDiff(s.dryRuns, name, diff)
+}
+
+// GetDryRun returns what the latest dry run of the config named name found.
+func (s *Store) GetDryRun(name string) (DataPlaneDiff, bool) {
+	return s.getDiff(s.dryRuns, name)
+}
+
+// RecordDrift records diff as the drift the latest check found between what the config
+// named name desires and what its data plane holds. Like RecordDryRun, it reports whether
+// that is something else than the check before found.
+func (s *Store) RecordDrift(name string, diff DataPlaneDiff) bool {
+	return s.recordDiff(s.drifts, name, diff)
+}
+
+// GetDrift returns the drift the latest check of the config named name found.
+func (s *Store) GetDrift(name string) (DataPlaneDiff, bool) {
+	return s.getDiff(s.drifts, name)
+}
+
+func (s *Store) recordDiff(diffs map[string]DataPlaneDiff, name string, diff DataPlaneDiff) bool {
+	s.Lock()
+	defer s.Unlock()
+	previous, ok := diffs[name]
+	diffs[name] = diff
+	return !ok || !previous.Equal(diff)
+}
+
+func (s *Store) getDiff(diffs map[string]DataPlaneDiff, name string) (DataPlaneDiff, bool) {
+	s.Lock()
+	defer s.Unlock()
+	diff, ok := diffs[name]
+	return diff, ok
+}
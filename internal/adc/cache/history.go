@@ -0,0 +1,255 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// revisionHistoryLimit bounds how many revisions are kept per config.
+const revisionHistoryLimit = 10
+
+// Revision is the full configuration every data plane endpoint of a config accepted at
+// one point in time.
+type Revision struct {
+	// Version numbers the revisions of a config, starting at 1.
+	Version   int64
+	Timestamp time.Time
+	// Hash is the sha256 of the JSON encoded Resources.
+	Hash string
+	// Objects names the Kubernetes objects whose translation differs from the revision
+	// before, or the type and ID of the translated object when no Kubernetes object is
+	// known for it, as for the merged global rules and plugin metadata.
+	Objects   []string
+	Resources *adctypes.Resources
+}
+
+// RevisionChange is one translated object that differs between two configurations.
+type RevisionChange struct {
+	Type string
+	ID   string
+	// Object is the Kubernetes object the translated one belongs to, if known.
+	Object string
+	// Change is "added", "removed" or "changed".
+	Change string
+	Before string
+	After  string
+}
+
+// RecordRevision records resources as the latest revision of the config named name, unless
+// they are what the latest revision already holds. It reports whether a revision was
+// added, and returns the latest one either way.
+//
+// Revisions share the translated objects with the cache, which replaces them on every
+// insert instead of changing them in place, so an unchanged object costs a revision
+// nothing beyond a pointer.
+func (s *Store) RecordRevision(name string, resources *adctypes.Resources) (Revision, bool, error) {
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return Revision{}, false, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	s.Lock()
+	defer s.Unlock()
+
+	history := s.revisions[name]
+	revision := Revision{
+		Version:   1,
+		Timestamp: time.Now(),
+		Hash:      hash,
+		Resources: resources,
+	}
+	var previous *adctypes.Resources
+	if len(history) > 0 {
+		latest := history[len(history)-1]
+		if latest.Hash == hash {
+			return latest, false, nil
+		}
+		revision.Version = latest.Version + 1
+		previous = latest.Resources
+	}
+	for _, change := range DiffResources(previous, resources) {
+		revision.Objects = append(revision.Objects, cmp.Or(change.Object, change.Type+"/"+change.ID))
+	}
+	slices.Sort(revision.Objects)
+	revision.Objects = slices.Compact(revision.Objects)
+
+	history = append(history, revision)
+	if len(history) > revisionHistoryLimit {
+		history = slices.Delete(history, 0, len(history)-revisionHistoryLimit)
+	}
+	s.revisions[name] = history
+	return revision, true, nil
+}
+
+// Revisions returns the revisions kept for the config named name, oldest first.
+func (s *Store) Revisions(name string) []Revision {
+	s.Lock()
+	defer s.Unlock()
+	return slices.Clone(s.revisions[name])
+}
+
+// GetRevision returns the revision of the config named name with the given version, which
+// is still known while it is kept in the history or pinned.
+func (s *Store) GetRevision(name string, version int64) (Revision, bool) {
+	s.Lock()
+	defer s.Unlock()
+	return s.getRevision(name, version)
+}
+
+func (s *Store) getRevision(name string, version int64) (Revision, bool) {
+	if pinned, ok := s.pins[name]; ok && pinned.Version == version {
+		return pinned, true
+	}
+	for _, revision := range s.revisions[name] {
+		if revision.Version == version {
+			return revision, true
+		}
+	}
+	return Revision{}, false
+}
+
+// LatestRevision returns the last revision recorded for the config named name.
+func (s *Store) LatestRevision(name string) (Revision, bool) {
+	s.Lock()
+	defer s.Unlock()
+	history := s.revisions[name]
+	if len(history) == 0 {
+		return Revision{}, false
+	}
+	return history[len(history)-1], true
+}
+
+// Pin marks the revision of the config named name with the given version as the one its
+// data plane is to be kept on, whatever the config desires, until Unpin.
+func (s *Store) Pin(name string, version int64) (Revision, error) {
+	s.Lock()
+	defer s.Unlock()
+	revision, ok := s.getRevision(name, version)
+	if !ok {
+		return Revision{}, fmt.Errorf("revision %d of config %s not found", version, name)
+	}
+	s.pins[name] = revision
+	return revision, nil
+}
+
+// Unpin lets the data plane of the config named name follow the config again. It reports
+// whether the config was pinned.
+func (s *Store) Unpin(name string) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, ok := s.pins[name]
+	delete(s.pins, name)
+	return ok
+}
+
+// Pinned returns the revision the config named name is pinned to, if any.
+func (s *Store) Pinned(name string) (Revision, bool) {
+	s.Lock()
+	defer s.Unlock()
+	revision, ok := s.pins[name]
+	return revision, ok
+}
+
+// DiffResources lists the translated objects that differ between from and to, either of
+// which may be nil.
+func DiffResources(from, to *adctypes.Resources) []RevisionChange {
+	before, after := indexResources(from), indexResources(to)
+
+	var changes []RevisionChange
+	for key, b := range before {
+		a, ok := after[key]
+		switch {
+		case !ok:
+			changes = append(changes, RevisionChange{Type: key.typ, ID: key.id, Object: b.object, Change: "removed", Before: string(b.data)})
+		case !bytes.Equal(a.data, b.data):
+			changes = append(changes, RevisionChange{Type: key.typ, ID: key.id, Object: cmp.Or(a.object, b.object), Change: "changed", Before: string(b.data), After: string(a.data)})
+		}
+	}
+	for key, a := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, RevisionChange{Type: key.typ, ID: key.id, Object: a.object, Change: "added", After: string(a.data)})
+		}
+	}
+	slices.SortFunc(changes, func(x, y RevisionChange) int {
+		return cmp.Or(cmp.Compare(x.Type, y.Type), cmp.Compare(x.ID, y.ID))
+	})
+	return changes
+}
+
+type indexedKey struct {
+	typ string
+	id  string
+}
+
+type indexedObject struct {
+	object string
+	data   []byte
+}
+
+func indexResources(resources *adctypes.Resources) map[indexedKey]indexedObject {
+	index := make(map[indexedKey]indexedObject)
+	if resources == nil {
+		return index
+	}
+	add := func(typ, id string, labels map[string]string, obj any) {
+		data, _ := json.MarshalIndent(obj, "", "  ")
+		index[indexedKey{typ: typ, id: id}] = indexedObject{object: labels[label.LabelResourceKey], data: data}
+	}
+	for _, service := range resources.Services {
+		add(adctypes.TypeService, service.ID, service.Labels, service)
+	}
+	for _, consumer := range resources.Consumers {
+		add(adctypes.TypeConsumer, consumer.Username, consumer.Labels, consumer)
+	}
+	for _, group := range resources.ConsumerGroups {
+		for i := range group.Consumers {
+			consumer := &group.Consumers[i]
+			// Moving a consumer into another group changes the consumer.
+			add(adctypes.TypeConsumer, consumer.Username, consumer.Labels, struct {
+				*adctypes.Consumer
+				Group string `json:"group"`
+			}{consumer, group.ID})
+		}
+		group := *group
+		group.Consumers = nil
+		add(adctypes.TypeConsumerGroup, group.ID, group.Labels, &group)
+	}
+	for _, ssl := range resources.SSLs {
+		add(adctypes.TypeSSL, ssl.ID, ssl.Labels, ssl)
+	}
+	for name, plugin := range resources.GlobalRules {
+		add(adctypes.TypeGlobalRule, name, nil, plugin)
+	}
+	for name, metadata := range resources.PluginMetadata {
+		add(adctypes.TypePluginMetadata, name, nil, metadata)
+	}
+	return index
+}
@@ -55,6 +55,8 @@ func (c *dbCache) Insert(obj any) error {
 		return c.InsertService(t)
 	case *types.Consumer:
 		return c.InsertConsumer(t)
+	case *types.ConsumerGroup:
+		return c.InsertConsumerGroup(t)
 	case *types.GlobalRuleItem:
 		return c.InsertGlobalRule(t)
 	default:
@@ -72,6 +74,8 @@ func (c *dbCache) Delete(obj any) error {
 		return c.DeleteService(t)
 	case *types.Consumer:
 		return c.DeleteConsumer(t)
+	case *types.ConsumerGroup:
+		return c.DeleteConsumerGroup(t)
 	case *types.GlobalRuleItem:
 		return c.DeleteGlobalRule(t)
 	default:
@@ -96,6 +100,10 @@ func (c *dbCache) InsertConsumer(consumer *types.Consumer) error {
 	return c.insert(types.TypeConsumer, consumer.DeepCopy())
 }
 
+func (c *dbCache) InsertConsumerGroup(group *types.ConsumerGroup) error {
+	return c.insert(types.TypeConsumerGroup, group.DeepCopy())
+}
+
 func (c *dbCache) InsertGlobalRule(globalRule *types.GlobalRuleItem) error {
 	return c.insert(types.TypeGlobalRule, globalRule.DeepCopy())
 }
@@ -142,6 +150,14 @@ func (c *dbCache) GetConsumer(username string) (*types.Consumer, error) {
 	return obj.(*types.Consumer).DeepCopy(), nil
 }
 
+func (c *dbCache) GetConsumerGroup(id string) (*types.ConsumerGroup, error) {
+	obj, err := c.get(types.TypeConsumerGroup, id)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*types.ConsumerGroup).DeepCopy(), nil
+}
+
 func (c *dbCache) GetGlobalRule(id string) (*types.GlobalRuleItem, error) {
 	obj, err := c.get(types.TypeGlobalRule, id)
 	if err != nil {
@@ -222,6 +238,18 @@ func (c *dbCache) ListConsumers(opts ...ListOption) ([]*types.Consumer, error) {
 	return consumers, nil
 }
 
+func (c *dbCache) ListConsumerGroups(opts ...ListOption) ([]*types.ConsumerGroup, error) {
+	raws, err := c.list(types.TypeConsumerGroup, opts...)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*types.ConsumerGroup, 0, len(raws))
+	for _, raw := range raws {
+		groups = append(groups, raw.(*types.ConsumerGroup).DeepCopy())
+	}
+	return groups, nil
+}
+
 func (c *dbCache) ListGlobalRules(opts ...ListOption) ([]*types.GlobalRuleItem, error) {
 	raws, err := c.list(types.TypeGlobalRule, opts...)
 	if err != nil {
@@ -272,6 +300,10 @@ func (c *dbCache) DeleteConsumer(consumer *types.Consumer) error {
 	return c.delete(types.TypeConsumer, consumer)
 }
 
+func (c *dbCache) DeleteConsumerGroup(group *types.ConsumerGroup) error {
+	return c.delete(types.TypeConsumerGroup, group)
+}
+
 func (c *dbCache) DeleteGlobalRule(globalRule *types.GlobalRuleItem) error {
 	return c.delete(types.TypeGlobalRule, globalRule)
 }
@@ -53,6 +53,10 @@ func (c *noopCache) InsertConsumer(consumer *types.Consumer) error {
 	return nil
 }
 
+func (c *noopCache) InsertConsumerGroup(group *types.ConsumerGroup) error {
+	return nil
+}
+
 func (c *noopCache) GetSSL(id string) (*types.SSL, error) {
 	return nil, nil
 }
@@ -69,6 +73,10 @@ func (c *noopCache) GetConsumer(username string) (*types.Consumer, error) {
 	return nil, nil
 }
 
+func (c *noopCache) GetConsumerGroup(id string) (*types.ConsumerGroup, error) {
+	return nil, nil
+}
+
 func (c *noopCache) ListSSL(...ListOption) ([]*types.SSL, error) {
 	return nil, nil
 }
@@ -89,6 +97,10 @@ func (c *noopCache) ListConsumers(...ListOption) ([]*types.Consumer, error) {
 	return nil, nil
 }
 
+func (c *noopCache) ListConsumerGroups(...ListOption) ([]*types.ConsumerGroup, error) {
+	return nil, nil
+}
+
 func (c *noopCache) DeleteSSL(ssl *types.SSL) error {
 	return nil
 }
@@ -104,3 +116,7 @@ func (c *noopCache) DeleteGlobalRule(gr *types.GlobalRuleItem) error {
 func (c *noopCache) DeleteConsumer(consumer *types.Consumer) error {
 	return nil
 }
+
+func (c *noopCache) DeleteConsumerGroup(group *types.ConsumerGroup) error {
+	return nil
+}
@@ -62,6 +62,22 @@ var (
 					},
 				},
 			},
+			"consumer_group": {
+				Name: "consumer_group",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "ID"},
+					},
+					KindLabelIndex: {
+						Name:         KindLabelIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &KindLabelIndexer,
+					},
+				},
+			},
 			"consumer": {
 				Name: "consumer",
 				Indexes: map[string]*memdb.IndexSchema{
@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+// ConfigSnapshot is everything the store holds for one config, with the labels that tell
+// which Kubernetes object each item was translated from.
+type ConfigSnapshot struct {
+	Services       []*adctypes.Service        `json:"services,omitempty"`
+	ConsumerGroups []*adctypes.ConsumerGroup  `json:"consumer_groups,omitempty"`
+	Consumers      []*adctypes.Consumer       `json:"consumers,omitempty"`
+	SSLs           []*adctypes.SSL            `json:"ssls,omitempty"`
+	GlobalRules    []*adctypes.GlobalRuleItem `json:"global_rules,omitempty"`
+	PluginMetadata adctypes.PluginMetadata    `json:"plugin_metadata,omitempty"`
+}
+
+// Snapshot returns what the store holds for the config named name.
+func (s *Store) Snapshot(name string) (*ConfigSnapshot, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	snapshot := &ConfigSnapshot{}
+	if meta, ok := s.pluginMetadataMap[name]; ok {
+		snapshot.PluginMetadata = meta.DeepCopy()
+	}
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return snapshot, nil
+	}
+	var err error
+	if snapshot.Services, err = targetCache.ListServices(); err != nil {
+		return nil, err
+	}
+	if snapshot.Consumers, err = targetCache.ListConsumers(); err != nil {
+		return nil, err
+	}
+	if snapshot.ConsumerGroups, err = targetCache.ListConsumerGroups(); err != nil {
+		return nil, err
+	}
+	// The group of a consumer is not encoded on its own: it is the group the consumer is in.
+	snapshot.Consumers, snapshot.ConsumerGroups = nestConsumers(snapshot.Consumers, snapshot.ConsumerGroups)
+	if snapshot.SSLs, err = targetCache.ListSSL(); err != nil {
+		return nil, err
+	}
+	if snapshot.GlobalRules, err = targetCache.ListGlobalRules(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Restore adds the items of snapshot to what the store holds for the config named name.
+// Items whose labels skip accepts are left out, and so is plugin metadata when the store
+// already holds some for the config: both stand for what was translated since.
+func (s *Store) Restore(name string, snapshot *ConfigSnapshot, skip func(labels map[string]string) bool) error {
+	if snapshot == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.pluginMetadataMap[name]; !ok && snapshot.PluginMetadata != nil {
+		s.pluginMetadataMap[name] = snapshot.PluginMetadata
+	}
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		db, err := NewMemDBCache()
+		if err != nil {
+			return err
+		}
+		s.cacheMap[name] = db
+		targetCache = db
+	}
+	for _, service := range snapshot.Services {
+		if skip(service.Labels) {
+			continue
+		}
+		if err := targetCache.InsertService(service); err != nil {
+			return err
+		}
+	}
+	for _, consumer := range snapshot.Consumers {
+		if skip(consumer.Labels) {
+			continue
+		}
+		if err := targetCache.InsertConsumer(consumer); err != nil {
+			return err
+		}
+	}
+	for _, group := range snapshot.ConsumerGroups {
+		for _, consumer := range group.Consumers {
+			if skip(consumer.Labels) {
+				continue
+			}
+			consumer.GroupID = group.ID
+			if err := targetCache.InsertConsumer(&consumer); err != nil {
+				return err
+			}
+		}
+		// A group is translated with its consumers, so the one already held is newer.
+		if _, err := targetCache.GetConsumerGroup(group.ID); err == nil {
+			continue
+		}
+		group := *group
+		group.Consumers = nil
+		if err := targetCache.InsertConsumerGroup(&group); err != nil {
+			return err
+		}
+	}
+	for _, ssl := range snapshot.SSLs {
+		if skip(ssl.Labels) {
+			continue
+		}
+		if err := targetCache.InsertSSL(ssl); err != nil {
+			return err
+		}
+	}
+	for _, globalRule := range snapshot.GlobalRules {
+		if skip(globalRule.Labels) {
+			continue
+		}
+		if err := targetCache.InsertGlobalRule(globalRule); err != nil {
+			return err
+		}
+	}
+	return pruneConsumerGroups(targetCache)
+}
@@ -19,6 +19,7 @@ package cache
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/go-logr/logr"
@@ -31,6 +32,15 @@ import (
 type Store struct {
 	cacheMap          map[string]Cache
 	pluginMetadataMap map[string]adctypes.PluginMetadata
+	// revisions holds, per config, the last revisionHistoryLimit configurations that every
+	// data plane endpoint accepted in full, see RecordRevision.
+	revisions map[string][]Revision
+	// pins holds the revision each pinned config keeps its data plane on, see Pin.
+	pins map[string]Revision
+	// dryRuns holds, per config, what the last dry run found, see RecordDryRun.
+	dryRuns map[string]DataPlaneDiff
+	// drifts holds, per config, what the last drift check found, see RecordDrift.
+	drifts map[string]DataPlaneDiff
 
 	sync.Mutex
 	log logr.Logger
@@ -40,6 +50,10 @@ func NewStore(log logr.Logger) *Store {
 	return &Store{
 		cacheMap:          make(map[string]Cache),
 		pluginMetadataMap: make(map[string]adctypes.PluginMetadata),
+		revisions:         make(map[string][]Revision),
+		pins:              make(map[string]Revision),
+		dryRuns:           make(map[string]DataPlaneDiff),
+		drifts:            make(map[string]DataPlaneDiff),
 		log:               log,
 	}
 }
@@ -94,6 +108,32 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 					return err
 				}
 			}
+			for _, group := range resources.ConsumerGroups {
+				for _, consumer := range group.Consumers {
+					consumer.GroupID = group.ID
+					if err := targetCache.InsertConsumer(&consumer); err != nil {
+						return err
+					}
+				}
+			}
+		case adctypes.TypeConsumerGroup:
+			groups, err := targetCache.ListConsumerGroups(selector)
+			if err != nil {
+				return err
+			}
+			for _, group := range groups {
+				if err := targetCache.DeleteConsumerGroup(group); err != nil {
+					return err
+				}
+			}
+			// The consumers of a group are kept on their own, see TypeConsumer.
+			for _, group := range resources.ConsumerGroups {
+				group := *group
+				group.Consumers = nil
+				if err := targetCache.InsertConsumerGroup(&group); err != nil {
+					return err
+				}
+			}
 		case adctypes.TypeSSL:
 			ssls, err := targetCache.ListSSL(selector)
 			if err != nil {
@@ -143,6 +183,9 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 			continue
 		}
 	}
+	if slices.Contains(resourceTypes, adctypes.TypeConsumer) {
+		return pruneConsumerGroups(targetCache)
+	}
 	return nil
 }
 
@@ -190,6 +233,19 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 					s.log.Error(err, "failed to delete consumer", "consumer", consumer.Username)
 				}
 			}
+			if err := pruneConsumerGroups(targetCache); err != nil {
+				s.log.Error(err, "failed to prune consumer groups")
+			}
+		case adctypes.TypeConsumerGroup:
+			groups, err := targetCache.ListConsumerGroups(selector)
+			if err != nil {
+				s.log.Error(err, "failed to list consumer groups")
+			}
+			for _, group := range groups {
+				if err := targetCache.DeleteConsumerGroup(group); err != nil {
+					s.log.Error(err, "failed to delete consumer group", "consumer group", group.ID)
+				}
+			}
 		case adctypes.TypeGlobalRule:
 			globalRules, err := targetCache.ListGlobalRules(selector)
 			if err != nil {
@@ -206,6 +262,10 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 	}
 	if len(resourceTypes) == 0 {
 		delete(s.cacheMap, name)
+		delete(s.revisions, name)
+		delete(s.pins, name)
+		delete(s.dryRuns, name)
+		delete(s.drifts, name)
 	}
 	return nil
 }
@@ -235,9 +295,12 @@ func (s *Store) GetResources(name string) (*adctypes.Resources, error) {
 		metadata = meta.DeepCopy()
 	}
 	consumers, _ := targetCache.ListConsumers()
+	groups, _ := targetCache.ListConsumerGroups()
+	consumers, groups = nestConsumers(consumers, groups)
 	services, _ := targetCache.ListServices()
 	ssls, _ := targetCache.ListSSL()
 	return &adctypes.Resources{
+		ConsumerGroups: groups,
 		Consumers:      consumers,
 		Services:       services,
 		SSLs:           ssls,
@@ -304,6 +367,14 @@ func (s *Store) GetResourceLabel(name, resourceType string, id string) (map[stri
 		if consumer != nil {
 			return consumer.Labels, nil
 		}
+	case adctypes.TypeConsumerGroup:
+		group, err := targetCache.GetConsumerGroup(id)
+		if err != nil {
+			return nil, err
+		}
+		if group != nil {
+			return group.Labels, nil
+		}
 	case adctypes.TypeGlobalRule:
 		globalRule, err := targetCache.GetGlobalRule(id)
 		if err != nil {
@@ -317,3 +388,49 @@ func (s *Store) GetResourceLabel(name, resourceType string, id string) (map[stri
 	}
 	return nil, nil
 }
+
+// nestConsumers nests each consumer in the group it belongs to, the way ADC lays them out,
+// and returns the consumers left out of any group with the groups. A consumer whose group
+// the cache does not hold is left out of any group.
+func nestConsumers(consumers []*adctypes.Consumer, groups []*adctypes.ConsumerGroup) ([]*adctypes.Consumer, []*adctypes.ConsumerGroup) {
+	byID := make(map[string]*adctypes.ConsumerGroup, len(groups))
+	for _, group := range groups {
+		byID[group.ID] = group
+	}
+	var ungrouped []*adctypes.Consumer
+	for _, consumer := range consumers {
+		group, ok := byID[consumer.GroupID]
+		if !ok {
+			ungrouped = append(ungrouped, consumer)
+			continue
+		}
+		group.Consumers = append(group.Consumers, *consumer)
+	}
+	return ungrouped, groups
+}
+
+// pruneConsumerGroups deletes the consumer groups no consumer belongs to anymore. A group
+// is only kept for its consumers: it is translated with each of them.
+func pruneConsumerGroups(c Cache) error {
+	groups, err := c.ListConsumerGroups()
+	if err != nil || len(groups) == 0 {
+		return err
+	}
+	consumers, err := c.ListConsumers()
+	if err != nil {
+		return err
+	}
+	inUse := make(map[string]bool, len(groups))
+	for _, consumer := range consumers {
+		inUse[consumer.GroupID] = true
+	}
+	for _, group := range groups {
+		if inUse[group.ID] {
+			continue
+		}
+		if err := c.DeleteConsumerGroup(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// BatchResult is the outcome of syncing one batch of a config.
+type BatchResult struct {
+	ConfigName string
+	// Objects are the Kubernetes objects whose changes the batch coalesced.
+	Objects []types.NamespacedNameKind
+	// Err is what the sync returned, types.ADCExecutionErrors when the data plane refused
+	// the configuration.
+	Err error
+}
+
+// batcher coalesces the changes Update and Delete make to the store into batches, one per
+// config, each synced as a whole once its window passed or it holds maxSize objects.
+type batcher struct {
+	window  time.Duration
+	maxSize int
+
+	// ready is signalled whenever a batch may have become due, see RunBatches.
+	ready chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*batch
+	// flushing holds the configs with a batch being synced. The next batch of such a config
+	// waits for it, collecting more changes meanwhile.
+	flushing map[string]struct{}
+}
+
+type batch struct {
+	config  adctypes.Config
+	objects map[types.NamespacedNameKind]struct{}
+	opened  time.Time
+	timer   *time.Timer
+	due     bool
+}
+
+// EnableBatching makes Update and Delete return as soon as the store holds the change, and
+// leaves the sync to RunBatches: the changes to each config are coalesced until window
+// passed since the first of them, or until they concern maxSize objects, and then synced
+// in one go. A maxSize of zero bounds batches by the window alone.
+//
+// Status of the objects a batch coalesced can no longer come back from Update, so it is
+// handed to OnBatchSynced instead.
+//
+// Only the api7ee provider enables batching: it is the one that syncs every object as it
+// changes. The apisix and apisix-standalone providers already only store a change with
+// UpdateConfig and coalesce the changes into full syncs of their own.
+func (c *Client) EnableBatching(window time.Duration, maxSize int) {
+	c.batcher = &batcher{
+		window:   window,
+		maxSize:  maxSize,
+		ready:    make(chan struct{}, 1),
+		pending:  make(map[string]*batch),
+		flushing: make(map[string]struct{}),
+	}
+}
+
+// RunBatches syncs the batches as they become due until ctx is done. Batches of different
+// configs are synced side by side.
+func (c *Client) RunBatches(ctx context.Context) {
+	b := c.batcher
+	if b == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.ready:
+		}
+		for _, due := range b.takeDue() {
+			go c.flushBatch(ctx, due)
+		}
+	}
+}
+
+// add records that key changed the configs of delta.
+func (b *batcher) add(key types.NamespacedNameKind, delta StoreDelta) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, configs := range []map[types.NamespacedNameKind]adctypes.Config{delta.Deleted, delta.Applied} {
+		for _, config := range configs {
+			pending, ok := b.pending[config.Name]
+			if !ok {
+				pending = &batch{
+					objects: make(map[types.NamespacedNameKind]struct{}),
+					opened:  time.Now(),
+				}
+				name := config.Name
+				pending.timer = time.AfterFunc(b.window, func() { b.markDue(name, pending) })
+				b.pending[name] = pending
+			}
+			// The latest config says where the batch goes, and a config the change removed
+			// still needs it to learn of the removal.
+			pending.config = config
+			pending.objects[key] = struct{}{}
+			if b.maxSize > 0 && len(pending.objects) >= b.maxSize && !pending.due {
+				pending.timer.Stop()
+				pending.due = true
+				b.notify()
+			}
+		}
+	}
+}
+
+func (b *batcher) markDue(name string, due *batch) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// The batch may have filled up and been taken already.
+	if b.pending[name] == due {
+		due.due = true
+		b.notify()
+	}
+}
+
+// takeDue removes the batches that are due and whose config has no batch being synced, and
+// marks their configs as being synced.
+func (b *batcher) takeDue() []*batch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var due []*batch
+	for name, pending := range b.pending {
+		if !pending.due {
+			continue
+		}
+		if _, ok := b.flushing[name]; ok {
+			continue
+		}
+		delete(b.pending, name)
+		b.flushing[name] = struct{}{}
+		due = append(due, pending)
+	}
+	return due
+}
+
+func (b *batcher) done(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.flushing, name)
+	if pending, ok := b.pending[name]; ok && pending.due {
+		b.notify()
+	}
+}
+
+func (b *batcher) notify() {
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// flushBatch syncs everything the store holds for the config of due, which carries every
+// change the batch coalesced, and hands the outcome to OnBatchSynced.
+func (c *Client) flushBatch(ctx context.Context, due *batch) {
+	name := due.config.Name
+	defer c.batcher.done(name)
+
+	result := BatchResult{
+		ConfigName: name,
+		Objects:    slices.SortedFunc(maps.Keys(due.objects), compareNamespacedNameKind),
+	}
+	result.Err = c.syncBatch(ctx, due.config)
+
+	status := adctypes.StatusSuccess
+	if result.Err != nil {
+		status = "failure"
+		c.log.Error(result.Err, "failed to sync batch", "config", name, "objects", len(result.Objects))
+	}
+	pkgmetrics.RecordBatch(name, status, len(result.Objects), time.Since(due.opened).Seconds())
+
+	if c.OnBatchSynced != nil {
+		c.OnBatchSynced(ctx, result)
+	}
+}
+
+func (c *Client) syncBatch(ctx context.Context, config adctypes.Config) error {
+	c.syncMu.RLock()
+	defer c.syncMu.RUnlock()
+
+	// A pinned data plane stays on its revision until it is unpinned.
+	if _, ok := c.Pinned(config.Name); ok {
+		return nil
+	}
+	resources, err := c.GetResources(config.Name)
+	if err != nil {
+		return err
+	}
+	return c.sync(ctx, Task{
+		Name: config.Name + "-batch",
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{}: config,
+		},
+		Resources: resources,
+	})
+}
+
+func compareNamespacedNameKind(a, b types.NamespacedNameKind) int {
+	return strings.Compare(a.String(), b.String())
+}
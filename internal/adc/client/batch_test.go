@@ -0,0 +1,109 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func batchTask(name string) Task {
+	return Task{
+		Key:  types.NamespacedNameKind{Namespace: "ns", Name: name, Kind: types.KindHTTPRoute},
+		Name: name,
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{}: {Name: "GatewayProxy/ns/name", BackendType: "apisix", ServerAddrs: []string{"http://apisix-0:9180"}},
+		},
+		Labels:        map[string]string{"k8s/kind": types.KindHTTPRoute, "k8s/namespace": "ns", "k8s/name": name},
+		ResourceTypes: []string{adctypes.TypeService},
+		Resources: &adctypes.Resources{
+			Services: []*adctypes.Service{{Metadata: adctypes.Metadata{ID: name, Name: name}}},
+		},
+	}
+}
+
+// runBatches runs the batches of c until the test ends, and returns what they synced.
+func runBatches(t *testing.T, c *Client) <-chan BatchResult {
+	results := make(chan BatchResult, 10)
+	c.OnBatchSynced = func(_ context.Context, result BatchResult) { results <- result }
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go c.RunBatches(ctx)
+	return results
+}
+
+func nextBatch(t *testing.T, results <-chan BatchResult) BatchResult {
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no batch was synced")
+		return BatchResult{}
+	}
+}
+
+func TestClientBatchesUpdatesUpToMaxSize(t *testing.T) {
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	c.EnableBatching(time.Hour, 3)
+	results := runBatches(t, c)
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, c.Update(context.Background(), batchTask(name)))
+	}
+
+	result := nextBatch(t, results)
+	require.NoError(t, result.Err)
+	assert.Equal(t, "GatewayProxy/ns/name", result.ConfigName)
+	assert.Equal(t, []types.NamespacedNameKind{
+		batchTask("a").Key, batchTask("b").Key, batchTask("c").Key,
+	}, result.Objects)
+
+	exec.mu.Lock()
+	defer exec.mu.Unlock()
+	require.Len(t, exec.pushes, 1, "three updates are one sync")
+	slices.Sort(exec.pushes[0].services)
+	assert.Equal(t, []string{"a", "b", "c"}, exec.pushes[0].services)
+}
+
+func TestClientBatchReportsWhatTheDataPlaneRefused(t *testing.T) {
+	exec := &recordingExecutor{reject: map[string]bool{"http://apisix-0:9180": true}}
+	c := newTestClient(exec)
+	c.EnableBatching(10*time.Millisecond, 0)
+	results := runBatches(t, c)
+
+	require.NoError(t, c.Update(context.Background(), batchTask("a")))
+	require.NoError(t, c.Delete(context.Background(), batchTask("b")),
+		"Update and Delete return before the data plane is synced")
+
+	result := nextBatch(t, results)
+	var execErrs types.ADCExecutionErrors
+	require.True(t, errors.As(result.Err, &execErrs))
+	assert.Equal(t, "GatewayProxy/ns/name", execErrs.Errors[0].Name)
+	// The object removed before it was ever synced has no config to sync.
+	assert.Equal(t, []types.NamespacedNameKind{batchTask("a").Key}, result.Objects)
+}
@@ -0,0 +1,196 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// circuit is the circuit breaker of one config.
+type circuit struct {
+	state types.CircuitState
+	// failures counts the pushes in a row the data plane did not answer.
+	failures int
+	// retryAt is when an open circuit lets the next push through as a probe.
+	retryAt time.Time
+}
+
+// EnableCircuitBreaker opens the circuit of a config once threshold pushes in a row found
+// its data plane unreachable, see unreachable. An open circuit skips the pushes to the
+// config, which only go to the store, until cooldown passed. The next push then probes the
+// data plane: if it answers, the circuit closes and the config is synced in full to catch
+// up with what was skipped, otherwise the circuit opens for another cooldown.
+func (c *Client) EnableCircuitBreaker(threshold int, cooldown time.Duration) {
+	c.circuitMu.Lock()
+	defer c.circuitMu.Unlock()
+	c.circuitThreshold = threshold
+	c.circuitCooldown = cooldown
+	c.circuits = make(map[string]*circuit)
+}
+
+// circuitOpen reports whether the circuit of the config named name skips its pushes.
+func (c *Client) circuitOpen(name string) bool {
+	c.circuitMu.Lock()
+	defer c.circuitMu.Unlock()
+	cb, ok := c.circuits[name]
+	return ok && cb.state != types.CircuitClosed
+}
+
+// rolloutThroughCircuit rolls config out unless its circuit holds the push back, and
+// catches the data plane up with what the open circuit skipped once the circuit closes.
+func (c *Client) rolloutThroughCircuit(ctx context.Context, config adctypes.Config, task Task, push pushFunc) ([]types.ADCExecutionError, error) {
+	if err := c.admit(ctx, config); err != nil {
+		return nil, err
+	}
+	alsoReport, err := c.rollout(ctx, config, task, push)
+	if c.observe(ctx, config, err) && (len(task.ResourceTypes) > 0 || len(task.Labels) > 0) {
+		// The push that closed the circuit carried part of the configuration.
+		if err := c.catchUp(ctx, config); err != nil {
+			c.log.Error(err, "failed to catch up with the pushes the open circuit skipped", "config", config.Name)
+		}
+	}
+	return alsoReport, err
+}
+
+// admit returns the error to report for a push to config its circuit holds back, or nil to
+// let the push through.
+func (c *Client) admit(ctx context.Context, config adctypes.Config) error {
+	c.circuitMu.Lock()
+	cb, ok := c.circuits[config.Name]
+	if !ok || cb.state == types.CircuitClosed {
+		c.circuitMu.Unlock()
+		return nil
+	}
+	if cb.state == types.CircuitOpen && !time.Now().Before(cb.retryAt) {
+		cb.state = types.CircuitHalfOpen
+		c.circuitMu.Unlock()
+		c.circuitChanged(ctx, config.Name, types.CircuitHalfOpen, "probing whether the data plane answers again")
+		return nil
+	}
+	retryAt := cb.retryAt
+	c.circuitMu.Unlock()
+
+	pkgmetrics.RecordCircuitSkipped(config.Name)
+	execErr := types.ADCExecutionError{Name: config.Name}
+	for _, serverAddr := range config.ServerAddrs {
+		execErr.FailedErrors = append(execErr.FailedErrors, types.ADCExecutionServerAddrError{
+			ServerAddr: serverAddr,
+			Err:        "circuit breaker open, data plane unreachable, push skipped until " + retryAt.Format(time.RFC3339),
+		})
+	}
+	return execErr
+}
+
+// observe records how a push to config went, and reports whether that closed its circuit.
+func (c *Client) observe(ctx context.Context, config adctypes.Config, err error) bool {
+	c.circuitMu.Lock()
+	if c.circuits == nil {
+		c.circuitMu.Unlock()
+		return false
+	}
+	cb, ok := c.circuits[config.Name]
+	if !ok {
+		cb = &circuit{state: types.CircuitClosed}
+		c.circuits[config.Name] = cb
+	}
+	previous := cb.state
+	if unreachable(config, err) {
+		cb.failures++
+		if cb.state == types.CircuitHalfOpen || cb.failures >= c.circuitThreshold {
+			cb.state = types.CircuitOpen
+			cb.retryAt = time.Now().Add(c.circuitCooldown)
+		}
+	} else {
+		// A data plane that refuses a configuration still answers.
+		cb.failures = 0
+		cb.state = types.CircuitClosed
+	}
+	state, failures, retryAt := cb.state, cb.failures, cb.retryAt
+	c.circuitMu.Unlock()
+
+	if state == previous {
+		return false
+	}
+	message := "the data plane answers"
+	if state == types.CircuitOpen {
+		message = fmt.Sprintf("%d pushes in a row found the data plane unreachable, pushes are skipped until %s: %s",
+			failures, retryAt.Format(time.RFC3339), err.Error())
+	}
+	c.circuitChanged(ctx, config.Name, state, message)
+	return state == types.CircuitClosed
+}
+
+func (c *Client) circuitChanged(ctx context.Context, name string, state types.CircuitState, message string) {
+	c.log.Info("circuit breaker changed state", "config", name, "state", state, "message", message)
+	pkgmetrics.RecordCircuitState(name, string(state))
+	if c.OnCircuitChange != nil {
+		c.OnCircuitChange(ctx, name, state, message)
+	}
+}
+
+// catchUp syncs everything the store holds for config.
+func (c *Client) catchUp(ctx context.Context, config adctypes.Config) error {
+	resources, err := c.GetResources(config.Name)
+	if err != nil {
+		return err
+	}
+	if pinned, ok := c.Pinned(config.Name); ok {
+		resources = pinned.Resources
+	}
+	return c.sync(ctx, Task{
+		Name: config.Name + "-catch-up",
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{}: config,
+		},
+		Resources: resources,
+	})
+}
+
+// unreachable reports whether err says the data plane of config did not answer: every
+// endpoint failed, and none named an object it refused.
+func unreachable(config adctypes.Config, err error) bool {
+	var execErr types.ADCExecutionError
+	if !errors.As(err, &execErr) || len(config.ServerAddrs) == 0 {
+		return false
+	}
+	failed := make(map[string]struct{})
+	for _, serverErr := range execErr.FailedErrors {
+		if len(serverErr.FailedStatuses) > 0 {
+			return false
+		}
+		// The ADC server syncs the endpoints of a standalone config in one request.
+		for _, serverAddr := range strings.Split(serverErr.ServerAddr, ",") {
+			failed[serverAddr] = struct{}{}
+		}
+	}
+	for _, serverAddr := range config.ServerAddrs {
+		if _, ok := failed[serverAddr]; !ok {
+			return false
+		}
+	}
+	return true
+}
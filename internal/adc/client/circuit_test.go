@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// outageExecutor fails every endpoint the way a data plane that does not answer does while
+// down is set, or refuses the configuration while refuse is set.
+type outageExecutor struct {
+	mu     sync.Mutex
+	calls  int
+	down   bool
+	refuse bool
+}
+
+func (e *outageExecutor) Execute(_ context.Context, config adctypes.Config, _ []string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	if !e.down && !e.refuse {
+		return nil
+	}
+	execErr := types.ADCExecutionError{Name: config.Name}
+	for _, serverAddr := range config.ServerAddrs {
+		serverErr := types.ADCExecutionServerAddrError{ServerAddr: serverAddr, Err: "context deadline exceeded"}
+		if e.refuse {
+			serverErr.Err = "invalid plugin configuration"
+			serverErr.FailedStatuses = []adctypes.SyncStatus{{Reason: serverErr.Err}}
+		}
+		execErr.FailedErrors = append(execErr.FailedErrors, serverErr)
+	}
+	return execErr
+}
+
+func (e *outageExecutor) Validate(context.Context, adctypes.Config, []string) error { return nil }
+
+func (e *outageExecutor) callCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+func TestClientCircuitBreakerSkipsPushesToAnUnreachableDataPlane(t *testing.T) {
+	exec := &outageExecutor{down: true}
+	c := newTestClient(exec)
+	c.EnableCircuitBreaker(2, time.Hour)
+	var states []types.CircuitState
+	c.OnCircuitChange = func(_ context.Context, name string, state types.CircuitState, _ string) {
+		assert.Equal(t, "GatewayProxy/ns/name", name)
+		states = append(states, state)
+	}
+	ctx := context.Background()
+
+	require.Error(t, c.sync(ctx, rolloutTask(nil, "v1")))
+	assert.Empty(t, states, "one failure does not open the circuit")
+	require.Error(t, c.sync(ctx, rolloutTask(nil, "v1")))
+	assert.Equal(t, []types.CircuitState{types.CircuitOpen}, states)
+
+	err := c.sync(ctx, rolloutTask(nil, "v2"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circuit breaker open")
+	assert.Equal(t, 2, exec.callCount(), "an open circuit skips the push")
+
+	// The cooldown passes and the data plane is back. The push that probes it carries part
+	// of the configuration, so the rest follows in full.
+	c.circuits["GatewayProxy/ns/name"].retryAt = time.Now()
+	exec.down = false
+	task := rolloutTask(nil, "v3")
+	task.ResourceTypes = []string{adctypes.TypeService}
+	require.NoError(t, c.sync(ctx, task))
+	assert.Equal(t, []types.CircuitState{types.CircuitOpen, types.CircuitHalfOpen, types.CircuitClosed}, states)
+	assert.Equal(t, 4, exec.callCount(), "the probe and the catch up sync")
+}
+
+func TestClientCircuitBreakerReopensWhenTheProbeFails(t *testing.T) {
+	exec := &outageExecutor{down: true}
+	c := newTestClient(exec)
+	c.EnableCircuitBreaker(1, time.Hour)
+	var states []types.CircuitState
+	c.OnCircuitChange = func(_ context.Context, _ string, state types.CircuitState, _ string) {
+		states = append(states, state)
+	}
+	ctx := context.Background()
+
+	require.Error(t, c.sync(ctx, rolloutTask(nil, "v1")))
+	c.circuits["GatewayProxy/ns/name"].retryAt = time.Now()
+	require.Error(t, c.sync(ctx, rolloutTask(nil, "v1")))
+
+	assert.Equal(t, []types.CircuitState{types.CircuitOpen, types.CircuitHalfOpen, types.CircuitOpen}, states)
+	assert.True(t, c.circuitOpen("GatewayProxy/ns/name"))
+}
+
+func TestClientCircuitBreakerIgnoresARefusedConfiguration(t *testing.T) {
+	exec := &outageExecutor{refuse: true}
+	c := newTestClient(exec)
+	c.EnableCircuitBreaker(1, time.Hour)
+
+	for range 3 {
+		require.Error(t, c.sync(context.Background(), rolloutTask(nil, "v1")))
+	}
+	assert.Equal(t, 3, exec.callCount(), "a data plane that refuses a configuration answers")
+	assert.False(t, c.circuitOpen("GatewayProxy/ns/name"))
+}
+
+func TestClientSyncLeavesOpenCircuitsToTheCircuitBreaker(t *testing.T) {
+	exec := &outageExecutor{down: true}
+	c := newTestClient(exec)
+	c.EnableCircuitBreaker(1, time.Hour)
+	task := rolloutTask(nil, "v1")
+	task.Key = types.NamespacedNameKind{Namespace: "ns", Name: "httpbin", Kind: types.KindHTTPRoute}
+	require.NoError(t, c.UpdateConfig(context.Background(), task))
+
+	failed, err := c.Sync(context.Background())
+	require.NoError(t, err, "retrying the sync would not reach the data plane sooner")
+	assert.Contains(t, failed, "GatewayProxy/ns/name", "the objects still report the failure")
+}
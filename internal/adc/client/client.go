@@ -18,9 +18,11 @@
 package client
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -32,6 +34,7 @@ import (
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 	"github.com/apache/apisix-ingress-controller/internal/types"
@@ -44,11 +47,40 @@ type Client struct {
 	*cache.Store
 
 	executor ADCExecutor
+	// nativeExecutor pushes the configs that select the native executor, see executorFor.
+	nativeExecutor ADCExecutor
 
 	ConfigManager    *common.ConfigManager[types.NamespacedNameKind, adctypes.Config]
 	ADCDebugProvider *common.ADCDebugProvider
 
-	defaultMode string
+	defaultMode     string
+	defaultExecutor string
+	// concurrency bounds how many configs are synced at once, see forEachBounded.
+	concurrency int
+	// dryRun diffs every config that does not say otherwise instead of pushing it, see diff.
+	dryRun bool
+
+	// OnDryRun, when set, is called with every dry run that found something else than the
+	// one before it for the same config.
+	OnDryRun func(ctx context.Context, name string, diff cache.DataPlaneDiff)
+	// OnDrift, when set, is called with every drift check that found a drift other than the
+	// one before it for the same config, see DetectDrift.
+	OnDrift func(ctx context.Context, name string, diff cache.DataPlaneDiff)
+	// OnBatchSynced, when set, is called with the outcome of every batch, see
+	// EnableBatching.
+	OnBatchSynced func(ctx context.Context, result BatchResult)
+	// OnCircuitChange, when set, is called whenever the circuit breaker of a config changes
+	// state, see EnableCircuitBreaker.
+	OnCircuitChange func(ctx context.Context, name string, state types.CircuitState, message string)
+
+	// batcher, when set, coalesces what Update and Delete change into batches.
+	batcher *batcher
+
+	// circuitMu guards circuits, which is nil unless EnableCircuitBreaker was called.
+	circuitMu        sync.Mutex
+	circuits         map[string]*circuit
+	circuitThreshold int
+	circuitCooldown  time.Duration
 
 	// rebuiltMu guards rebuiltBaselines.
 	rebuiltMu sync.Mutex
@@ -60,7 +92,10 @@ type Client struct {
 	log logr.Logger
 }
 
-func New(log logr.Logger, defaultMode string, timeout time.Duration) (*Client, error) {
+// New creates a Client whose executors give every data plane endpoint timeout to sync, and
+// which syncs at most concurrency configs, and endpoints of each config, at once. With
+// dryRun, it never writes to a data plane unless a config says so, see diff.
+func New(log logr.Logger, defaultMode, defaultExecutor string, timeout time.Duration, concurrency int, dryRun bool) (*Client, error) {
 	serverURL := os.Getenv("ADC_SERVER_URL")
 	if serverURL == "" {
 		serverURL = defaultHTTPADCExecutorAddr
@@ -72,15 +107,40 @@ func New(log logr.Logger, defaultMode string, timeout time.Duration) (*Client, e
 	logger := log.WithName("client")
 	logger.Info("ADC client initialized")
 
-	return &Client{
+	c := &Client{
 		Store:            store,
 		rebuiltBaselines: make(map[string]struct{}),
-		executor:         NewHTTPADCExecutor(log, serverURL, timeout),
+		executor:         NewHTTPADCExecutor(log, serverURL, timeout, concurrency),
+		nativeExecutor:   NewNativeExecutor(log, timeout, concurrency),
 		ConfigManager:    configManager,
-		ADCDebugProvider: common.NewADCDebugProvider(store, configManager),
 		log:              logger,
 		defaultMode:      defaultMode,
-	}, nil
+		defaultExecutor:  defaultExecutor,
+		concurrency:      concurrency,
+		dryRun:           dryRun,
+	}
+	c.ADCDebugProvider = common.NewADCDebugProvider(store, configManager, c)
+	return c, nil
+}
+
+// executorFor returns the executor that pushes cfg: the native one when the config, or
+// failing that the client, selects it, and the ADC server otherwise. The native executor
+// only speaks to APISIX: a client or config for any other backend, such as api7ee, falls
+// back to the ADC server.
+func (c *Client) executorFor(cfg adctypes.Config) ADCExecutor {
+	executor := cfg.Executor
+	if executor == "" {
+		executor = c.defaultExecutor
+	}
+	for _, backend := range []string{c.defaultMode, cmp.Or(cfg.BackendType, c.defaultMode)} {
+		if backend != backendAPISIX && backend != backendAPISIXStandalone {
+			return c.executor
+		}
+	}
+	if executor == string(config.ExecutorTypeNative) && c.nativeExecutor != nil {
+		return c.nativeExecutor
+	}
+	return c.executor
 }
 
 // InvalidateADCCache forgets which ADC baselines are known to be current, so that the
@@ -112,6 +172,12 @@ func (c *Client) markBaselineCurrent(cacheKey string) {
 	c.rebuiltBaselines[cacheKey] = struct{}{}
 }
 
+func (c *Client) forgetBaseline(cacheKey string) {
+	c.rebuiltMu.Lock()
+	defer c.rebuiltMu.Unlock()
+	delete(c.rebuiltBaselines, cacheKey)
+}
+
 // isConfVersionRejection reports whether the data plane refused the push because of a
 // conf_version, which is the one rejection re-deriving the baseline can answer.
 //
@@ -199,6 +265,10 @@ func (c *Client) applySync(ctx context.Context, args Task, delta StoreDelta) err
 	c.syncMu.RLock()
 	defer c.syncMu.RUnlock()
 
+	// A pinned data plane stays on its revision until it is unpinned.
+	delta.Deleted = c.unpinned(delta.Deleted)
+	delta.Applied = c.unpinned(delta.Applied)
+
 	if len(delta.Deleted) > 0 {
 		if err := c.sync(ctx, Task{
 			Name:          args.Name,
@@ -227,6 +297,10 @@ func (c *Client) Update(ctx context.Context, args Task) error {
 	if err != nil {
 		return err
 	}
+	if c.batcher != nil {
+		c.batcher.add(args.Key, delta)
+		return nil
+	}
 	return c.applySync(ctx, args, delta)
 }
 
@@ -240,6 +314,10 @@ func (c *Client) Delete(ctx context.Context, args Task) error {
 	if err != nil {
 		return err
 	}
+	if c.batcher != nil {
+		c.batcher.add(args.Key, delta)
+		return nil
+	}
 	return c.applySync(ctx, args, delta)
 }
 
@@ -269,7 +347,11 @@ func (c *Client) Validate(ctx context.Context, task Task) error {
 		if config.BackendType == "" {
 			config.BackendType = c.defaultMode
 		}
-		if err := c.executor.Validate(ctx, config, args); err != nil {
+		if err := c.executorFor(config).Validate(ctx, config, args); err != nil {
+			if errors.Is(err, ErrValidationNotSupported) {
+				c.log.V(1).Info("skipping validation", "config", config.Name, "reason", err.Error())
+				continue
+			}
 			var validationErr types.ADCValidationError
 			if errors.As(err, &validationErr) {
 				errs.Errors = append(errs.Errors, validationErr)
@@ -285,12 +367,29 @@ func (c *Client) Validate(ctx context.Context, task Task) error {
 	return nil
 }
 
+// ListResources returns what the store holds for each config, by config name: what a full
+// sync would push.
+func (c *Client) ListResources() (map[string]*adctypes.Resources, error) {
+	resources := make(map[string]*adctypes.Resources)
+	for _, config := range c.ConfigManager.List() {
+		if _, ok := resources[config.Name]; ok {
+			continue
+		}
+		configResources, err := c.GetResources(config.Name)
+		if err != nil {
+			return nil, err
+		}
+		resources[config.Name] = configResources
+	}
+	return resources, nil
+}
+
 func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors, error) {
 	c.syncMu.Lock()
 	defer c.syncMu.Unlock()
 	c.log.Info("syncing all resources")
 
-	configs := c.ConfigManager.List()
+	configs := slices.Collect(maps.Values(c.ConfigManager.List()))
 
 	if len(configs) == 0 {
 		c.log.Info("no GatewayProxy configs provided")
@@ -299,18 +398,24 @@ func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors,
 
 	c.log.V(1).Info("syncing resources with multiple configs", "configs", configs)
 
-	failedMap := map[string]types.ADCExecutionErrors{}
-	var failedConfigs []string
-	for _, config := range configs {
+	// Configs are synced side by side, so that one GatewayProxy with slow data plane
+	// endpoints does not hold up the others.
+	results := make([]error, len(configs))
+	forEachBounded(len(configs), c.concurrency, func(i int) {
+		config := configs[i]
 		name := config.Name
 		resources, err := c.GetResources(name)
 		if err != nil {
 			c.log.Error(err, "failed to get resources from store", "name", name)
-			failedConfigs = append(failedConfigs, name)
-			continue
+			results[i] = err
+			return
+		}
+		if pinned, ok := c.Pinned(name); ok {
+			c.log.V(1).Info("syncing pinned revision", "name", name, "version", pinned.Version)
+			resources = pinned.Resources
 		}
 		if resources == nil {
-			continue
+			return
 		}
 
 		if err := c.sync(ctx, Task{
@@ -321,12 +426,26 @@ func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors,
 			Resources: resources,
 		}); err != nil {
 			c.log.Error(err, "failed to sync resources", "name", name)
-			failedConfigs = append(failedConfigs, name)
-			var execErrs types.ADCExecutionErrors
-			if errors.As(err, &execErrs) {
-				failedMap[name] = execErrs
-			}
+			results[i] = err
+		}
+	})
+
+	failedMap := map[string]types.ADCExecutionErrors{}
+	var failedConfigs []string
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		name := configs[i].Name
+		var execErrs types.ADCExecutionErrors
+		if errors.As(err, &execErrs) {
+			failedMap[name] = execErrs
 		}
+		// The circuit breaker retries a data plane that does not answer, not the caller.
+		if c.circuitOpen(name) {
+			continue
+		}
+		failedConfigs = append(failedConfigs, name)
 	}
 
 	var err error
@@ -352,6 +471,12 @@ func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors,
 // explains -- and a conf_version the data plane refuses is the only way any of that shows
 // itself. Re-read the data plane and push again.
 func (c *Client) push(ctx context.Context, config adctypes.Config, args []string) ([]types.ADCExecutionError, error) {
+	if executor := c.executorFor(config); executor != c.executor {
+		// The native executor reads the data plane on every sync, it keeps no baseline
+		// that could need rebuilding.
+		return nil, executor.Execute(ctx, config, args)
+	}
+
 	standalone := config.BackendType == backendAPISIXStandalone
 	config.BypassCache = standalone && !c.baselineIsCurrent(config.Name)
 
@@ -448,7 +573,15 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 				config.BackendType = c.defaultMode
 			}
 
-			err = c.executor.Execute(ctx, config, args)
+			var alsoReport []types.ADCExecutionError
+			if c.dryRunFor(config) {
+				err = c.diff(ctx, config, args)
+			} else {
+				alsoReport, err = c.rolloutThroughCircuit(ctx, config, task, func(config adctypes.Config) ([]types.ADCExecutionError, error) {
+					return nil, c.executorFor(config).Execute(ctx, config, args)
+				})
+			}
+			errs.Errors = append(errs.Errors, alsoReport...)
 			duration := time.Since(startTime).Seconds()
 
 			status := "success"
@@ -489,7 +622,10 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 
 	args := BuildADCExecuteArgs(syncFilePath, task.Labels, task.ResourceTypes)
 
-	for _, config := range task.Configs {
+	configs := slices.Collect(maps.Values(task.Configs))
+	reported := make([][]types.ADCExecutionError, len(configs))
+	forEachBounded(len(configs), c.concurrency, func(i int) {
+		config := configs[i]
 		// Record sync duration for each config
 		startTime := time.Now()
 		resourceType := strings.Join(task.ResourceTypes, ",")
@@ -500,8 +636,18 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 			config.BackendType = c.defaultMode
 		}
 
-		alsoReport, err := c.push(ctx, config, args)
-		errs.Errors = append(errs.Errors, alsoReport...)
+		var (
+			alsoReport []types.ADCExecutionError
+			err        error
+		)
+		if c.dryRunFor(config) {
+			err = c.diff(ctx, config, args)
+		} else {
+			alsoReport, err = c.rolloutThroughCircuit(ctx, config, task, func(config adctypes.Config) ([]types.ADCExecutionError, error) {
+				return c.push(ctx, config, args)
+			})
+		}
+		reported[i] = append(reported[i], alsoReport...)
 
 		duration := time.Since(startTime).Seconds()
 
@@ -512,7 +658,7 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 
 			var execErr types.ADCExecutionError
 			if errors.As(err, &execErr) {
-				errs.Errors = append(errs.Errors, execErr)
+				reported[i] = append(reported[i], execErr)
 				pkgmetrics.RecordExecutionError(config.Name, execErr.Name)
 			} else {
 				pkgmetrics.RecordExecutionError(config.Name, "unknown")
@@ -521,6 +667,9 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 
 		// Record metrics
 		pkgmetrics.RecordSyncDuration(config.Name, resourceType, status, duration)
+	})
+	for _, execErrs := range reported {
+		errs.Errors = append(errs.Errors, execErrs...)
 	}
 
 	if len(errs.Errors) > 0 {
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// DetectDrift compares what every config desires with what its data plane holds, the way
+// Sync would push it, and returns the names of the configs whose data plane drifted. What
+// it finds is kept as the latest drift of each config, recorded as metrics, and handed to
+// OnDrift when it differs from what the check before found.
+//
+// A config in dry run is left out, its dry runs already report the difference, and so is
+// one whose circuit breaker is open. The ADC baseline of a config that drifted no longer
+// says what its data plane holds, so it is forgotten for the next sync to re-derive it.
+func (c *Client) DetectDrift(ctx context.Context) ([]string, error) {
+	// A sync half done would show as drift.
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	configs := slices.Collect(maps.Values(c.ConfigManager.List()))
+	drifted := make([]bool, len(configs))
+	results := make([]error, len(configs))
+	forEachBounded(len(configs), c.concurrency, func(i int) {
+		config := configs[i]
+		if config.BackendType == "" {
+			config.BackendType = c.defaultMode
+		}
+		// A data plane the circuit breaker gave up on would not answer either.
+		if c.dryRunFor(config) || c.circuitOpen(config.Name) {
+			return
+		}
+		resources, err := c.GetResources(config.Name)
+		if err != nil {
+			results[i] = err
+			return
+		}
+		if pinned, ok := c.Pinned(config.Name); ok {
+			resources = pinned.Resources
+		}
+		if resources == nil {
+			return
+		}
+
+		syncFilePath, cleanup, err := prepareSyncFile(resources)
+		if err != nil {
+			results[i] = err
+			return
+		}
+		defer cleanup()
+
+		diff, err := c.compare(ctx, config, BuildADCExecuteArgs(syncFilePath, nil, nil))
+		results[i] = err
+
+		counts := make(map[string]int)
+		for _, change := range diff.Changes {
+			counts[change.Collection]++
+		}
+		pkgmetrics.RecordDriftedResources(config.Name, counts)
+		if len(diff.Changes) > 0 {
+			drifted[i] = true
+			c.forgetBaseline(config.Name)
+			c.log.Info("data plane drifted", "config", config.Name, "resources", len(diff.Changes))
+		}
+		if c.RecordDrift(config.Name, diff) && len(diff.Changes) > 0 && c.OnDrift != nil {
+			c.OnDrift(ctx, config.Name, diff)
+		}
+	})
+
+	var names, failed []string
+	for i, config := range configs {
+		if drifted[i] {
+			names = append(names, config.Name)
+		}
+		if results[i] != nil {
+			c.log.Error(results[i], "failed to check the data plane for drift", "config", config.Name)
+			failed = append(failed, config.Name)
+		}
+	}
+	slices.Sort(names)
+	if len(failed) > 0 {
+		return names, fmt.Errorf("failed to check %d configs for drift: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return names, nil
+}
@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestClientDetectDrift(t *testing.T) {
+	api := newFakeAdminAPI()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	c := newTestClient(&recordingExecutor{})
+	c.nativeExecutor = NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	c.defaultMode = backendAPISIX
+	var reported []cache.DataPlaneDiff
+	c.OnDrift = func(_ context.Context, name string, diff cache.DataPlaneDiff) {
+		assert.Equal(t, "GatewayProxy/ns/name", name)
+		reported = append(reported, diff)
+	}
+
+	key := types.NamespacedNameKind{Namespace: "ns", Name: "httpbin", Kind: types.KindHTTPRoute}
+	require.NoError(t, c.UpdateConfig(context.Background(), Task{
+		Key: key,
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{}: {Name: "GatewayProxy/ns/name", BackendType: "apisix", Executor: "native", ServerAddrs: []string{server.URL}, Token: "key"},
+		},
+		Labels:        map[string]string{"k8s/kind": types.KindHTTPRoute, "k8s/namespace": "ns", "k8s/name": "httpbin"},
+		ResourceTypes: []string{adctypes.TypeService},
+		Resources:     httpbinResources("/get"),
+	}))
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, api.takeWrites(), "PUT routes/route")
+
+	drifted, err := c.DetectDrift(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, drifted)
+	assert.Empty(t, reported)
+
+	// Someone edits the route behind the controller's back.
+	api.store("routes/route", map[string]any{"uris": []any{"/elsewhere"}, "labels": map[string]any{"k8s/name": "httpbin"}})
+	c.markBaselineCurrent("GatewayProxy/ns/name")
+
+	drifted, err = c.DetectDrift(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GatewayProxy/ns/name"}, drifted)
+	require.Len(t, reported, 1)
+	assert.Equal(t, []string{"changed routes/route"}, changesOf(reported[0].Changes))
+	assert.False(t, c.baselineIsCurrent("GatewayProxy/ns/name"), "the next sync must re-derive the baseline")
+	assert.Empty(t, api.takeWrites(), "a drift check never writes")
+
+	// The same drift is reported once.
+	_, err = c.DetectDrift(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, reported, 1)
+
+	// A sync corrects it.
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+	drifted, err = c.DetectDrift(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, drifted)
+	latest, ok := c.GetDrift("GatewayProxy/ns/name")
+	require.True(t, ok)
+	assert.Empty(t, latest.Changes)
+}
@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+)
+
+// dryRunFor reports whether config is diffed instead of pushed: as the config says, or
+// failing that as the client does.
+func (c *Client) dryRunFor(config adctypes.Config) bool {
+	if config.DryRun != nil {
+		return *config.DryRun
+	}
+	return c.dryRun
+}
+
+// diff validates what args would push to config and reads every endpoint of the config
+// for what pushing it would change there, without pushing it. What it finds is kept as the
+// latest dry run of the config, and handed to OnDryRun when it differs from the one before.
+//
+// A dry run neither records a revision nor stages a rollout, as nothing reached the data
+// plane.
+func (c *Client) diff(ctx context.Context, config adctypes.Config, args []string) error {
+	var diff cache.DataPlaneDiff
+	err := c.executorFor(config).Validate(ctx, config, args)
+	if errors.Is(err, ErrValidationNotSupported) {
+		err = nil
+	}
+	if err == nil {
+		diff, err = c.compare(ctx, config, args)
+	} else {
+		diff = cache.DataPlaneDiff{Timestamp: time.Now(), Error: err.Error()}
+	}
+
+	c.log.Info("dry run", "config", config.Name, "changes", len(diff.Changes), "error", diff.Error)
+	if c.RecordDryRun(config.Name, diff) && c.OnDryRun != nil {
+		c.OnDryRun(ctx, config.Name, diff)
+	}
+	return err
+}
+
+// compare reads every endpoint of config for what pushing args would change there.
+//
+// The data plane is read the way the native executor reads it, whichever executor the
+// config selects: the ADC server has no way to tell what it would change.
+func (c *Client) compare(ctx context.Context, config adctypes.Config, args []string) (cache.DataPlaneDiff, error) {
+	diff := cache.DataPlaneDiff{Timestamp: time.Now()}
+	differ, ok := c.nativeExecutor.(ADCDiffer)
+	if !ok {
+		err := errors.New("no executor can read the data plane")
+		diff.Error = err.Error()
+		return diff, err
+	}
+	changes, err := differ.Diff(ctx, config, args)
+	if err != nil {
+		diff.Error = err.Error()
+	}
+	slices.SortFunc(changes, func(a, b cache.DataPlaneChange) int {
+		return cmp.Or(cmp.Compare(a.ServerAddr, b.ServerAddr), cmp.Compare(a.Collection, b.Collection), cmp.Compare(a.ID, b.ID))
+	})
+	diff.Changes = changes
+	return diff, err
+}
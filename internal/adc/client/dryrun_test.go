@@ -0,0 +1,141 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// changesOf lists changes as "change collection/id", which is all most tests look at.
+func changesOf(changes []cache.DataPlaneChange) []string {
+	var out []string
+	for _, change := range changes {
+		out = append(out, change.Change+" "+change.Collection+"/"+change.ID)
+	}
+	return out
+}
+
+func TestNativeExecutorDiffAdminAPI(t *testing.T) {
+	api := newFakeAdminAPI()
+	server := httptest.NewServer(api)
+	defer server.Close()
+	api.store("routes/other", map[string]any{"labels": map[string]any{"k8s/name": "other"}})
+	api.store("routes/stale", map[string]any{"labels": map[string]any{"k8s/name": "httpbin"}})
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix"}
+	selector := map[string]string{"k8s/name": "httpbin"}
+
+	changes, err := e.Diff(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"added services/svc", "added routes/route", "removed routes/stale"}, changesOf(changes))
+	assert.Equal(t, server.URL, changes[0].ServerAddr)
+	assert.Equal(t, "httpbin", changes[0].Labels["k8s/name"])
+	assert.Empty(t, api.takeWrites(), "a diff never writes")
+
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService)))
+	api.takeWrites()
+
+	// APISIX fills in defaults, which are no change.
+	changes, err = e.Diff(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService))
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = e.Diff(context.Background(), cfg, writeSyncFile(t, httpbinResources("/headers"), selector, adctypes.TypeService))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"changed routes/route"}, changesOf(changes))
+	assert.Empty(t, api.takeWrites())
+}
+
+func TestNativeExecutorDiffStandalone(t *testing.T) {
+	api := &fakeStandaloneAPI{config: map[string]any{
+		"routes": []any{
+			map[string]any{"id": "other", "labels": map[string]any{"k8s/name": "other"}, "modifiedIndex": 1},
+			map[string]any{"id": "stale", "labels": map[string]any{"k8s/name": "httpbin"}, "modifiedIndex": 1},
+		},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix-standalone"}
+	selector := map[string]string{"k8s/name": "httpbin"}
+
+	changes, err := e.Diff(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"added services/svc", "added routes/route", "removed routes/stale"}, changesOf(changes))
+	assert.Zero(t, api.puts, "a diff never writes")
+}
+
+func TestClientDryRunReportsChangesInsteadOfPushing(t *testing.T) {
+	api := newFakeAdminAPI()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	c.nativeExecutor = NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	c.dryRun = true
+	var reported []cache.DataPlaneDiff
+	c.OnDryRun = func(_ context.Context, name string, dryRun cache.DataPlaneDiff) {
+		assert.Equal(t, "GatewayProxy/ns/name", name)
+		reported = append(reported, dryRun)
+	}
+
+	task := func(dryRun *bool) Task {
+		return Task{
+			Name: "GatewayProxy/ns/name-sync",
+			Configs: map[types.NamespacedNameKind]adctypes.Config{
+				{}: {Name: "GatewayProxy/ns/name", BackendType: "apisix", ServerAddrs: []string{server.URL}, Token: "key", DryRun: dryRun},
+			},
+			Resources: httpbinResources("/get"),
+		}
+	}
+
+	require.NoError(t, c.sync(context.Background(), task(nil)))
+	assert.Empty(t, exec.pushes)
+	assert.Empty(t, api.takeWrites())
+	require.Len(t, reported, 1)
+	assert.ElementsMatch(t, []string{"added services/svc", "added routes/route"}, changesOf(reported[0].Changes))
+	dryRun, ok := c.GetDryRun("GatewayProxy/ns/name")
+	require.True(t, ok)
+	assert.Equal(t, reported[0].Changes, dryRun.Changes)
+	_, ok = c.LatestRevision("GatewayProxy/ns/name")
+	assert.False(t, ok, "nothing a dry run found reached the data plane")
+
+	// The same difference is reported once.
+	require.NoError(t, c.sync(context.Background(), task(nil)))
+	assert.Len(t, reported, 1)
+
+	// A GatewayProxy can opt out of the dry run.
+	require.NoError(t, c.sync(context.Background(), task(ptr.To(false))))
+	assert.Len(t, exec.pushes, 1)
+	assert.Len(t, reported, 1)
+}
@@ -34,6 +34,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -43,6 +44,7 @@ const (
 	pathSync     = "/sync"
 	pathValidate = "/validate"
 
+	backendAPISIX           = "apisix"
 	backendAPISIXStandalone = "apisix-standalone"
 )
 
@@ -51,6 +53,12 @@ type ADCExecutor interface {
 	Validate(ctx context.Context, config adctypes.Config, args []string) error
 }
 
+// ADCDiffer is implemented by an executor that can tell what Execute would change on the
+// data plane without changing it.
+type ADCDiffer interface {
+	Diff(ctx context.Context, config adctypes.Config, args []string) ([]cache.DataPlaneChange, error)
+}
+
 func BuildADCExecuteArgs(filePath string, labels map[string]string, types []string) []string {
 	args := []string{
 		"sync",
@@ -119,11 +127,14 @@ type HTTPADCExecutor struct {
 	httpClient *http.Client
 	serverURL  string
 	log        logr.Logger
+	// concurrency bounds how many server addresses are synced at once.
+	concurrency int
 }
 
 // NewHTTPADCExecutor creates a new HTTPADCExecutor with the specified ADC Server URL.
 // serverURL can be "http(s)://host:port" or "unix:///path/to/socket" or "unix:/path/to/socket".
-func NewHTTPADCExecutor(log logr.Logger, serverURL string, timeout time.Duration) *HTTPADCExecutor {
+// Each server address gets its own timeout, and at most concurrency of them are synced at once.
+func NewHTTPADCExecutor(log logr.Logger, serverURL string, timeout time.Duration, concurrency int) *HTTPADCExecutor {
 	httpClient := &http.Client{
 		Timeout: timeout,
 	}
@@ -145,9 +156,10 @@ func NewHTTPADCExecutor(log logr.Logger, serverURL string, timeout time.Duration
 	}
 
 	return &HTTPADCExecutor{
-		httpClient: httpClient,
-		serverURL:  serverURL,
-		log:        log.WithName("executor"),
+		httpClient:  httpClient,
+		serverURL:   serverURL,
+		log:         log.WithName("executor"),
+		concurrency: concurrency,
 	}
 }
 
@@ -160,7 +172,8 @@ func (e *HTTPADCExecutor) Validate(ctx context.Context, config adctypes.Config,
 	return e.runHTTPValidate(ctx, config, args)
 }
 
-// runHTTPSync performs HTTP sync to ADC Server for each server address
+// runHTTPSync performs HTTP sync to ADC Server for each server address, fanning out to
+// at most e.concurrency of them at once. A slow server only holds up its own slot.
 func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Config, args []string) error {
 	var execErrs = types.ADCExecutionError{
 		Name: config.Name,
@@ -174,18 +187,31 @@ func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Confi
 	}()
 	e.log.V(1).Info("running http sync", "serverAddrs", serverAddrs)
 
-	for _, addr := range serverAddrs {
-		if err := e.runHTTPSyncForSingleServer(ctx, addr, config, args); err != nil {
-			e.log.Error(err, "failed to run http sync for server", "server", addr)
-			var execErr types.ADCExecutionServerAddrError
-			if errors.As(err, &execErr) {
-				execErrs.FailedErrors = append(execErrs.FailedErrors, execErr)
-			} else {
-				execErrs.FailedErrors = append(execErrs.FailedErrors, types.ADCExecutionServerAddrError{
-					ServerAddr: addr,
-					Err:        err.Error(),
-				})
-			}
+	// Results are kept by index so that failures are reported in the order of ServerAddrs,
+	// whichever server answers first.
+	results := make([]error, len(serverAddrs))
+	forEachBounded(len(serverAddrs), e.concurrency, func(i int) {
+		addr := serverAddrs[i]
+		start := time.Now()
+		err := e.runHTTPSyncForSingleServer(ctx, addr, config, args)
+		recordEndpointSync(config.Name, addr, err, time.Since(start))
+		results[i] = err
+	})
+
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		addr := serverAddrs[i]
+		e.log.Error(err, "failed to run http sync for server", "server", addr)
+		var execErr types.ADCExecutionServerAddrError
+		if errors.As(err, &execErr) {
+			execErrs.FailedErrors = append(execErrs.FailedErrors, execErr)
+		} else {
+			execErrs.FailedErrors = append(execErrs.FailedErrors, types.ADCExecutionServerAddrError{
+				ServerAddr: addr,
+				Err:        err.Error(),
+			})
 		}
 	}
 	if len(execErrs.FailedErrors) > 0 {
@@ -232,13 +258,13 @@ func (e *HTTPADCExecutor) runHTTPSyncForSingleServer(ctx context.Context, server
 	defer cancel()
 
 	// Parse args to extract labels, types, and file path
-	labels, types, filePath, err := e.parseArgs(args)
+	labels, types, filePath, err := parseArgs(args)
 	if err != nil {
 		return fmt.Errorf("failed to parse args: %w", err)
 	}
 
 	// Load resources from file
-	resources, err := e.loadResourcesFromFile(filePath)
+	resources, err := loadResourcesFromFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
 	}
@@ -268,12 +294,12 @@ func (e *HTTPADCExecutor) runHTTPValidateForSingleServer(ctx context.Context, se
 	ctx, cancel := context.WithTimeout(ctx, e.httpClient.Timeout)
 	defer cancel()
 
-	labels, types, filePath, err := e.parseArgs(args)
+	labels, types, filePath, err := parseArgs(args)
 	if err != nil {
 		return fmt.Errorf("failed to parse args: %w", err)
 	}
 
-	resources, err := e.loadResourcesFromFile(filePath)
+	resources, err := loadResourcesFromFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
 	}
@@ -297,7 +323,7 @@ func (e *HTTPADCExecutor) runHTTPValidateForSingleServer(ctx context.Context, se
 }
 
 // parseArgs parses the command line arguments to extract labels, types, and file path
-func (e *HTTPADCExecutor) parseArgs(args []string) (map[string]string, []string, string, error) {
+func parseArgs(args []string) (map[string]string, []string, string, error) {
 	labels := make(map[string]string)
 	var types []string
 	var filePath string
@@ -334,7 +360,7 @@ func (e *HTTPADCExecutor) parseArgs(args []string) (map[string]string, []string,
 }
 
 // loadResourcesFromFile loads ADC resources from the specified file
-func (e *HTTPADCExecutor) loadResourcesFromFile(filePath string) (*adctypes.Resources, error) {
+func loadResourcesFromFile(filePath string) (*adctypes.Resources, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
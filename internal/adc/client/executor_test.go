@@ -30,6 +30,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -109,6 +111,8 @@ func (f *fakeExecutor) Validate(context.Context, adctypes.Config, []string) erro
 // known to be current, so the first sync of a cacheKey rebuilds it.
 func newTestClient(exec ADCExecutor) *Client {
 	return &Client{
+		Store:            cache.NewStore(logr.Discard()),
+		ConfigManager:    common.NewConfigManager[types.NamespacedNameKind, adctypes.Config](),
 		executor:         exec,
 		rebuiltBaselines: make(map[string]struct{}),
 		log:              logr.Discard(),
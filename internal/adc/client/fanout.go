@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// forEachBounded calls fn for every index below n, with at most limit calls running at
+// once. A limit below 1 runs them one after another.
+func forEachBounded(n, limit int, fn func(i int)) {
+	limit = max(1, min(limit, n))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range n {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// recordEndpointSync records how long syncing config to a single server took.
+func recordEndpointSync(configName, serverAddr string, err error, duration time.Duration) {
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+	}
+	pkgmetrics.RecordEndpointSyncDuration(configName, serverAddr, status, duration.Seconds())
+}
@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestForEachBounded(t *testing.T) {
+	for _, limit := range []int{-1, 0, 1, 3, 100} {
+		var running, peak atomic.Int32
+		var mu sync.Mutex
+		var seen []int
+		forEachBounded(10, limit, func(i int) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			seen = append(seen, i)
+			mu.Unlock()
+		})
+
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, seen, "limit %d", limit)
+		assert.LessOrEqual(t, peak.Load(), int32(max(1, min(limit, 10))), "limit %d", limit)
+	}
+}
+
+func TestHTTPADCExecutorSyncsServersSideBySide(t *testing.T) {
+	// The ADC server holds every request until all three servers are being synced at
+	// once, which a serial sync never gets to.
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	adc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ADCServerRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		arrived.Done()
+		arrived.Wait()
+
+		if req.Task.Opts.Server[0] == "http://apisix-1:9180" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("unavailable"))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(adctypes.SyncResult{})
+	}))
+	defer adc.Close()
+
+	e := NewHTTPADCExecutor(logr.Discard(), adc.URL, 5*time.Second, 3)
+	cfg := adctypes.Config{
+		Name:        "GatewayProxy/ns/name",
+		ServerAddrs: []string{"http://apisix-0:9180", "http://apisix-1:9180", "http://apisix-2:9180"},
+		BackendType: "apisix",
+	}
+	err := e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), nil))
+
+	var execErr types.ADCExecutionError
+	require.ErrorAs(t, err, &execErr)
+	require.Len(t, execErr.FailedErrors, 1)
+	assert.Equal(t, "http://apisix-1:9180", execErr.FailedErrors[0].ServerAddr)
+	assert.Equal(t, "HTTP 500: unavailable", execErr.FailedErrors[0].Err)
+}
@@ -0,0 +1,987 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const (
+	pathAdminAPI = "/apisix/admin/"
+	// pathStandaloneConfigs is where APISIX standalone takes its whole configuration at once.
+	pathStandaloneConfigs = "configs"
+
+	confVersionSuffix = "_conf_version"
+)
+
+// NativeExecutor implements ADCExecutor without an ADC server. It reads what the data
+// plane holds and writes the difference itself: object by object through the APISIX
+// Admin API, or all at once through the configuration API of APISIX standalone.
+//
+// Nothing it diffs against outlives it. Every sync starts from what the data plane holds
+// at that moment, so there is no baseline to go stale across leadership terms. Objects
+// without labels -- global rules, plugin metadata, credentials -- cannot be told apart
+// from those others wrote, so only the ones this executor wrote are ever deleted: one
+// written before a restart is left in place once dropped from the configuration.
+//
+// Every request to the data plane is given the timeout on its own.
+type NativeExecutor struct {
+	httpClient         *http.Client
+	insecureHTTPClient *http.Client
+	log                logr.Logger
+	// concurrency bounds how many servers are synced at once.
+	concurrency int
+
+	mu sync.Mutex
+	// written remembers, per server and object, the digest of the body this executor last
+	// wrote and the modifiedIndex APISIX stored it under. APISIX fills in defaults, so what
+	// it returns never equals what was sent: an object is unchanged when the body it would
+	// be sent hashes the same and nobody has written it since.
+	written map[string]writtenObject
+	// unlabelled remembers, per server, the objects without labels this executor wrote,
+	// the only ones of them it deletes.
+	unlabelled map[string]struct{}
+}
+
+type writtenObject struct {
+	digest        string
+	modifiedIndex int64
+}
+
+// NewNativeExecutor creates a NativeExecutor whose requests to the data plane time out
+// after the given duration, and which syncs at most concurrency servers at once.
+func NewNativeExecutor(log logr.Logger, timeout time.Duration, concurrency int) *NativeExecutor {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	return &NativeExecutor{
+		httpClient:         &http.Client{Timeout: timeout},
+		insecureHTTPClient: &http.Client{Timeout: timeout, Transport: transport},
+		log:                log.WithName("native-executor"),
+		written:            make(map[string]writtenObject),
+		unlabelled:         make(map[string]struct{}),
+		concurrency:        concurrency,
+	}
+}
+
+// Execute implements the ADCExecutor interface. Each server is synced on its own, at most
+// e.concurrency of them at once, and reported on its own, the way the ADC server reports them.
+func (e *NativeExecutor) Execute(ctx context.Context, config adctypes.Config, args []string) error {
+	labels, resourceTypes, filePath, err := parseArgs(args)
+	if err != nil {
+		return fmt.Errorf("failed to parse args: %w", err)
+	}
+	resources, err := loadResourcesFromFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
+	}
+	desired, err := toNativeObjects(resources)
+	if err != nil {
+		return err
+	}
+
+	return e.forEachServer(config, func(i int) error {
+		addr := config.ServerAddrs[i]
+		start := time.Now()
+		var err error
+		switch config.BackendType {
+		case backendAPISIXStandalone:
+			err = e.syncStandalone(ctx, addr, config, labels, resourceTypes, desired)
+		case backendAPISIX:
+			err = e.syncAdminAPI(ctx, addr, config, labels, resourceTypes, desired)
+		default:
+			err = fmt.Errorf("backend %s is not supported by the native executor", config.BackendType)
+		}
+		recordEndpointSync(config.Name, addr, err, time.Since(start))
+		return err
+	})
+}
+
+// Diff implements the ADCDiffer interface. It reads every server the way Execute does and
+// reports what Execute would write to or delete from it, without writing anything.
+func (e *NativeExecutor) Diff(ctx context.Context, config adctypes.Config, args []string) ([]cache.DataPlaneChange, error) {
+	labels, resourceTypes, filePath, err := parseArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse args: %w", err)
+	}
+	resources, err := loadResourcesFromFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
+	}
+	desired, err := toNativeObjects(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([][]cache.DataPlaneChange, len(config.ServerAddrs))
+	err = e.forEachServer(config, func(i int) error {
+		addr := config.ServerAddrs[i]
+		var (
+			serverChanges []cache.DataPlaneChange
+			err           error
+		)
+		switch config.BackendType {
+		case backendAPISIXStandalone:
+			serverChanges, err = e.diffStandalone(ctx, addr, config, labels, resourceTypes, desired)
+		case backendAPISIX:
+			serverChanges, err = e.diffAdminAPI(ctx, addr, config, labels, resourceTypes, desired)
+		default:
+			err = fmt.Errorf("backend %s is not supported by the native executor", config.BackendType)
+		}
+		for i := range serverChanges {
+			serverChanges[i].ServerAddr = addr
+		}
+		changes[i] = serverChanges
+		return err
+	})
+	return slices.Concat(changes...), err
+}
+
+// forEachServer calls fn with the index of every server of config, at most e.concurrency
+// of them at once, and reports each server fn failed for on its own, the way the ADC
+// server does.
+func (e *NativeExecutor) forEachServer(config adctypes.Config, fn func(i int) error) error {
+	execErrs := types.ADCExecutionError{
+		Name: config.Name,
+	}
+	results := make([]error, len(config.ServerAddrs))
+	forEachBounded(len(config.ServerAddrs), e.concurrency, func(i int) {
+		results[i] = fn(i)
+	})
+
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		addr := config.ServerAddrs[i]
+		e.log.Error(err, "failed to sync server", "server", addr)
+		var serverErr types.ADCExecutionServerAddrError
+		if !errors.As(err, &serverErr) {
+			serverErr = types.ADCExecutionServerAddrError{
+				ServerAddr: addr,
+				Err:        err.Error(),
+			}
+		}
+		execErrs.FailedErrors = append(execErrs.FailedErrors, serverErr)
+	}
+	if len(execErrs.FailedErrors) > 0 {
+		return execErrs
+	}
+	return nil
+}
+
+// ErrValidationNotSupported is returned by an executor that cannot check a configuration
+// without applying it.
+var ErrValidationNotSupported = errors.New("validation is not supported by the native executor")
+
+// Validate implements the ADCExecutor interface. Neither API can check a configuration
+// without applying it, so it returns ErrValidationNotSupported and a configuration APISIX
+// refuses is reported by the sync that pushes it.
+func (e *NativeExecutor) Validate(context.Context, adctypes.Config, []string) error {
+	return ErrValidationNotSupported
+}
+
+// remoteObject is an object the Admin API holds.
+type remoteObject struct {
+	collection    string
+	path          string
+	modifiedIndex int64
+	value         map[string]any
+}
+
+// adminObject is how the Admin API returns a single object.
+type adminObject struct {
+	Key           string         `json:"key"`
+	Value         map[string]any `json:"value"`
+	ModifiedIndex int64          `json:"modifiedIndex"`
+}
+
+// adminList is how the Admin API returns a collection. An empty collection may come back
+// as an empty object rather than an empty array.
+type adminList struct {
+	List json.RawMessage `json:"list"`
+}
+
+// adminAPIError is a request the data plane answered with anything but a 2xx.
+type adminAPIError struct {
+	status  int
+	message string
+}
+
+func (e *adminAPIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.status, e.message)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *adminAPIError
+	return errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound
+}
+
+// syncAdminAPI writes the objects that changed and deletes those no longer wanted, one by
+// one. A failure does not stop the sync: every object that failed is reported, each with
+// the resource it belongs to.
+func (e *NativeExecutor) syncAdminAPI(ctx context.Context, serverAddr string, config adctypes.Config,
+	labels map[string]string, resourceTypes []string, desired nativeObjects) error {
+	scope := nativeCollectionsInScope(resourceTypes)
+	remote, err := e.listAdminObjects(ctx, serverAddr, config, labels, scope, desired)
+	if err != nil {
+		return types.ADCExecutionServerAddrError{
+			ServerAddr: serverAddr,
+			Err:        err.Error(),
+		}
+	}
+	remoteByPath := make(map[string]remoteObject)
+	for _, objects := range remote {
+		for _, obj := range objects {
+			remoteByPath[obj.path] = obj
+		}
+	}
+
+	var failed []adctypes.SyncStatus
+	for _, collection := range scope {
+		for _, obj := range desired[collection] {
+			current, exists := remoteByPath[obj.path]
+			eventType := "create"
+			if exists {
+				eventType = "update"
+			}
+			if err := e.writeAdminObject(ctx, serverAddr, config, obj, current, exists); err != nil {
+				failed = append(failed, failedSyncStatus(obj.event, eventType, err))
+			}
+		}
+	}
+
+	for _, obj := range e.unwantedAdminObjects(serverAddr, scope, remote, desired) {
+		if err := e.deleteAdminObject(ctx, serverAddr, config, obj.path); err != nil && !isNotFound(err) {
+			failed = append(failed, failedSyncStatus(remoteStatusEvent(obj), "delete", err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return types.ADCExecutionServerAddrError{
+			ServerAddr:     serverAddr,
+			Err:            failed[0].Reason,
+			FailedStatuses: failed,
+		}
+	}
+	return nil
+}
+
+// diffAdminAPI reports what syncAdminAPI would write and delete. An object the Admin API
+// holds is unchanged when it holds everything the desired one would write: APISIX fills in
+// defaults, so it may hold more.
+func (e *NativeExecutor) diffAdminAPI(ctx context.Context, serverAddr string, config adctypes.Config,
+	labels map[string]string, resourceTypes []string, desired nativeObjects) ([]cache.DataPlaneChange, error) {
+	scope := nativeCollectionsInScope(resourceTypes)
+	remote, err := e.listAdminObjects(ctx, serverAddr, config, labels, scope, desired)
+	if err != nil {
+		return nil, err
+	}
+	remoteByPath := make(map[string]remoteObject)
+	for _, objects := range remote {
+		for _, obj := range objects {
+			remoteByPath[obj.path] = obj
+		}
+	}
+
+	var changes []cache.DataPlaneChange
+	for _, collection := range scope {
+		for _, obj := range desired[collection] {
+			current, exists := remoteByPath[obj.path]
+			switch {
+			case !exists:
+				changes = append(changes, dataPlaneChange(collection, obj.id, "added", obj.body))
+			case !containsValue(current.value, obj.body):
+				changes = append(changes, dataPlaneChange(collection, obj.id, "changed", obj.body))
+			}
+		}
+	}
+	for _, obj := range e.unwantedAdminObjects(serverAddr, scope, remote, desired) {
+		objectID := obj.path[strings.LastIndex(obj.path, "/")+1:]
+		changes = append(changes, dataPlaneChange(obj.collection, objectID, "removed", obj.value))
+	}
+	return changes, nil
+}
+
+// unwantedAdminObjects returns the objects in scope that are no longer desired, in the
+// order they are to be deleted. An object without labels is only deleted when this
+// executor wrote it.
+func (e *NativeExecutor) unwantedAdminObjects(serverAddr string, scope []string,
+	remote map[string][]remoteObject, desired nativeObjects) []remoteObject {
+	wanted := make(map[string]struct{})
+	for _, objects := range desired {
+		for _, obj := range objects {
+			wanted[obj.path] = struct{}{}
+		}
+	}
+	var unwanted []remoteObject
+	for i := len(scope) - 1; i >= 0; i-- {
+		for _, obj := range remote[scope[i]] {
+			if _, ok := wanted[obj.path]; ok {
+				continue
+			}
+			if !isLabelled(scope[i]) && !e.wroteUnlabelled(serverAddr, obj.path) {
+				continue
+			}
+			unwanted = append(unwanted, obj)
+		}
+	}
+	return unwanted
+}
+
+// listAdminObjects returns the objects of each collection in scope that the label
+// selector picks out.
+func (e *NativeExecutor) listAdminObjects(ctx context.Context, serverAddr string, config adctypes.Config,
+	labels map[string]string, scope []string, desired nativeObjects) (map[string][]remoteObject, error) {
+	remote := make(map[string][]remoteObject)
+	for _, collection := range scope {
+		switch collection {
+		case collectionCredentials:
+			// Credentials live below their consumer and carry no labels of their own: the
+			// ones in scope are those of the consumers in scope.
+			owners := make(map[string]struct{})
+			for _, obj := range remote[collectionConsumers] {
+				owners[strings.TrimPrefix(obj.path, collectionConsumers+"/")] = struct{}{}
+			}
+			for _, obj := range desired[collectionConsumers] {
+				owners[obj.id] = struct{}{}
+			}
+			for owner := range owners {
+				objects, err := e.listAdminCollection(ctx, serverAddr, config,
+					collectionConsumers+"/"+owner+"/"+collectionCredentials, nil)
+				if err != nil {
+					return nil, err
+				}
+				for i := range objects {
+					objects[i].collection = collectionCredentials
+				}
+				remote[collection] = append(remote[collection], objects...)
+			}
+		case collectionPluginMetadata:
+			// The Admin API cannot list plugin metadata. Only the metadata about to be
+			// written is read, and metadata dropped from the configuration stays in place.
+			for _, obj := range desired[collection] {
+				var current adminObject
+				err := e.send(ctx, serverAddr, config, http.MethodGet, obj.path, nil, &current)
+				if isNotFound(err) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				remote[collection] = append(remote[collection], remoteObject{
+					collection:    collection,
+					path:          obj.path,
+					modifiedIndex: current.ModifiedIndex,
+					value:         current.Value,
+				})
+			}
+		default:
+			selector := labels
+			if !isLabelled(collection) {
+				selector = nil
+			}
+			objects, err := e.listAdminCollection(ctx, serverAddr, config, collection, selector)
+			if err != nil {
+				return nil, err
+			}
+			remote[collection] = objects
+		}
+	}
+	return remote, nil
+}
+
+func (e *NativeExecutor) listAdminCollection(ctx context.Context, serverAddr string, config adctypes.Config,
+	path string, labels map[string]string) ([]remoteObject, error) {
+	var list adminList
+	err := e.send(ctx, serverAddr, config, http.MethodGet, path, nil, &list)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []adminObject
+	if trimmed := bytes.TrimSpace(list.List); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+		}
+	}
+
+	objects := make([]remoteObject, 0, len(items))
+	for _, item := range items {
+		if !matchLabels(item.Value["labels"], labels) {
+			continue
+		}
+		objects = append(objects, remoteObject{
+			collection:    path,
+			path:          strings.TrimPrefix(item.Key, "/apisix/"),
+			modifiedIndex: item.ModifiedIndex,
+			value:         item.Value,
+		})
+	}
+	return objects, nil
+}
+
+func (e *NativeExecutor) writeAdminObject(ctx context.Context, serverAddr string, config adctypes.Config,
+	obj nativeObject, current remoteObject, exists bool) error {
+	digest, err := digestOf(obj.body)
+	if err != nil {
+		return err
+	}
+	key := serverAddr + "/" + obj.path
+	if exists && e.isUnchanged(key, digest, current.modifiedIndex) {
+		return nil
+	}
+
+	var written adminObject
+	if err := e.send(ctx, serverAddr, config, http.MethodPut, obj.path, obj.body, &written); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.written[key] = writtenObject{digest: digest, modifiedIndex: written.ModifiedIndex}
+	if !isLabelled(obj.collection) {
+		e.unlabelled[key] = struct{}{}
+	}
+	return nil
+}
+
+func (e *NativeExecutor) deleteAdminObject(ctx context.Context, serverAddr string, config adctypes.Config, path string) error {
+	if err := e.send(ctx, serverAddr, config, http.MethodDelete, path, nil, nil); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.written, serverAddr+"/"+path)
+	delete(e.unlabelled, serverAddr+"/"+path)
+	return nil
+}
+
+// wroteUnlabelled reports whether this executor wrote the object without labels at path,
+// a path below /apisix/admin or the identity of a standalone item.
+func (e *NativeExecutor) wroteUnlabelled(serverAddr, path string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.unlabelled[serverAddr+"/"+path]
+	return ok
+}
+
+// isUnchanged reports whether the object this executor last wrote under key is still the
+// one the data plane holds, with the body it would write now.
+func (e *NativeExecutor) isUnchanged(key, digest string, modifiedIndex int64) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	last, ok := e.written[key]
+	if !ok || last.digest != digest {
+		return false
+	}
+	// An Admin API that does not answer a write with the modifiedIndex leaves it to the
+	// next listing to tell.
+	if last.modifiedIndex == 0 {
+		e.written[key] = writtenObject{digest: digest, modifiedIndex: modifiedIndex}
+		return true
+	}
+	return last.modifiedIndex == modifiedIndex
+}
+
+// syncStandalone replaces, in the configuration the data plane holds, the objects in
+// scope with the desired ones, and pushes the configuration back when that changed it.
+//
+// APISIX standalone refuses a configuration whose conf_versions move back, and reading
+// them from the data plane on every sync is what keeps them moving forward.
+func (e *NativeExecutor) syncStandalone(ctx context.Context, serverAddr string, config adctypes.Config,
+	labels map[string]string, resourceTypes []string, desired nativeObjects) error {
+	current, err := e.getStandaloneConfig(ctx, serverAddr, config)
+	if err != nil {
+		return standaloneSyncError(serverAddr, err)
+	}
+
+	changed := false
+	now := time.Now().UnixMilli()
+	for _, collection := range nativeCollectionsInScope(resourceTypes) {
+		if collection == collectionCredentials {
+			// Standalone keeps credentials among the consumers.
+			continue
+		}
+		version := max(now, toInt64(current[collection+confVersionSuffix])+1)
+		owns := func(identity string) bool {
+			return e.wroteUnlabelled(serverAddr, collection+"/"+identity)
+		}
+		items, collectionChanges := mergeStandaloneItems(collection, toItems(current[collection]), labels, desired, version, owns)
+		if len(collectionChanges) == 0 {
+			continue
+		}
+		changed = true
+		current[collection] = items
+		current[collection+confVersionSuffix] = version
+	}
+	if !changed {
+		e.log.V(1).Info("standalone configuration is up to date", "server", serverAddr)
+		e.rememberStandaloneUnlabelled(serverAddr, resourceTypes, desired)
+		return nil
+	}
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return standaloneSyncError(serverAddr, err)
+	}
+	sum := sha256.Sum256(data)
+	req, err := newAdminRequest(ctx, serverAddr, config, http.MethodPut, pathStandaloneConfigs, data)
+	if err != nil {
+		return standaloneSyncError(serverAddr, err)
+	}
+	// APISIX compares the digest with the stored one to skip an update that repeats it.
+	req.Header.Set("X-Digest", hex.EncodeToString(sum[:]))
+	if err := e.do(config, req, nil); err != nil {
+		return standaloneSyncError(serverAddr, err)
+	}
+	e.rememberStandaloneUnlabelled(serverAddr, resourceTypes, desired)
+	return nil
+}
+
+// StandaloneConfig lays resources out the way APISIX standalone reads them, from
+// apisix.yaml or its Admin API: a list of items per collection, credentials among the
+// consumers. It leaves out the conf_versions and modifiedIndexes a sync keeps moving
+// forward, which a configuration written from scratch does without.
+func StandaloneConfig(resources *adctypes.Resources) (map[string]any, error) {
+	desired, err := toNativeObjects(resources)
+	if err != nil {
+		return nil, err
+	}
+	config := make(map[string]any)
+	for _, collection := range nativeCollections {
+		if collection == collectionCredentials {
+			continue
+		}
+		items, _ := mergeStandaloneItems(collection, nil, nil, desired, 0, func(string) bool { return false })
+		if len(items) == 0 {
+			continue
+		}
+		for _, item := range items {
+			delete(item, "modifiedIndex")
+		}
+		config[collection] = items
+	}
+	return config, nil
+}
+
+// diffStandalone reports what syncStandalone would change in the configuration the data
+// plane holds.
+func (e *NativeExecutor) diffStandalone(ctx context.Context, serverAddr string, config adctypes.Config,
+	labels map[string]string, resourceTypes []string, desired nativeObjects) ([]cache.DataPlaneChange, error) {
+	current, err := e.getStandaloneConfig(ctx, serverAddr, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []cache.DataPlaneChange
+	for _, collection := range nativeCollectionsInScope(resourceTypes) {
+		if collection == collectionCredentials {
+			continue
+		}
+		owns := func(identity string) bool {
+			return e.wroteUnlabelled(serverAddr, collection+"/"+identity)
+		}
+		_, collectionChanges := mergeStandaloneItems(collection, toItems(current[collection]), labels, desired, 0, owns)
+		changes = append(changes, collectionChanges...)
+	}
+	return changes, nil
+}
+
+// getStandaloneConfig returns the configuration the data plane holds, without the
+// metadata APISIX echoes back but rejects on write.
+func (e *NativeExecutor) getStandaloneConfig(ctx context.Context, serverAddr string, config adctypes.Config) (map[string]any, error) {
+	current := make(map[string]any)
+	if err := e.send(ctx, serverAddr, config, http.MethodGet, pathStandaloneConfigs, nil, &current); err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	for key := range current {
+		if strings.HasPrefix(strings.ToUpper(key), "X-") {
+			delete(current, key)
+		}
+	}
+	return current, nil
+}
+
+// rememberStandaloneUnlabelled records the items without labels the data plane holds
+// after a sync as the ones this executor wrote, in place of those it held before.
+func (e *NativeExecutor) rememberStandaloneUnlabelled(serverAddr string, resourceTypes []string, desired nativeObjects) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, collection := range nativeCollectionsInScope(resourceTypes) {
+		if isLabelled(collection) {
+			continue
+		}
+		// Credentials are items of the consumers collection, the only ones without labels.
+		prefix := collection
+		if collection == collectionCredentials {
+			prefix = collectionConsumers
+		}
+		for key := range e.unlabelled {
+			if strings.HasPrefix(key, serverAddr+"/"+prefix+"/") {
+				delete(e.unlabelled, key)
+			}
+		}
+		for _, obj := range desired[collection] {
+			e.unlabelled[serverAddr+"/"+prefix+"/"+obj.id] = struct{}{}
+		}
+	}
+}
+
+// mergeStandaloneItems replaces the items of a collection that are in scope with the
+// desired ones, and returns the changes that makes. An item that is unchanged keeps its
+// modifiedIndex, one that is new or changed takes version. An item without labels is only
+// in scope when it is desired or owns reports it was written by this executor.
+func mergeStandaloneItems(collection string, existing []map[string]any, labels map[string]string,
+	desired nativeObjects, version int64, owns func(identity string) bool) ([]map[string]any, []cache.DataPlaneChange) {
+	wanted := desired[collection]
+	if collection == collectionConsumers {
+		wanted = append(slices.Clone(wanted), desired[collectionCredentials]...)
+	}
+	wantedIdentities := make(map[string]struct{}, len(wanted))
+	for _, obj := range wanted {
+		wantedIdentities[obj.id] = struct{}{}
+	}
+	ownsUnlabelled := func(identity string) bool {
+		_, ok := wantedIdentities[identity]
+		return ok || owns(identity)
+	}
+
+	// Credentials carry no labels of their own: the ones in scope are those of the
+	// consumers in scope.
+	ownedConsumers := make(map[string]struct{})
+	if collection == collectionConsumers {
+		for _, obj := range desired[collectionConsumers] {
+			ownedConsumers[obj.id] = struct{}{}
+		}
+		for _, item := range existing {
+			if username, ok := item["username"]; ok && matchLabels(item["labels"], labels) {
+				ownedConsumers[toString(username)] = struct{}{}
+			}
+		}
+	}
+
+	owned := make(map[string]map[string]any)
+	var kept []map[string]any
+	for _, item := range existing {
+		identity := standaloneIdentity(collection, item)
+		var inScope bool
+		if isLabelled(collection) {
+			inScope = matchLabels(item["labels"], labels)
+		} else {
+			inScope = ownsUnlabelled(identity)
+		}
+		if owner, _, isCredential := strings.Cut(identity, "/"+collectionCredentials+"/"); collection == collectionConsumers && isCredential {
+			_, inScope = ownedConsumers[owner]
+			inScope = inScope && ownsUnlabelled(identity)
+		}
+		if inScope {
+			owned[identity] = item
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	var changes []cache.DataPlaneChange
+	items := kept
+	for _, obj := range wanted {
+		item := maps.Clone(obj.body)
+		if obj.collection != collectionConsumers {
+			item["id"] = obj.id
+		}
+		identity := standaloneIdentity(collection, item)
+		previous, ok := owned[identity]
+		delete(owned, identity)
+		switch {
+		case ok && sameStandaloneItem(previous, item):
+			item["modifiedIndex"] = previous["modifiedIndex"]
+		case ok:
+			item["modifiedIndex"] = version
+			changes = append(changes, dataPlaneChange(obj.collection, obj.id, "changed", obj.body))
+		default:
+			item["modifiedIndex"] = version
+			changes = append(changes, dataPlaneChange(obj.collection, obj.id, "added", obj.body))
+		}
+		items = append(items, item)
+	}
+	// Whatever is left in scope is no longer wanted.
+	for _, identity := range slices.Sorted(maps.Keys(owned)) {
+		itemCollection := collection
+		if strings.Contains(identity, "/"+collectionCredentials+"/") {
+			itemCollection = collectionCredentials
+		}
+		changes = append(changes, dataPlaneChange(itemCollection, identity, "removed", owned[identity]))
+	}
+	return items, changes
+}
+
+// standaloneIdentity is what an item of the standalone configuration is known by:
+// consumers by their username, everything else, credentials included, by its id.
+func standaloneIdentity(collection string, item map[string]any) string {
+	if collection == collectionConsumers {
+		if username, ok := item["username"]; ok {
+			return toString(username)
+		}
+	}
+	return toString(item["id"])
+}
+
+func sameStandaloneItem(a, b map[string]any) bool {
+	a, b = maps.Clone(a), maps.Clone(b)
+	delete(a, "modifiedIndex")
+	delete(b, "modifiedIndex")
+	digestA, errA := digestOf(a)
+	digestB, errB := digestOf(b)
+	return errA == nil && errB == nil && digestA == digestB
+}
+
+func standaloneSyncError(serverAddr string, err error) error {
+	// The standalone API refuses the configuration as a whole, so the failure belongs to
+	// every resource in it.
+	return types.ADCExecutionServerAddrError{
+		ServerAddr:     serverAddr,
+		Err:            err.Error(),
+		FailedStatuses: []adctypes.SyncStatus{{Reason: err.Error()}},
+	}
+}
+
+func newAdminRequest(ctx context.Context, serverAddr string, config adctypes.Config, method, path string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(serverAddr, "/")+pathAdminAPI+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("X-API-KEY", config.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// send makes a request to the Admin API and decodes the response into out, if given.
+func (e *NativeExecutor) send(ctx context.Context, serverAddr string, config adctypes.Config, method, path string, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+	req, err := newAdminRequest(ctx, serverAddr, config, method, path, data)
+	if err != nil {
+		return err
+	}
+	return e.do(config, req, out)
+}
+
+func (e *NativeExecutor) do(config adctypes.Config, req *http.Request, out any) error {
+	httpClient := e.httpClient
+	if !config.TlsVerify {
+		httpClient = e.insecureHTTPClient
+	}
+
+	e.log.V(1).Info("sending request to the Admin API", "method", req.Method, "url", req.URL.String())
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			e.log.Error(closeErr, "failed to close response body")
+		}
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		var result struct {
+			ErrorMsg string `json:"error_msg"`
+		}
+		message := string(data)
+		if json.Unmarshal(data, &result) == nil && result.ErrorMsg != "" {
+			message = result.ErrorMsg
+		}
+		return &adminAPIError{status: resp.StatusCode, message: message}
+	}
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return nil
+}
+
+func failedSyncStatus(event adctypes.StatusEvent, eventType string, err error) adctypes.SyncStatus {
+	event.Type = eventType
+	status := adctypes.SyncStatus{
+		Event:    event,
+		FailedAt: time.Now(),
+		Reason:   err.Error(),
+	}
+	var apiErr *adminAPIError
+	if errors.As(err, &apiErr) {
+		status.Response.Status = apiErr.status
+	}
+	return status
+}
+
+// remoteStatusEvent names an object found on the data plane by the ADC resource type
+// that corresponds to its collection.
+func remoteStatusEvent(obj remoteObject) adctypes.StatusEvent {
+	objectID := obj.path[strings.LastIndex(obj.path, "/")+1:]
+	return statusEvent(strings.TrimSuffix(obj.collection, "s"), objectID, "", "")
+}
+
+// dataPlaneChange describes a change to the object with the given body, whose labels name
+// the Kubernetes object it belongs to.
+func dataPlaneChange(collection, objectID, change string, body map[string]any) cache.DataPlaneChange {
+	return cache.DataPlaneChange{
+		Collection: collection,
+		ID:         objectID,
+		Change:     change,
+		Labels:     labelsOf(body["labels"]),
+	}
+}
+
+// labelsOf returns the labels of an object, whether it is about to be written or was
+// returned by APISIX.
+func labelsOf(v any) map[string]string {
+	switch labels := v.(type) {
+	case map[string]string:
+		return labels
+	case map[string]any:
+		out := make(map[string]string, len(labels))
+		for key, value := range labels {
+			out[key] = toString(value)
+		}
+		return out
+	}
+	return nil
+}
+
+// containsValue reports whether have, a value APISIX returned, holds everything in want,
+// a value that would be written to it.
+func containsValue(have, want any) bool {
+	switch want := want.(type) {
+	case map[string]any:
+		have, ok := have.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			if !containsValue(have[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		have, ok := have.([]any)
+		if !ok || len(have) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !containsValue(have[i], want[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// Normalise what was never through JSON, such as labels held as map[string]string.
+		a, errA := json.Marshal(have)
+		b, errB := json.Marshal(want)
+		return errA == nil && errB == nil && bytes.Equal(a, b)
+	}
+}
+
+// matchLabels reports whether the labels of an object returned by APISIX carry every
+// label of the selector.
+func matchLabels(objectLabels any, selector map[string]string) bool {
+	if len(selector) == 0 {
+		return true
+	}
+	labels, _ := objectLabels.(map[string]any)
+	for k, v := range selector {
+		if value, ok := labels[k].(string); !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func digestOf(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func toItems(v any) []map[string]any {
+	list, _ := v.([]any)
+	items := make([]map[string]any, 0, len(list))
+	for _, entry := range list {
+		if item, ok := entry.(map[string]any); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func toInt64(v any) int64 {
+	switch n := v.(type) {
+	case float64:
+		return int64(n)
+	case int64:
+		return n
+	}
+	return 0
+}
+
+func toString(v any) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,404 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// fakeAdminAPI is an APISIX Admin API that keeps its objects in memory.
+type fakeAdminAPI struct {
+	mu            sync.Mutex
+	objects       map[string]map[string]any
+	modifiedIndex map[string]int64
+	index         int64
+	// writes records every PUT and DELETE, as "METHOD path".
+	writes []string
+	// reject answers a PUT to the path with the message.
+	reject map[string]string
+}
+
+func newFakeAdminAPI() *fakeAdminAPI {
+	return &fakeAdminAPI{
+		objects:       map[string]map[string]any{},
+		modifiedIndex: map[string]int64{},
+		reject:        map[string]string{},
+	}
+}
+
+func (f *fakeAdminAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-API-KEY") != "key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, pathAdminAPI)
+	segments := strings.Split(path, "/")
+	switch r.Method {
+	case http.MethodGet:
+		if len(segments)%2 == 1 {
+			list := []map[string]any{}
+			for key, value := range f.objects {
+				if strings.HasPrefix(key, path+"/") && !strings.Contains(strings.TrimPrefix(key, path+"/"), "/") {
+					list = append(list, map[string]any{"key": "/apisix/" + key, "value": value, "modifiedIndex": f.modifiedIndex[key]})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"total": len(list), "list": list})
+			return
+		}
+		value, ok := f.objects[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"key": "/apisix/" + path, "value": value, "modifiedIndex": f.modifiedIndex[path]})
+	case http.MethodPut:
+		f.writes = append(f.writes, "PUT "+path)
+		if message, ok := f.reject[path]; ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error_msg": message})
+			return
+		}
+		var value map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&value)
+		// APISIX fills in defaults, so what it stores is never what it was sent.
+		value["update_time"] = time.Now().Unix()
+		f.store(path, value)
+		_ = json.NewEncoder(w).Encode(map[string]any{"key": "/apisix/" + path, "value": value, "modifiedIndex": f.modifiedIndex[path]})
+	case http.MethodDelete:
+		f.writes = append(f.writes, "DELETE "+path)
+		if _, ok := f.objects[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, path)
+		delete(f.modifiedIndex, path)
+	}
+}
+
+func (f *fakeAdminAPI) store(path string, value map[string]any) {
+	f.index++
+	f.objects[path] = value
+	f.modifiedIndex[path] = f.index
+}
+
+func (f *fakeAdminAPI) takeWrites() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writes := f.writes
+	f.writes = nil
+	return writes
+}
+
+func writeSyncFile(t *testing.T, resources *adctypes.Resources, labels map[string]string, resourceTypes ...string) []string {
+	t.Helper()
+	data, err := json.Marshal(resources)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "sync.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return BuildADCExecuteArgs(path, labels, resourceTypes)
+}
+
+func httpbinResources(uri string) *adctypes.Resources {
+	labels := map[string]string{"k8s/controller-name": "test", "k8s/name": "httpbin"}
+	return &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "svc", Name: "httpbin", Labels: labels},
+			Upstream: &adctypes.Upstream{Nodes: adctypes.UpstreamNodes{{Host: "10.0.0.1", Port: 80, Weight: 100}}},
+			Routes: []*adctypes.Route{{
+				Metadata: adctypes.Metadata{ID: "route", Name: "httpbin-route", Labels: labels},
+				Uris:     []string{uri},
+			}},
+		}},
+	}
+}
+
+func TestNativeExecutorAdminAPI(t *testing.T) {
+	api := newFakeAdminAPI()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	// Something the selector does not pick out, and something it picks out but the
+	// configuration no longer wants.
+	api.store("routes/other", map[string]any{"labels": map[string]any{"k8s/name": "other"}})
+	api.store("routes/stale", map[string]any{"labels": map[string]any{"k8s/name": "httpbin"}})
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix"}
+	selector := map[string]string{"k8s/name": "httpbin"}
+
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService)))
+	assert.ElementsMatch(t, []string{"PUT services/svc", "PUT routes/route", "DELETE routes/stale"}, api.takeWrites())
+	assert.Equal(t, "svc", api.objects["routes/route"]["service_id"])
+	assert.NotContains(t, api.objects, "routes/stale")
+	assert.Contains(t, api.objects, "routes/other")
+
+	// Nothing changed, so nothing is written.
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService)))
+	assert.Empty(t, api.takeWrites())
+
+	// Only what changed is written.
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/headers"), selector, adctypes.TypeService)))
+	assert.Equal(t, []string{"PUT routes/route"}, api.takeWrites())
+
+	// Someone else wrote the route: it is written back.
+	api.store("routes/route", map[string]any{"uris": []any{"/elsewhere"}})
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/headers"), selector, adctypes.TypeService)))
+	assert.Equal(t, []string{"PUT routes/route"}, api.takeWrites())
+}
+
+func TestNativeExecutorAdminAPIReportsFailedResources(t *testing.T) {
+	api := newFakeAdminAPI()
+	api.reject["routes/route"] = "invalid configuration: uris"
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix"}
+	err := e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), nil, adctypes.TypeService))
+
+	var execErr types.ADCExecutionError
+	require.ErrorAs(t, err, &execErr)
+	assert.Equal(t, "GatewayProxy/ns/name", execErr.Name)
+	require.Len(t, execErr.FailedErrors, 1)
+	failed := execErr.FailedErrors[0]
+	assert.Equal(t, server.URL, failed.ServerAddr)
+	assert.Equal(t, "HTTP 400: invalid configuration: uris", failed.Err)
+	require.Len(t, failed.FailedStatuses, 1)
+	assert.Equal(t, adctypes.TypeRoute, failed.FailedStatuses[0].Event.ResourceType)
+	assert.Equal(t, "route", failed.FailedStatuses[0].Event.ResourceID)
+	assert.Equal(t, "create", failed.FailedStatuses[0].Event.Type)
+	assert.Equal(t, http.StatusBadRequest, failed.FailedStatuses[0].Response.Status)
+	// The service the route belongs to is written regardless.
+	assert.Contains(t, api.objects, "services/svc")
+}
+
+func TestNativeExecutorReportsUnreachableServers(t *testing.T) {
+	e := NewNativeExecutor(logr.Discard(), time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{"http://127.0.0.1:1"}, Token: "key", BackendType: "apisix"}
+	err := e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), nil))
+
+	var execErr types.ADCExecutionError
+	require.ErrorAs(t, err, &execErr)
+	require.Len(t, execErr.FailedErrors, 1)
+	assert.Equal(t, "http://127.0.0.1:1", execErr.FailedErrors[0].ServerAddr)
+	assert.Contains(t, execErr.FailedErrors[0].Err, "failed to send HTTP request")
+}
+
+// fakeStandaloneAPI is the configuration API of APISIX standalone.
+type fakeStandaloneAPI struct {
+	mu     sync.Mutex
+	config map[string]any
+	puts   int
+	digest string
+}
+
+func (f *fakeStandaloneAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		config := map[string]any{"X-Last-Modified": 1}
+		for k, v := range f.config {
+			config[k] = v
+		}
+		_ = json.NewEncoder(w).Encode(config)
+	case http.MethodPut:
+		var config map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&config)
+		for key, value := range config {
+			if strings.HasSuffix(key, confVersionSuffix) && toInt64(value) < toInt64(f.config[key]) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error_msg": key + " must be greater than or equal to"})
+				return
+			}
+		}
+		f.config = config
+		f.digest = r.Header.Get("X-Digest")
+		f.puts++
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestNativeExecutorStandalone(t *testing.T) {
+	future := time.Now().Add(time.Hour).UnixMilli()
+	api := &fakeStandaloneAPI{config: map[string]any{
+		"routes": []any{
+			map[string]any{"id": "other", "labels": map[string]any{"k8s/name": "other"}, "modifiedIndex": 1},
+			map[string]any{"id": "stale", "labels": map[string]any{"k8s/name": "httpbin"}, "modifiedIndex": 1},
+		},
+		// Another writer moved the version ahead of the clock.
+		"routes_conf_version": future,
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix-standalone"}
+	selector := map[string]string{"k8s/name": "httpbin"}
+
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService)))
+	require.Equal(t, 1, api.puts)
+	assert.NotEmpty(t, api.digest)
+	assert.NotContains(t, api.config, "X-Last-Modified")
+	assert.Greater(t, toInt64(api.config["routes_conf_version"]), future)
+
+	ids := func(collection string) []string {
+		var ids []string
+		for _, item := range toItems(api.config[collection]) {
+			ids = append(ids, toString(item["id"]))
+		}
+		return ids
+	}
+	assert.ElementsMatch(t, []string{"other", "route"}, ids("routes"))
+	assert.ElementsMatch(t, []string{"svc"}, ids("services"))
+	assert.NotContains(t, api.config, "ssls", "collections out of scope are left alone")
+
+	// Nothing changed, so nothing is pushed.
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/get"), selector, adctypes.TypeService)))
+	assert.Equal(t, 1, api.puts)
+
+	// A change bumps the version of its own collection only.
+	servicesVersion := api.config["services_conf_version"]
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, httpbinResources("/headers"), selector, adctypes.TypeService)))
+	assert.Equal(t, 2, api.puts)
+	assert.Equal(t, servicesVersion, api.config["services_conf_version"])
+}
+
+func TestNativeStandaloneCredentialsFollowTheirConsumer(t *testing.T) {
+	resources := &adctypes.Resources{Consumers: []*adctypes.Consumer{{
+		Metadata: adctypes.Metadata{Labels: map[string]string{"k8s/name": "jack"}},
+		Username: "jack",
+		Credentials: []adctypes.Credential{{
+			Metadata: adctypes.Metadata{Name: "key"},
+			Type:     "key-auth",
+			Config:   adctypes.Plugins{"key": "secret"},
+		}},
+	}}}
+	desired, err := toNativeObjects(resources)
+	require.NoError(t, err)
+
+	existing := []map[string]any{
+		{"username": "jack", "labels": map[string]any{"k8s/name": "jack"}, "modifiedIndex": 1},
+		{"id": "jack/credentials/old", "plugins": map[string]any{}, "modifiedIndex": 1},
+		{"id": "jack/credentials/manual", "plugins": map[string]any{}, "modifiedIndex": 1},
+		{"username": "rose", "labels": map[string]any{"k8s/name": "rose"}, "modifiedIndex": 1},
+		{"id": "rose/credentials/old", "plugins": map[string]any{}, "modifiedIndex": 1},
+	}
+	// Only the credentials this executor wrote are dropped, one added by hand stays.
+	owns := func(identity string) bool { return strings.HasSuffix(identity, "/old") }
+	items, changes := mergeStandaloneItems(collectionConsumers, existing, map[string]string{"k8s/name": "jack"}, desired, 2, owns)
+	assert.Contains(t, changes, cache.DataPlaneChange{Collection: collectionCredentials, ID: desired[collectionCredentials][0].id, Change: "added"})
+	assert.Contains(t, changes, cache.DataPlaneChange{Collection: collectionCredentials, ID: "jack/credentials/old", Change: "removed"})
+
+	var identities []string
+	for _, item := range items {
+		identities = append(identities, standaloneIdentity(collectionConsumers, item))
+	}
+	assert.ElementsMatch(t, []string{"jack", desired[collectionCredentials][0].id, "jack/credentials/manual", "rose", "rose/credentials/old"}, identities)
+}
+
+func TestStandaloneConfig(t *testing.T) {
+	resources := httpbinResources("/get")
+	resources.Consumers = []*adctypes.Consumer{{
+		Username: "jack",
+		Credentials: []adctypes.Credential{{
+			Metadata: adctypes.Metadata{Name: "key"},
+			Type:     "key-auth",
+			Config:   adctypes.Plugins{"key": "secret"},
+		}},
+	}}
+	config, err := StandaloneConfig(resources)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{collectionServices, collectionRoutes, collectionConsumers}, slices.Collect(maps.Keys(config)))
+	for collection, items := range config {
+		for _, item := range items.([]map[string]any) {
+			assert.NotContains(t, item, "modifiedIndex", collection)
+		}
+	}
+	consumers := config[collectionConsumers].([]map[string]any)
+	require.Len(t, consumers, 2, "the credential is listed among the consumers")
+	assert.Equal(t, "jack", consumers[0]["username"])
+	assert.True(t, strings.HasPrefix(toString(consumers[1]["id"]), "jack/credentials/"))
+}
+
+func TestNativeExecutorOnlyDeletesGlobalRulesItWrote(t *testing.T) {
+	api := newFakeAdminAPI()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	// A global rule someone else wrote.
+	api.store("global_rules/manual", map[string]any{"plugins": map[string]any{"manual": map[string]any{}}})
+
+	e := NewNativeExecutor(logr.Discard(), 5*time.Second, 1)
+	cfg := adctypes.Config{Name: "GatewayProxy/ns/name", ServerAddrs: []string{server.URL}, Token: "key", BackendType: "apisix"}
+	withRule := &adctypes.Resources{GlobalRules: adctypes.GlobalRule{"prometheus": map[string]any{}}}
+
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, withRule, nil, adctypes.TypeGlobalRule)))
+	assert.Equal(t, []string{"PUT global_rules/prometheus"}, api.takeWrites())
+
+	require.NoError(t, e.Execute(context.Background(), cfg, writeSyncFile(t, &adctypes.Resources{}, nil, adctypes.TypeGlobalRule)))
+	assert.Equal(t, []string{"DELETE global_rules/prometheus"}, api.takeWrites())
+	assert.Contains(t, api.objects, "global_rules/manual")
+}
+
+func TestNativeExecutorValidateIsNotSupported(t *testing.T) {
+	e := NewNativeExecutor(logr.Discard(), time.Second, 1)
+	assert.ErrorIs(t, e.Validate(context.Background(), adctypes.Config{}, nil), ErrValidationNotSupported)
+}
+
+func TestClientExecutorFor(t *testing.T) {
+	adc, native := &fakeExecutor{}, &fakeExecutor{}
+	c := &Client{executor: adc, nativeExecutor: native, defaultMode: "apisix"}
+
+	assert.Same(t, adc, c.executorFor(adctypes.Config{}))
+	assert.Same(t, native, c.executorFor(adctypes.Config{Executor: string(config.ExecutorTypeNative)}))
+
+	c.defaultExecutor = string(config.ExecutorTypeNative)
+	assert.Same(t, native, c.executorFor(adctypes.Config{}))
+	assert.Same(t, adc, c.executorFor(adctypes.Config{Executor: string(config.ExecutorTypeADC)}))
+
+	// The native executor only speaks to APISIX, api7ee falls back to the ADC server.
+	c.defaultMode = "api7ee"
+	assert.Same(t, adc, c.executorFor(adctypes.Config{Executor: string(config.ExecutorTypeNative)}))
+	assert.Same(t, adc, c.executorFor(adctypes.Config{Executor: string(config.ExecutorTypeNative), BackendType: "apisix"}))
+}
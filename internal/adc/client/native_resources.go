@@ -0,0 +1,324 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/id"
+)
+
+// The collections of the APISIX Admin API, which are also the keys of the standalone
+// configuration.
+const (
+	collectionUpstreams      = "upstreams"
+	collectionServices       = "services"
+	collectionRoutes         = "routes"
+	collectionStreamRoutes   = "stream_routes"
+	collectionSSLs           = "ssls"
+	collectionConsumerGroups = "consumer_groups"
+	collectionConsumers      = "consumers"
+	collectionCredentials    = "credentials"
+	collectionGlobalRules    = "global_rules"
+	collectionPluginMetadata = "plugin_metadata"
+)
+
+// nativeCollections lists every collection in the order objects are written: whatever
+// an object refers to is written before it, and deleted after it.
+var nativeCollections = []string{
+	collectionUpstreams,
+	collectionServices,
+	collectionRoutes,
+	collectionStreamRoutes,
+	collectionSSLs,
+	collectionConsumerGroups,
+	collectionConsumers,
+	collectionCredentials,
+	collectionGlobalRules,
+	collectionPluginMetadata,
+}
+
+// nativeCollectionsByType maps the ADC resource types a sync is scoped to onto the
+// collections that hold them.
+var nativeCollectionsByType = map[string][]string{
+	adctypes.TypeService:        {collectionUpstreams, collectionServices, collectionRoutes, collectionStreamRoutes},
+	adctypes.TypeRoute:          {collectionRoutes, collectionStreamRoutes},
+	adctypes.TypeSSL:            {collectionSSLs},
+	adctypes.TypeConsumerGroup:  {collectionConsumerGroups},
+	adctypes.TypeConsumer:       {collectionConsumers, collectionCredentials},
+	adctypes.TypeGlobalRule:     {collectionGlobalRules},
+	adctypes.TypePluginMetadata: {collectionPluginMetadata},
+}
+
+// nativeCollectionsInScope returns the collections a sync including the given ADC
+// resource types touches, in write order. No types means all of them.
+func nativeCollectionsInScope(resourceTypes []string) []string {
+	if len(resourceTypes) == 0 {
+		return nativeCollections
+	}
+	var scope []string
+	for _, collection := range nativeCollections {
+		for _, t := range resourceTypes {
+			if slices.Contains(nativeCollectionsByType[t], collection) {
+				scope = append(scope, collection)
+				break
+			}
+		}
+	}
+	return scope
+}
+
+// isLabelled reports whether the objects of a collection carry labels. Those that do not
+// are never filtered by the label selector: a sync that includes them owns all of them.
+func isLabelled(collection string) bool {
+	return collection != collectionGlobalRules && collection != collectionPluginMetadata &&
+		collection != collectionCredentials
+}
+
+// nativeObject is one object of the APISIX Admin API, built from the ADC resources.
+type nativeObject struct {
+	collection string
+	// id identifies the object in the standalone configuration.
+	id string
+	// path locates the object below /apisix/admin.
+	path string
+	// owner is the username of the consumer a credential belongs to.
+	owner string
+	body  map[string]any
+	// event is what a failure to write the object is reported as. It names the ADC
+	// resource the status updater can resolve back to a Kubernetes object, which for an
+	// object ADC nests in another -- an upstream, a stream route, a credential -- is the
+	// resource it is nested in.
+	event adctypes.StatusEvent
+}
+
+// nativeObjects holds the objects of each collection.
+type nativeObjects map[string][]nativeObject
+
+func (o nativeObjects) add(collection, objectID, path string, body map[string]any, event adctypes.StatusEvent) {
+	o[collection] = append(o[collection], nativeObject{
+		collection: collection,
+		id:         objectID,
+		path:       path,
+		body:       body,
+		event:      event,
+	})
+}
+
+// toNativeObjects flattens ADC resources into the objects APISIX stores: routes, stream
+// routes and named upstreams leave their service, credentials their consumer, and every
+// global rule plugin becomes a global rule of its own, the way ADC lays them out.
+func toNativeObjects(resources *adctypes.Resources) (nativeObjects, error) {
+	objects := nativeObjects{}
+	if resources == nil {
+		return objects, nil
+	}
+
+	for _, service := range resources.Services {
+		if err := objects.addService(service); err != nil {
+			return nil, err
+		}
+	}
+	for _, ssl := range resources.SSLs {
+		if err := objects.addSSL(ssl); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range resources.ConsumerGroups {
+		body, err := toNativeBody(group, "consumers", "name")
+		if err != nil {
+			return nil, err
+		}
+		objects.add(collectionConsumerGroups, group.ID, collectionConsumerGroups+"/"+group.ID, body,
+			statusEvent(adctypes.TypeConsumerGroup, group.ID, group.Name, ""))
+		for i := range group.Consumers {
+			if err := objects.addConsumer(&group.Consumers[i], group.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, consumer := range resources.Consumers {
+		if err := objects.addConsumer(consumer, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(resources.GlobalRules)) {
+		body := map[string]any{"plugins": map[string]any{name: resources.GlobalRules[name]}}
+		objects.add(collectionGlobalRules, name, collectionGlobalRules+"/"+name, body,
+			statusEvent(adctypes.TypeGlobalRule, name, name, ""))
+	}
+	for _, name := range slices.Sorted(maps.Keys(resources.PluginMetadata)) {
+		body, err := toNativeBody(resources.PluginMetadata[name])
+		if err != nil {
+			return nil, err
+		}
+		objects.add(collectionPluginMetadata, name, collectionPluginMetadata+"/"+name, body,
+			statusEvent(adctypes.TypePluginMetadata, name, name, ""))
+	}
+	return objects, nil
+}
+
+func (o nativeObjects) addService(service *adctypes.Service) error {
+	body, err := toNativeBody(service, "routes", "stream_routes", "upstreams", "upstream", "path_prefix", "strip_path_prefix")
+	if err != nil {
+		return err
+	}
+	if service.Upstream != nil {
+		upstream, err := toNativeBody(service.Upstream)
+		if err != nil {
+			return err
+		}
+		body["upstream"] = upstream
+	}
+	event := statusEvent(adctypes.TypeService, service.ID, service.Name, "")
+	o.add(collectionServices, service.ID, collectionServices+"/"+service.ID, body, event)
+
+	for _, upstream := range service.Upstreams {
+		body, err := toNativeBody(upstream)
+		if err != nil {
+			return err
+		}
+		inheritLabels(body, service.Labels)
+		o.add(collectionUpstreams, upstream.ID, collectionUpstreams+"/"+upstream.ID, body, event)
+	}
+	for _, route := range service.Routes {
+		body, err := toNativeBody(route)
+		if err != nil {
+			return err
+		}
+		body["service_id"] = service.ID
+		inheritLabels(body, service.Labels)
+		o.add(collectionRoutes, route.ID, collectionRoutes+"/"+route.ID, body,
+			statusEvent(adctypes.TypeRoute, route.ID, route.Name, service.ID))
+	}
+	for _, streamRoute := range service.StreamRoutes {
+		body, err := toNativeBody(streamRoute)
+		if err != nil {
+			return err
+		}
+		body["service_id"] = service.ID
+		inheritLabels(body, service.Labels)
+		o.add(collectionStreamRoutes, streamRoute.ID, collectionStreamRoutes+"/"+streamRoute.ID, body, event)
+	}
+	return nil
+}
+
+func (o nativeObjects) addSSL(ssl *adctypes.SSL) error {
+	body, err := toNativeBody(ssl, "certificates", "name", "desc")
+	if err != nil {
+		return err
+	}
+	// ADC lists certificates, APISIX keeps the first one apart from the others.
+	for i, certificate := range ssl.Certificates {
+		if i == 0 {
+			body["cert"] = certificate.Certificate
+			body["key"] = certificate.Key
+			continue
+		}
+		body["certs"] = append(toStrings(body["certs"]), certificate.Certificate)
+		body["keys"] = append(toStrings(body["keys"]), certificate.Key)
+	}
+	o.add(collectionSSLs, ssl.ID, collectionSSLs+"/"+ssl.ID, body,
+		statusEvent(adctypes.TypeSSL, ssl.ID, ssl.Name, ""))
+	return nil
+}
+
+func (o nativeObjects) addConsumer(consumer *adctypes.Consumer, groupID string) error {
+	body, err := toNativeBody(consumer, "credentials", "name")
+	if err != nil {
+		return err
+	}
+	if groupID != "" {
+		body["group_id"] = groupID
+	}
+	username := consumer.Username
+	event := statusEvent(adctypes.TypeConsumer, username, username, "")
+	o.add(collectionConsumers, username, collectionConsumers+"/"+username, body, event)
+
+	for _, credential := range consumer.Credentials {
+		credentialID := credential.ID
+		if credentialID == "" {
+			credentialID = id.GenID(username + "/" + credential.Name)
+		}
+		body, err := toNativeBody(credential, "config", "type", "name")
+		if err != nil {
+			return err
+		}
+		body["plugins"] = map[string]any{credential.Type: credential.Config}
+		// A credential lives below its consumer, and in the standalone configuration among
+		// the consumers with an id that says so.
+		o[collectionCredentials] = append(o[collectionCredentials], nativeObject{
+			collection: collectionCredentials,
+			id:         username + "/" + collectionCredentials + "/" + credentialID,
+			path:       collectionConsumers + "/" + username + "/" + collectionCredentials + "/" + credentialID,
+			owner:      username,
+			body:       body,
+			event:      event,
+		})
+	}
+	return nil
+}
+
+// toNativeBody turns an ADC resource into the body APISIX stores for it, without the
+// given fields. The id is part of the path, not of the body, and what ADC calls the
+// description APISIX calls desc.
+func toNativeBody(v any, drop ...string) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+	body := map[string]any{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", v, err)
+	}
+	if desc, ok := body["description"]; ok {
+		delete(body, "description")
+		body["desc"] = desc
+	}
+	delete(body, "id")
+	for _, field := range drop {
+		delete(body, field)
+	}
+	return body, nil
+}
+
+// inheritLabels gives an object its service's labels when it has none of its own, so
+// the label selector finds it again on the next sync.
+func inheritLabels(body map[string]any, labels map[string]string) {
+	if _, ok := body["labels"]; !ok && len(labels) > 0 {
+		body["labels"] = labels
+	}
+}
+
+func toStrings(v any) []string {
+	s, _ := v.([]string)
+	return s
+}
+
+func statusEvent(resourceType, resourceID, resourceName, parentID string) adctypes.StatusEvent {
+	return adctypes.StatusEvent{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		ResourceName: resourceName,
+		ParentID:     parentID,
+	}
+}
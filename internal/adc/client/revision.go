@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// recordRevision records what a task that carries the whole configuration pushed as the
+// latest revision of config. A task that only replaces part of it leaves the history alone:
+// every such change is followed by a full sync, which records the outcome.
+func (c *Client) recordRevision(config adctypes.Config, task Task) {
+	if len(task.ResourceTypes) > 0 || len(task.Labels) > 0 || task.Resources == nil {
+		return
+	}
+	revision, added, err := c.RecordRevision(config.Name, task.Resources)
+	if err != nil {
+		c.log.Error(err, "failed to record configuration revision", "config", config.Name)
+		return
+	}
+	if added {
+		c.log.V(1).Info("recorded configuration revision", "config", config.Name,
+			"version", revision.Version, "hash", revision.Hash, "objects", revision.Objects)
+		pkgmetrics.RecordConfigRevision(config.Name, revision.Version)
+	}
+}
+
+// unpinned returns the configs that are not pinned to a revision.
+func (c *Client) unpinned(configs map[types.NamespacedNameKind]adctypes.Config) map[types.NamespacedNameKind]adctypes.Config {
+	unpinned := make(map[types.NamespacedNameKind]adctypes.Config, len(configs))
+	for key, config := range configs {
+		if _, ok := c.Pinned(config.Name); ok {
+			c.log.V(1).Info("skipping sync of pinned config", "name", config.Name)
+			continue
+		}
+		unpinned[key] = config
+	}
+	return unpinned
+}
+
+// PinRevision pushes the given revision of the config named name to its data plane, and
+// keeps the data plane on it, whatever the config desires, until UnpinRevision. It is how
+// on-call returns a data plane to a known-good configuration while the Kubernetes object
+// that broke it is fixed.
+//
+// The config stays pinned if the push fails, so that the next sync retries it.
+func (c *Client) PinRevision(ctx context.Context, name string, version int64) error {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	key, config, ok := c.configByName(name)
+	if !ok {
+		return fmt.Errorf("config %s not found", name)
+	}
+	revision, err := c.Pin(name, version)
+	if err != nil {
+		return err
+	}
+	pkgmetrics.RecordConfigPinned(name, true)
+	c.log.Info("pinning config to revision", "name", name, "version", version, "hash", revision.Hash)
+
+	err = c.sync(ctx, Task{
+		Name:      name + "-rollback",
+		Configs:   map[types.NamespacedNameKind]adctypes.Config{key: config},
+		Resources: revision.Resources,
+	})
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+	}
+	pkgmetrics.RecordRollback(name, status)
+	return err
+}
+
+// UnpinRevision lets the data plane of the config named name follow the config again, and
+// syncs it to what the config desires now.
+func (c *Client) UnpinRevision(ctx context.Context, name string) error {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	if !c.Unpin(name) {
+		return fmt.Errorf("config %s is not pinned", name)
+	}
+	pkgmetrics.RecordConfigPinned(name, false)
+	c.log.Info("unpinned config", "name", name)
+
+	key, config, ok := c.configByName(name)
+	if !ok {
+		return nil
+	}
+	resources, err := c.GetResources(name)
+	if err != nil {
+		return err
+	}
+	return c.sync(ctx, Task{
+		Name:      name + "-sync",
+		Configs:   map[types.NamespacedNameKind]adctypes.Config{key: config},
+		Resources: resources,
+	})
+}
+
+func (c *Client) configByName(name string) (types.NamespacedNameKind, adctypes.Config, bool) {
+	for key, config := range c.ConfigManager.List() {
+		if config.Name == name {
+			return key, config, true
+		}
+	}
+	return types.NamespacedNameKind{}, adctypes.Config{}, false
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestClientSyncKeepsABoundedRevisionHistory(t *testing.T) {
+	c := newTestClient(&recordingExecutor{})
+
+	for i := 1; i <= 12; i++ {
+		task := rolloutTask(nil, fmt.Sprintf("v%d", i))
+		task.Resources.Services[0].Labels = map[string]string{label.LabelResourceKey: "HTTPRoute/default/httpbin"}
+		require.NoError(t, c.sync(context.Background(), task))
+	}
+
+	revisions := c.Revisions("GatewayProxy/ns/name")
+	require.Len(t, revisions, 10)
+	assert.Equal(t, int64(3), revisions[0].Version)
+	assert.Equal(t, int64(12), revisions[9].Version)
+	assert.Len(t, revisions[9].Hash, 64)
+	assert.Equal(t, []string{"HTTPRoute/default/httpbin"}, revisions[9].Objects,
+		"a revision names the Kubernetes objects whose translation changed")
+}
+
+func TestClientPinRevisionKeepsTheDataPlaneOnIt(t *testing.T) {
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	task := func(service string) Task { return rolloutTask(nil, service) }
+	c.ConfigManager.Update(types.NamespacedNameKind{Name: "route"}, task("v1").Configs)
+
+	require.NoError(t, c.sync(context.Background(), task("v1")))
+	require.NoError(t, c.sync(context.Background(), task("v2")))
+
+	exec.pushes = nil
+	require.NoError(t, c.PinRevision(context.Background(), "GatewayProxy/ns/name", 1))
+	require.Len(t, exec.pushes, 1)
+	assert.Equal(t, []string{"v1"}, exec.pushes[0].services)
+
+	// While pinned, a change to the config reaches the store but not the data plane, and
+	// the periodic sync keeps pushing the pinned revision.
+	exec.pushes = nil
+	require.NoError(t, c.Update(context.Background(), Task{
+		Key:           types.NamespacedNameKind{Name: "route"},
+		Labels:        map[string]string{label.LabelResourceKey: "HTTPRoute/default/httpbin"},
+		Configs:       task("v3").Configs,
+		ResourceTypes: []string{adctypes.TypeService},
+		Resources:     task("v3").Resources,
+	}))
+	assert.Empty(t, exec.pushes)
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.pushes, 1)
+	assert.Equal(t, []string{"v1"}, exec.pushes[0].services)
+
+	// Unpinning syncs what the config desires now.
+	exec.pushes = nil
+	require.NoError(t, c.UnpinRevision(context.Background(), "GatewayProxy/ns/name"))
+	require.Len(t, exec.pushes, 1)
+	assert.Equal(t, []string{"v3"}, exec.pushes[0].services)
+
+	assert.Error(t, c.PinRevision(context.Background(), "GatewayProxy/ns/name", 42))
+}
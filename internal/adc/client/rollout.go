@@ -0,0 +1,185 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// How a staged rollout ended, as recorded by pkgmetrics.RecordRollout.
+const (
+	rolloutPromoted       = "promoted"
+	rolloutRolledBack     = "rolled_back"
+	rolloutRollbackFailed = "rollback_failed"
+	rolloutIncomplete     = "incomplete"
+)
+
+// pushFunc pushes one config and returns, beside its error, the ones to report next to it.
+type pushFunc func(config adctypes.Config) ([]types.ADCExecutionError, error)
+
+// rollout pushes config through push, staged across its ServerAddrs when the config asks
+// for it: the canary endpoints first, then, once they accepted the push and pass the health
+// check, the rest. A canary that fails either is rolled back to the revision every endpoint
+// last accepted, and the push goes no further.
+//
+// A push every endpoint accepted is recorded as the latest revision of the config, see
+// recordRevision.
+func (c *Client) rollout(ctx context.Context, config adctypes.Config, task Task, push pushFunc) ([]types.ADCExecutionError, error) {
+	strategy := config.Rollout
+	if strategy == nil || len(config.ServerAddrs) <= strategy.Canary {
+		alsoReport, err := push(config)
+		if err == nil {
+			c.recordRevision(config, task)
+		}
+		return alsoReport, err
+	}
+
+	canary, rest := config, config
+	canary.ServerAddrs = config.ServerAddrs[:strategy.Canary]
+	rest.ServerAddrs = config.ServerAddrs[strategy.Canary:]
+
+	alsoReport, err := push(canary)
+	if err == nil {
+		err = c.probeCanary(ctx, canary)
+	}
+	if err != nil {
+		c.log.Error(err, "canary endpoints did not take the configuration, rolling them back",
+			"config", config.Name, "canary", canary.ServerAddrs)
+		if rollbackErr := c.rollBack(ctx, canary); rollbackErr != nil {
+			c.log.Error(rollbackErr, "failed to roll back canary endpoints", "config", config.Name)
+			pkgmetrics.RecordRollout(config.Name, rolloutRollbackFailed)
+			var execErr types.ADCExecutionError
+			if errors.As(rollbackErr, &execErr) {
+				alsoReport = append(alsoReport, execErr)
+			}
+			return alsoReport, err
+		}
+		pkgmetrics.RecordRollout(config.Name, rolloutRolledBack)
+		return alsoReport, err
+	}
+
+	more, err := push(rest)
+	alsoReport = append(alsoReport, more...)
+	if err != nil {
+		// The canary took the change and stays on it: it is the rest that failed, and the
+		// next sync retries them.
+		pkgmetrics.RecordRollout(config.Name, rolloutIncomplete)
+		return alsoReport, err
+	}
+	pkgmetrics.RecordRollout(config.Name, rolloutPromoted)
+	c.recordRevision(config, task)
+	return alsoReport, nil
+}
+
+// rollBack pushes the revision the rest of the endpoints run to the canary endpoints: the
+// one the config is pinned to, or else the last one every endpoint accepted.
+func (c *Client) rollBack(ctx context.Context, canary adctypes.Config) error {
+	revision, ok := c.Pinned(canary.Name)
+	if !ok {
+		revision, ok = c.LatestRevision(canary.Name)
+	}
+	if !ok {
+		return canaryError(canary, "no configuration every endpoint accepted is known to roll back to")
+	}
+
+	syncFilePath, cleanup, err := prepareSyncFile(revision.Resources)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	_, err = c.push(ctx, canary, BuildADCExecuteArgs(syncFilePath, nil, nil))
+	var execErr types.ADCExecutionError
+	if errors.As(err, &execErr) {
+		// Tell the rollback apart from the push it rolls back.
+		for i := range execErr.FailedErrors {
+			execErr.FailedErrors[i].Err = "rollback failed: " + execErr.FailedErrors[i].Err
+		}
+		return execErr
+	}
+	return err
+}
+
+// probeCanary sends the rollout health check to every canary endpoint. Any response other
+// than a 2xx fails the canary.
+func (c *Client) probeCanary(ctx context.Context, canary adctypes.Config) error {
+	check := canary.Rollout.HealthCheck
+	if check == nil {
+		return nil
+	}
+
+	execErr := types.ADCExecutionError{Name: canary.Name}
+	for _, serverAddr := range canary.ServerAddrs {
+		if err := probe(ctx, serverAddr, check); err != nil {
+			execErr.FailedErrors = append(execErr.FailedErrors, types.ADCExecutionServerAddrError{
+				ServerAddr: serverAddr,
+				Err:        "rollout health check failed: " + err.Error(),
+			})
+		}
+	}
+	if len(execErr.FailedErrors) > 0 {
+		return execErr
+	}
+	return nil
+}
+
+func probe(ctx context.Context, serverAddr string, check *adctypes.RolloutHealthCheck) error {
+	u, err := url.Parse(serverAddr)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	target := "http://" + net.JoinHostPort(u.Hostname(), strconv.Itoa(int(check.Port))) + check.Path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s returned %s", target, resp.Status)
+	}
+	return nil
+}
+
+func canaryError(canary adctypes.Config, reason string) types.ADCExecutionError {
+	execErr := types.ADCExecutionError{Name: canary.Name}
+	for _, serverAddr := range canary.ServerAddrs {
+		execErr.FailedErrors = append(execErr.FailedErrors, types.ADCExecutionServerAddrError{
+			ServerAddr: serverAddr,
+			Err:        "rollback failed: " + reason,
+		})
+	}
+	return execErr
+}
@@ -0,0 +1,210 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// rolloutPush is one Execute call the recordingExecutor saw: where it went and what it
+// carried.
+type rolloutPush struct {
+	serverAddrs []string
+	services    []string
+}
+
+// recordingExecutor records every push and rejects the ones that reach a server in reject.
+type recordingExecutor struct {
+	mu     sync.Mutex
+	pushes []rolloutPush
+	reject map[string]bool
+}
+
+func (e *recordingExecutor) Execute(_ context.Context, config adctypes.Config, args []string) error {
+	data, err := os.ReadFile(args[2])
+	if err != nil {
+		return err
+	}
+	var resources adctypes.Resources
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return err
+	}
+	push := rolloutPush{serverAddrs: config.ServerAddrs}
+	for _, service := range resources.Services {
+		push.services = append(push.services, service.Name)
+	}
+
+	e.mu.Lock()
+	e.pushes = append(e.pushes, push)
+	e.mu.Unlock()
+
+	for _, serverAddr := range config.ServerAddrs {
+		if e.reject[serverAddr] {
+			return rejection("invalid plugin configuration")
+		}
+	}
+	return nil
+}
+
+func (e *recordingExecutor) Validate(context.Context, adctypes.Config, []string) error { return nil }
+
+func rolloutTask(rollout *adctypes.Rollout, service string) Task {
+	return Task{
+		Name: "GatewayProxy/ns/name-sync",
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{}: {
+				Name:        "GatewayProxy/ns/name",
+				BackendType: "apisix",
+				ServerAddrs: []string{"http://apisix-0:9180", "http://apisix-1:9180", "http://apisix-2:9180"},
+				Rollout:     rollout,
+			},
+		},
+		Resources: &adctypes.Resources{
+			Services: []*adctypes.Service{{Metadata: adctypes.Metadata{ID: "svc", Name: service}}},
+		},
+	}
+}
+
+func TestClientSyncPromotesARolloutTheCanaryAccepted(t *testing.T) {
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+
+	require.NoError(t, c.sync(context.Background(), rolloutTask(&adctypes.Rollout{Canary: 1}, "v1")))
+
+	assert.Equal(t, []rolloutPush{
+		{serverAddrs: []string{"http://apisix-0:9180"}, services: []string{"v1"}},
+		{serverAddrs: []string{"http://apisix-1:9180", "http://apisix-2:9180"}, services: []string{"v1"}},
+	}, exec.pushes)
+
+	revision, ok := c.LatestRevision("GatewayProxy/ns/name")
+	require.True(t, ok, "a rollout every endpoint accepted is the one to roll back to next")
+	assert.Equal(t, "v1", revision.Resources.Services[0].Name)
+}
+
+func TestClientSyncRollsTheCanaryBackWhenItRejectsThePush(t *testing.T) {
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	require.NoError(t, c.sync(context.Background(), rolloutTask(&adctypes.Rollout{Canary: 1}, "v1")))
+
+	exec.pushes = nil
+	exec.reject = map[string]bool{"http://apisix-0:9180": true}
+	// The canary rejects the rollback too, but it is the snapshot the rollback carries.
+	err := c.sync(context.Background(), rolloutTask(&adctypes.Rollout{Canary: 1}, "v2"))
+	require.Error(t, err)
+
+	assert.Equal(t, []rolloutPush{
+		{serverAddrs: []string{"http://apisix-0:9180"}, services: []string{"v2"}},
+		{serverAddrs: []string{"http://apisix-0:9180"}, services: []string{"v1"}},
+	}, exec.pushes, "the rest must never see a change the canary rejected")
+
+	revision, _ := c.LatestRevision("GatewayProxy/ns/name")
+	assert.Equal(t, int64(1), revision.Version, "a rollout the canary rejected is no revision")
+}
+
+func TestClientSyncRollsTheCanaryBackWhenItFailsTheHealthCheck(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/status/ready", r.URL.Path)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	rollout := &adctypes.Rollout{
+		Canary: 1,
+		HealthCheck: &adctypes.RolloutHealthCheck{
+			Port:    int32(port),
+			Path:    "/status/ready",
+			Timeout: time.Second,
+		},
+	}
+	task := func(service string) Task {
+		task := rolloutTask(rollout, service)
+		cfg := task.Configs[types.NamespacedNameKind{}]
+		// The probe goes to the host of the endpoint, on the health check port.
+		cfg.ServerAddrs = []string{"http://127.0.0.1:9180", "http://apisix-1:9180"}
+		task.Configs[types.NamespacedNameKind{}] = cfg
+		return task
+	}
+
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	require.NoError(t, c.sync(context.Background(), task("v1")))
+	require.Len(t, exec.pushes, 2)
+
+	exec.pushes = nil
+	healthy.Store(false)
+	err = c.sync(context.Background(), task("v2"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rollout health check failed")
+
+	assert.Equal(t, []rolloutPush{
+		{serverAddrs: []string{"http://127.0.0.1:9180"}, services: []string{"v2"}},
+		{serverAddrs: []string{"http://127.0.0.1:9180"}, services: []string{"v1"}},
+	}, exec.pushes)
+}
+
+func TestClientSyncReportsACanaryWithNothingToRollBackTo(t *testing.T) {
+	exec := &recordingExecutor{reject: map[string]bool{"http://apisix-0:9180": true}}
+	c := newTestClient(exec)
+
+	err := c.sync(context.Background(), rolloutTask(&adctypes.Rollout{Canary: 1}, "v1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rollback failed")
+	assert.Len(t, exec.pushes, 1, "the rest must never see a change the canary rejected")
+}
+
+func TestClientSyncRecordsRevisionsOfFullSyncsOnly(t *testing.T) {
+	exec := &recordingExecutor{}
+	c := newTestClient(exec)
+	require.NoError(t, c.sync(context.Background(), rolloutTask(nil, "v1")))
+	// Syncing what the latest revision already holds adds none.
+	require.NoError(t, c.sync(context.Background(), rolloutTask(nil, "v1")))
+
+	// A task that replaces part of the configuration is followed by a full sync, which is
+	// what records the outcome.
+	task := rolloutTask(nil, "v2")
+	task.ResourceTypes = []string{adctypes.TypeService}
+	require.NoError(t, c.sync(context.Background(), task))
+
+	revisions := c.Revisions("GatewayProxy/ns/name")
+	require.Len(t, revisions, 1)
+	assert.Equal(t, int64(1), revisions[0].Version)
+}
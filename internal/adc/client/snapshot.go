@@ -0,0 +1,190 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// snapshot is what the client holds for one config, see Snapshots.
+type snapshot struct {
+	Name string `json:"name"`
+	// Parents are the keys the config is known by, usually the one GatewayProxy it is
+	// translated from.
+	Parents []snapshotParent `json:"parents"`
+	// Objects are the Kubernetes objects translated into the config.
+	Objects   []snapshotObject      `json:"objects,omitempty"`
+	Resources *cache.ConfigSnapshot `json:"resources"`
+}
+
+type snapshotParent struct {
+	Key    types.NamespacedNameKind   `json:"key"`
+	Config snapshotConfig             `json:"config"`
+	Refs   []types.NamespacedNameKind `json:"refs,omitempty"`
+}
+
+type snapshotObject struct {
+	Key     types.NamespacedNameKind   `json:"key"`
+	Parents []types.NamespacedNameKind `json:"parents"`
+}
+
+// snapshotConfig is an adctypes.Config, whose own JSON leaves the Token out.
+type snapshotConfig struct {
+	Name        string            `json:"name"`
+	ServerAddrs []string          `json:"serverAddrs"`
+	Token       string            `json:"token,omitempty"`
+	TlsVerify   bool              `json:"tlsVerify"`
+	BackendType string            `json:"backendType,omitempty"`
+	Executor    string            `json:"executor,omitempty"`
+	Rollout     *adctypes.Rollout `json:"rollout,omitempty"`
+	DryRun      *bool             `json:"dryRun,omitempty"`
+}
+
+func newSnapshotConfig(cfg adctypes.Config) snapshotConfig {
+	return snapshotConfig{
+		Name:        cfg.Name,
+		ServerAddrs: cfg.ServerAddrs,
+		Token:       cfg.Token,
+		TlsVerify:   cfg.TlsVerify,
+		BackendType: cfg.BackendType,
+		Executor:    cfg.Executor,
+		Rollout:     cfg.Rollout,
+		DryRun:      cfg.DryRun,
+	}
+}
+
+func (s snapshotConfig) config() adctypes.Config {
+	return adctypes.Config{
+		Name:        s.Name,
+		ServerAddrs: s.ServerAddrs,
+		Token:       s.Token,
+		TlsVerify:   s.TlsVerify,
+		BackendType: s.BackendType,
+		Executor:    s.Executor,
+		Rollout:     s.Rollout,
+		DryRun:      s.DryRun,
+	}
+}
+
+// Snapshots returns what the client holds for each config, by config name, in the form
+// Restore takes back. The same content always encodes the same way, so that a caller can
+// tell from the bytes whether a config changed.
+//
+// A snapshot carries the secrets of the config: its token, SSL private keys and consumer
+// credentials.
+func (c *Client) Snapshots() (map[string][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	configs := c.ConfigManager.List()
+	snapshots := make(map[string]*snapshot)
+	for key, cfg := range configs {
+		s, ok := snapshots[cfg.Name]
+		if !ok {
+			resources, err := c.Store.Snapshot(cfg.Name)
+			if err != nil {
+				return nil, err
+			}
+			s = &snapshot{Name: cfg.Name, Resources: resources}
+			snapshots[cfg.Name] = s
+		}
+		refs := slices.Clone(c.ConfigManager.GetConfigRefs(key))
+		slices.SortFunc(refs, compareNamespacedNameKind)
+		s.Parents = append(s.Parents, snapshotParent{Key: key, Config: newSnapshotConfig(cfg), Refs: refs})
+	}
+	for key, parents := range c.ConfigManager.Resources() {
+		byName := make(map[string][]types.NamespacedNameKind)
+		for _, parent := range parents {
+			if cfg, ok := configs[parent]; ok {
+				byName[cfg.Name] = append(byName[cfg.Name], parent)
+			}
+		}
+		for name, parents := range byName {
+			slices.SortFunc(parents, compareNamespacedNameKind)
+			snapshots[name].Objects = append(snapshots[name].Objects, snapshotObject{Key: key, Parents: parents})
+		}
+	}
+
+	encoded := make(map[string][]byte, len(snapshots))
+	for name, s := range snapshots {
+		slices.SortFunc(s.Parents, func(a, b snapshotParent) int { return compareNamespacedNameKind(a.Key, b.Key) })
+		slices.SortFunc(s.Objects, func(a, b snapshotObject) int { return compareNamespacedNameKind(a.Key, b.Key) })
+		data, err := json.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode snapshot of config %s: %w", name, err)
+		}
+		encoded[name] = data
+	}
+	return encoded, nil
+}
+
+// Restore brings back what Snapshots returned, and returns the keys of the Kubernetes
+// objects and configs it restored. Whatever the client already knows, because it was
+// translated since, is left as it is: restoring only fills in what has not been
+// translated yet, and holds it as the configuration the data plane last received.
+//
+// A restored object may have been deleted meanwhile, which is for the caller to find out.
+func (c *Client) Restore(snapshots map[string][]byte) ([]types.NamespacedNameKind, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	known := c.ConfigManager.Resources()
+	skip := func(labels map[string]string) bool {
+		_, ok := known[types.NamespacedNameKind{
+			Kind:      labels[label.LabelKind],
+			Namespace: labels[label.LabelNamespace],
+			Name:      labels[label.LabelName],
+		}]
+		return ok
+	}
+
+	var (
+		configs   = make(map[types.NamespacedNameKind]adctypes.Config)
+		refs      = make(map[types.NamespacedNameKind][]types.NamespacedNameKind)
+		resources = make(map[types.NamespacedNameKind][]types.NamespacedNameKind)
+	)
+	for name, data := range snapshots {
+		var s snapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of config %s: %w", name, err)
+		}
+		if err := c.Store.Restore(s.Name, s.Resources, skip); err != nil {
+			return nil, fmt.Errorf("failed to restore config %s: %w", s.Name, err)
+		}
+		for _, parent := range s.Parents {
+			configs[parent.Key] = parent.Config.config()
+			if len(parent.Refs) > 0 {
+				refs[parent.Key] = parent.Refs
+			}
+		}
+		// An object translated into several configs is in the snapshot of each.
+		for _, object := range s.Objects {
+			resources[object.Key] = append(resources[object.Key], object.Parents...)
+		}
+	}
+	restored := c.ConfigManager.Restore(resources, configs, refs)
+	c.log.Info("restored store", "configs", len(snapshots), "restored", len(restored))
+	return restored, nil
+}
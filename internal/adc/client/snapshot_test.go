@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+var snapshotGatewayProxy = types.NamespacedNameKind{Namespace: "ns", Name: "name", Kind: types.KindGatewayProxy}
+
+func routeTask(name, service string) Task {
+	key := types.NamespacedNameKind{Namespace: "ns", Name: name, Kind: types.KindHTTPRoute}
+	labels := map[string]string{
+		label.LabelKind:      key.Kind,
+		label.LabelNamespace: key.Namespace,
+		label.LabelName:      key.Name,
+	}
+	return Task{
+		Key:    key,
+		Name:   key.String(),
+		Labels: labels,
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			snapshotGatewayProxy: {
+				Name:        "GatewayProxy/ns/name",
+				BackendType: "apisix",
+				ServerAddrs: []string{"http://apisix-0:9180"},
+				Token:       "admin-key",
+			},
+		},
+		ResourceTypes: []string{adctypes.TypeService},
+		Resources: &adctypes.Resources{
+			Services: []*adctypes.Service{{Metadata: adctypes.Metadata{ID: name, Name: service, Labels: labels}}},
+		},
+	}
+}
+
+func TestClientRestoresWhatItSnapshotted(t *testing.T) {
+	ctx := context.Background()
+	before := newTestClient(&recordingExecutor{})
+	require.NoError(t, before.UpdateConfig(ctx, routeTask("a", "a-v1")))
+	require.NoError(t, before.UpdateConfig(ctx, routeTask("b", "b-v1")))
+
+	snapshots, err := before.Snapshots()
+	require.NoError(t, err)
+	again, err := before.Snapshots()
+	require.NoError(t, err)
+	assert.Equal(t, snapshots, again, "the same content must encode the same way")
+
+	exec := &recordingExecutor{}
+	after := newTestClient(exec)
+	// a was reconciled before the restore, and the restore must not take it back.
+	require.NoError(t, after.UpdateConfig(ctx, routeTask("a", "a-v2")))
+
+	restored, err := after.Restore(snapshots)
+	require.NoError(t, err)
+	assert.Equal(t, []types.NamespacedNameKind{routeTask("b", "").Key}, restored)
+
+	_, err = after.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, exec.pushes, 1)
+	assert.ElementsMatch(t, []string{"a-v2", "b-v1"}, exec.pushes[0].services)
+
+	empty := newTestClient(&recordingExecutor{})
+	restored, err = empty.Restore(snapshots)
+	require.NoError(t, err)
+	assert.Len(t, restored, 3)
+	assert.Equal(t, "admin-key", empty.ConfigManager.List()[snapshotGatewayProxy].Token,
+		"the token must survive the snapshot")
+}
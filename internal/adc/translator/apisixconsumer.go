@@ -113,7 +113,7 @@ func (t *Translator) TranslateApisixConsumer(tctx *provider.TranslateContext, ac
 	}
 	consumer.Plugins = plugins
 	consumer.Labels = label.GenLabelWithObjectLabels(ac)
-	result.Consumers = append(result.Consumers, consumer)
+	t.addConsumer(tctx, result, ac.Namespace, ac.Spec.GroupRef, consumer)
 	return result, nil
 }
 
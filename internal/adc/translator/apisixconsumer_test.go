@@ -28,6 +28,7 @@ import (
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
@@ -144,3 +145,32 @@ func TestTranslateApisixConsumer_UsesMetadataLabelsWithoutOverwritingControllerL
 	require.Equal(t, consumer.Name, translated.Labels[label.LabelName])
 	require.Equal(t, "ApisixConsumer/default/demo", translated.Labels[label.LabelResourceKey])
 }
+
+func TestTranslateApisixConsumer_NestsConsumerInItsGroup(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.ConsumerGroups[k8stypes.NamespacedName{Namespace: "default", Name: "gold"}] = &v1alpha1.ConsumerGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold", Namespace: "default"},
+	}
+
+	consumer := &apiv2.ApisixConsumer{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: apiv2.ApisixConsumerSpec{
+			GroupRef: &corev1.LocalObjectReference{Name: "gold"},
+			AuthParameter: &apiv2.ApisixConsumerAuthParameter{
+				KeyAuth: &apiv2.ApisixConsumerKeyAuth{
+					Value: &apiv2.ApisixConsumerKeyAuthValue{Key: "demo-key"},
+				},
+			},
+		},
+	}
+
+	result, err := translator.TranslateApisixConsumer(tctx, consumer)
+	require.NoError(t, err)
+	require.Empty(t, result.Consumers)
+	require.Len(t, result.ConsumerGroups, 1)
+	require.Equal(t, "default_gold", result.ConsumerGroups[0].Name)
+	require.Len(t, result.ConsumerGroups[0].Consumers, 1)
+	require.Equal(t, "default_demo", result.ConsumerGroups[0].Consumers[0].Username)
+	require.Contains(t, result.ConsumerGroups[0].Consumers[0].Plugins, "key-auth")
+}
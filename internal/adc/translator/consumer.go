@@ -19,13 +19,17 @@ package translator
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
 
 func (t *Translator) TranslateConsumerV1alpha1(tctx *provider.TranslateContext, consumerV *v1alpha1.Consumer) (*TranslateResult, error) {
@@ -39,7 +43,13 @@ func (t *Translator) TranslateConsumerV1alpha1(tctx *provider.TranslateContext,
 		Username: username,
 	}
 	credentials := make([]adctypes.Credential, 0, len(consumerV.Spec.Credentials))
+	now := time.Now()
 	for _, credentialSpec := range consumerV.Spec.Credentials {
+		// Credentials outside their validity are left out, the controller reconciles the
+		// consumer again when they start or stop being accepted.
+		if !utils.CredentialActive(credentialSpec, now) {
+			continue
+		}
 		credential := adctypes.Credential{}
 		credential.Name = credentialSpec.Name
 		credential.Type = credentialSpec.Type
@@ -55,6 +65,10 @@ func (t *Translator) TranslateConsumerV1alpha1(tctx *provider.TranslateContext,
 			if secret == nil {
 				continue
 			}
+			if len(credentialSpec.SecretRef.Keys) > 0 {
+				credentials = append(credentials, translateRotatedCredentials(credentialSpec, secret)...)
+				continue
+			}
 			authConfig := make(map[string]any)
 			for k, v := range secret.Data {
 				authConfig[k] = string(v)
@@ -90,6 +104,28 @@ func (t *Translator) TranslateConsumerV1alpha1(tctx *provider.TranslateContext,
 		plugins[pluginName] = pluginConfig
 	}
 	consumer.Plugins = plugins
-	result.Consumers = append(result.Consumers, consumer)
+	t.addConsumer(tctx, result, consumerV.Namespace, consumerV.Spec.GroupRef, consumer)
 	return result, nil
 }
+
+// translateRotatedCredentials translates a key-auth credential whose current and previous
+// keys are held by the keys of secret the credential lists, into a credential per key.
+func translateRotatedCredentials(credentialSpec v1alpha1.Credential, secret *corev1.Secret) []adctypes.Credential {
+	credentials := make([]adctypes.Credential, 0, len(credentialSpec.SecretRef.Keys))
+	for i, key := range credentialSpec.SecretRef.Keys {
+		value := secret.Data[key]
+		if len(value) == 0 {
+			continue
+		}
+		credential := adctypes.Credential{
+			Type:   credentialSpec.Type,
+			Config: map[string]any{"key": string(value)},
+		}
+		credential.Name = credentialSpec.Name
+		if i > 0 {
+			credential.Name = strings.TrimPrefix(credentialSpec.Name+"-"+key, "-")
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials
+}
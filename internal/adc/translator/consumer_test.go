@@ -20,10 +20,14 @@ package translator
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
@@ -59,3 +63,99 @@ func TestTranslateConsumerV1alpha1_UsesMetadataLabelsWithoutOverwritingControlle
 	require.Equal(t, consumer.Name, translated.Labels[label.LabelName])
 	require.Equal(t, "Consumer/default/demo", translated.Labels[label.LabelResourceKey])
 }
+
+func TestTranslateConsumerV1alpha1_NestsConsumerInItsGroup(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	group := &v1alpha1.ConsumerGroup{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConsumerGroup",
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "gold", Namespace: "default"},
+		Spec: v1alpha1.ConsumerGroupSpec{
+			Plugins: []v1alpha1.Plugin{{
+				Name:   "limit-count",
+				Config: apiextensionsv1.JSON{Raw: []byte(`{"count":100,"time_window":60}`)},
+			}},
+		},
+	}
+	tctx.ConsumerGroups[types.NamespacedName{Namespace: "default", Name: "gold"}] = group
+
+	consumer := &v1alpha1.Consumer{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Consumer",
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: v1alpha1.ConsumerSpec{
+			GroupRef: &corev1.LocalObjectReference{Name: "gold"},
+		},
+	}
+
+	result, err := translator.TranslateConsumerV1alpha1(tctx, consumer)
+	require.NoError(t, err)
+	require.Empty(t, result.Consumers)
+	require.Len(t, result.ConsumerGroups, 1)
+
+	translated := result.ConsumerGroups[0]
+	require.Equal(t, "default_gold", translated.Name)
+	require.NotEmpty(t, translated.ID)
+	require.Equal(t, "ConsumerGroup/default/gold", translated.Labels[label.LabelResourceKey])
+	require.Equal(t, map[string]any{"count": float64(100), "time_window": float64(60)}, translated.Plugins["limit-count"])
+	require.Len(t, translated.Consumers, 1)
+	require.Equal(t, "default_demo", translated.Consumers[0].Username)
+	require.Equal(t, "Consumer/default/demo", translated.Consumers[0].Labels[label.LabelResourceKey])
+
+	// A group that does not exist leaves the consumer on its own.
+	consumer.Spec.GroupRef.Name = "silver"
+	result, err = translator.TranslateConsumerV1alpha1(tctx, consumer)
+	require.NoError(t, err)
+	require.Empty(t, result.ConsumerGroups)
+	require.Len(t, result.Consumers, 1)
+}
+
+func TestTranslateConsumerV1alpha1_OnlyActiveCredentials(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.Secrets[types.NamespacedName{Namespace: "default", Name: "rotated"}] = &corev1.Secret{
+		Data: map[string][]byte{
+			"key":          []byte("new-key"),
+			"previous-key": []byte("old-key"),
+		},
+	}
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+	config := apiextensionsv1.JSON{Raw: []byte(`{"key":"inline-key"}`)}
+
+	consumer := &v1alpha1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: v1alpha1.ConsumerSpec{
+			Credentials: []v1alpha1.Credential{
+				{Name: "expired", Type: "key-auth", Config: config, NotAfter: &past},
+				{Name: "pending", Type: "key-auth", Config: config, NotBefore: &future},
+				{Name: "current", Type: "key-auth", Config: config, NotBefore: &past, NotAfter: &future},
+				{
+					Name: "rotated",
+					Type: "key-auth",
+					SecretRef: &v1alpha1.SecretReference{
+						Name: "rotated",
+						Keys: []string{"key", "previous-key", "missing-key"},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := translator.TranslateConsumerV1alpha1(tctx, consumer)
+	require.NoError(t, err)
+	require.Len(t, result.Consumers, 1)
+
+	credentials := result.Consumers[0].Credentials
+	require.Len(t, credentials, 3)
+	require.Equal(t, "current", credentials[0].Name)
+	require.Equal(t, "rotated", credentials[1].Name)
+	require.Equal(t, map[string]any{"key": "new-key"}, map[string]any(credentials[1].Config))
+	require.Equal(t, "rotated-previous-key", credentials[2].Name)
+	require.Equal(t, map[string]any{"key": "old-key"}, map[string]any(credentials[2].Config))
+}
@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translator
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/pkg/id"
+)
+
+// TranslateConsumerGroup translates a ConsumerGroup without its consumers.
+func (t *Translator) TranslateConsumerGroup(group *v1alpha1.ConsumerGroup) *adctypes.ConsumerGroup {
+	name := adctypes.ComposeConsumerName(group.Namespace, group.Name)
+	result := &adctypes.ConsumerGroup{
+		Name:    name,
+		Plugins: adctypes.Plugins{},
+	}
+	result.ID = id.GenID(name)
+	result.Labels = label.GenLabel(group)
+	for _, plugin := range group.Spec.Plugins {
+		pluginConfig := make(map[string]any)
+		if len(plugin.Config.Raw) > 0 {
+			if err := json.Unmarshal(plugin.Config.Raw, &pluginConfig); err != nil {
+				t.Log.Error(err, "failed to unmarshal plugin config",
+					"consumergroup", group.Namespace+"/"+group.Name,
+					"plugin", plugin.Name)
+				continue
+			}
+		}
+		result.Plugins[plugin.Name] = pluginConfig
+	}
+	return result
+}
+
+// addConsumer adds consumer to result, nested in the consumer group it refers to when that
+// group is in tctx. A consumer carries its group, so that the group reaches the data plane
+// with the consumer, but the group keeps its own labels and is never deleted with it.
+func (t *Translator) addConsumer(tctx *provider.TranslateContext, result *TranslateResult, namespace string, groupRef *corev1.LocalObjectReference, consumer *adctypes.Consumer) {
+	if groupRef == nil {
+		result.Consumers = append(result.Consumers, consumer)
+		return
+	}
+	group, ok := tctx.ConsumerGroups[types.NamespacedName{Namespace: namespace, Name: groupRef.Name}]
+	if !ok || group == nil {
+		result.Consumers = append(result.Consumers, consumer)
+		return
+	}
+	translated := t.TranslateConsumerGroup(group)
+	translated.Consumers = []adctypes.Consumer{*consumer}
+	result.ConsumerGroups = append(result.ConsumerGroups, translated)
+}
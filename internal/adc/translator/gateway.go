@@ -51,6 +51,22 @@ func (t *Translator) TranslateGateway(tctx *provider.TranslateContext, obj *gate
 			result.SSL = append(result.SSL, ssl...)
 		}
 	}
+	// Listeners merged from ListenerSets terminate TLS on the same data plane as the
+	// Gateway's own listeners, so their SNIs take part in the same deduplication.
+	for _, ls := range tctx.ListenerSets {
+		for _, listener := range utils.ListenerSetListeners(ls) {
+			if listener.TLS == nil {
+				continue
+			}
+			tctx.GatewayTLSConfig = append(tctx.GatewayTLSConfig, *listener.TLS)
+			sslName := fmt.Sprintf("%s_%s", adctypes.ComposeSSLName(internaltypes.KindListenerSet, ls.Namespace, ls.Name), listener.Name)
+			ssl, err := t.translateListenerSecret(tctx, listener, obj, ls.Namespace, sslName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate secret of listenerset %s/%s: %w", ls.Namespace, ls.Name, err)
+			}
+			result.SSL = append(result.SSL, ssl...)
+		}
+	}
 	ssls, err := dedupGatewaySSLSNIs(result.SSL)
 	if err != nil {
 		return nil, err
@@ -194,6 +210,14 @@ func mergeCertificates(into, extra []adctypes.Certificate) []adctypes.Certificat
 }
 
 func (t *Translator) translateSecret(tctx *provider.TranslateContext, listener gatewayv1.Listener, obj *gatewayv1.Gateway) ([]*adctypes.SSL, error) {
+	sslName := fmt.Sprintf("%s_%s", adctypes.ComposeSSLName(internaltypes.KindGateway, obj.Namespace, obj.Name), listener.Name)
+	return t.translateListenerSecret(tctx, listener, obj, obj.Namespace, sslName)
+}
+
+// translateListenerSecret builds the SSL objects of a listener of the Gateway, whether
+// declared on the Gateway itself or merged from a ListenerSet. certNamespace is the
+// namespace certificateRefs default to, and sslName prefixes the generated SSL IDs.
+func (t *Translator) translateListenerSecret(tctx *provider.TranslateContext, listener gatewayv1.Listener, obj *gatewayv1.Gateway, certNamespace, sslName string) ([]*adctypes.SSL, error) {
 	if tctx.Secrets == nil {
 		return nil, nil
 	}
@@ -230,7 +254,7 @@ func (t *Translator) translateSecret(tctx *provider.TranslateContext, listener g
 			return nil, err
 		}
 		for refIndex, ref := range listener.TLS.CertificateRefs {
-			ns := obj.GetNamespace()
+			ns := certNamespace
 			if ref.Namespace != nil {
 				ns = string(*ref.Namespace)
 			}
@@ -275,7 +299,7 @@ func (t *Translator) translateSecret(tctx *provider.TranslateContext, listener g
 				// normalized before a collision can be recognised
 				sslObj.Snis = sslutils.NormalizeHosts(sslObj.Snis)
 				sslObj.Client = client
-				sslObj.ID = id.GenID(fmt.Sprintf("%s_%s_%d", adctypes.ComposeSSLName(internaltypes.KindGateway, obj.Namespace, obj.Name), listener.Name, refIndex))
+				sslObj.ID = id.GenID(fmt.Sprintf("%s_%d", sslName, refIndex))
 				t.Log.V(1).Info("generated ssl id", "ssl id", sslObj.ID, "secret", secretNN.String())
 				sslObj.Labels = label.GenLabel(obj)
 				sslObjs = append(sslObjs, sslObj)
@@ -218,6 +218,60 @@ func TestTranslateGateway_DuplicateSNIAcrossListeners(t *testing.T) {
 	}
 }
 
+// TestTranslateGateway_ListenerSet verifies the listeners merged from a ListenerSet
+// are programmed with the Gateway: their certificateRefs default to the namespace
+// of the ListenerSet and their SNIs are deduplicated with the Gateway's own.
+func TestTranslateGateway_ListenerSet(t *testing.T) {
+	tr := &Translator{Log: logr.Discard()}
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.Secrets[types.NamespacedName{Namespace: "team", Name: "wildcard-cert"}] = &corev1.Secret{
+		Data: map[string][]byte{
+			"cert": []byte(wildcardSANCert),
+			"key":  []byte(wildcardSANKey),
+		},
+	}
+	certRef := []gatewayv1.SecretObjectReference{{
+		Kind: ptr.To(gatewayv1.Kind("Secret")),
+		Name: gatewayv1.ObjectName("wildcard-cert"),
+	}}
+	tctx.ListenerSets = []*gatewayv1.ListenerSet{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "ls"},
+		Spec: gatewayv1.ListenerSetSpec{
+			ParentRef: gatewayv1.ParentGatewayReference{Name: "gw", Namespace: ptr.To(gatewayv1.Namespace("default"))},
+			Listeners: []gatewayv1.ListenerEntry{
+				{
+					Name:     "https",
+					Port:     443,
+					Hostname: ptr.To(gatewayv1.Hostname("a.wildcard.org")),
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: certRef},
+				},
+				{
+					Name:     "https-again",
+					Port:     443,
+					Hostname: ptr.To(gatewayv1.Hostname("a.wildcard.org")),
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: certRef},
+				},
+			},
+		},
+	}}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+			},
+		},
+	}
+
+	result, err := tr.TranslateGateway(tctx, gateway)
+	require.NoError(t, err)
+	require.Len(t, result.SSL, 1, "the same certificate for the same SNI is programmed once")
+	assert.Equal(t, []string{"a.wildcard.org"}, result.SSL[0].Snis)
+	assert.Len(t, tctx.GatewayTLSConfig, 2)
+}
+
 // TestDedupGatewaySSLSNIs covers what happens to the losing side of an SNI
 // collision: only a configuration the surviving SSL object already serves may be
 // dropped, and only a listener claiming exactly the same SNIs may contribute extra
@@ -18,9 +18,11 @@
 package translator
 
 import (
+	"cmp"
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -50,12 +52,21 @@ func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateConte
 	cfg := types.Config{
 		Name:        utils.NamespacedNameKind(gatewayProxy).String(),
 		BackendType: cp.Mode,
+		Executor:    cp.Executor,
 	}
 
 	if cp.TlsVerify != nil {
 		cfg.TlsVerify = *cp.TlsVerify
 	}
 
+	if cp.DryRun != nil {
+		cfg.DryRun = ptr.To(*cp.DryRun)
+	}
+
+	if cp.Rollout != nil {
+		cfg.Rollout = translateRollout(cp.Rollout)
+	}
+
 	if cp.Auth.Type == v1alpha1.AuthTypeAdminKey && cp.Auth.AdminKey != nil {
 		if cp.Auth.AdminKey.ValueFrom != nil && cp.Auth.AdminKey.ValueFrom.SecretKeyRef != nil {
 			secretRef := cp.Auth.AdminKey.ValueFrom.SecretKeyRef
@@ -142,3 +153,20 @@ func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateConte
 
 	return &cfg, nil
 }
+
+func translateRollout(strategy *v1alpha1.RolloutStrategy) *types.Rollout {
+	rollout := &types.Rollout{
+		Canary: max(int(strategy.Canary), 1),
+	}
+	if hc := strategy.HealthCheck; hc != nil {
+		rollout.HealthCheck = &types.RolloutHealthCheck{
+			Port:    hc.Port,
+			Path:    cmp.Or(hc.Path, "/status/ready"),
+			Timeout: 5 * time.Second,
+		}
+		if hc.Timeout != nil && hc.Timeout.Duration > 0 {
+			rollout.HealthCheck.Timeout = hc.Timeout.Duration
+		}
+	}
+	return rollout
+}
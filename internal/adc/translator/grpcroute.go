@@ -40,11 +40,17 @@ func (t *Translator) fillPluginsFromGRPCRouteFilters(
 	filters []gatewayv1.GRPCRouteFilter,
 	tctx *provider.TranslateContext,
 ) {
+	var mirrored bool
 	for _, filter := range filters {
 		switch filter.Type {
 		case gatewayv1.GRPCRouteFilterRequestHeaderModifier:
 			t.fillPluginFromHTTPRequestHeaderFilter(plugins, filter.RequestHeaderModifier)
 		case gatewayv1.GRPCRouteFilterRequestMirror:
+			if mirrored {
+				t.Log.V(1).Info("ignoring additional RequestMirror filter", "backendRef", filter.RequestMirror.BackendRef.Name)
+				continue
+			}
+			mirrored = true
 			t.fillPluginFromHTTPRequestMirrorFilter(plugins, namespace, filter.RequestMirror, apiv2.SchemeGRPC)
 		case gatewayv1.GRPCRouteFilterResponseHeaderModifier:
 			t.fillPluginFromHTTPResponseHeaderFilter(plugins, filter.ResponseHeaderModifier)
@@ -196,7 +202,7 @@ func (t *Translator) TranslateGRPCRoute(tctx *provider.TranslateContext, grpcRou
 				continue
 			}
 
-			t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+			t.AttachBackendTrafficPolicyToUpstreamWithScheme(backend.BackendRef, tctx, upstream, apiv2.SchemeGRPC)
 			upstream.Nodes = upNodes
 
 			var (
@@ -217,6 +223,7 @@ func (t *Translator) TranslateGRPCRoute(tctx *provider.TranslateContext, grpcRou
 			upstream.Name = upstreamName
 			upstream.ID = id.GenID(upstreamName)
 			upstream.Scheme = cmp.Or(upstream.Scheme, apiv2.SchemeGRPC)
+			t.AttachBackendTLSPolicyToUpstream(backend.BackendRef, tctx, upstream)
 			upstreams = append(upstreams, upstream)
 		}
 
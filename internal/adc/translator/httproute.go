@@ -21,8 +21,13 @@ import (
 	"cmp"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -39,6 +44,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/provider"
 	sslutils "github.com/apache/apisix-ingress-controller/internal/ssl"
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
 
 func (t *Translator) fillPluginsFromHTTPRouteFilters(
@@ -48,6 +54,7 @@ func (t *Translator) fillPluginsFromHTTPRouteFilters(
 	matches []gatewayv1.HTTPRouteMatch,
 	tctx *provider.TranslateContext,
 ) {
+	var mirrored bool
 	for _, filter := range filters {
 		switch filter.Type {
 		case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
@@ -55,6 +62,13 @@ func (t *Translator) fillPluginsFromHTTPRouteFilters(
 		case gatewayv1.HTTPRouteFilterRequestRedirect:
 			t.fillPluginFromHTTPRequestRedirectFilter(plugins, filter.RequestRedirect)
 		case gatewayv1.HTTPRouteFilterRequestMirror:
+			// proxy-mirror mirrors to a single backend, so only the first
+			// RequestMirror filter of a rule is programmed.
+			if mirrored {
+				t.Log.V(1).Info("ignoring additional RequestMirror filter", "backendRef", filter.RequestMirror.BackendRef.Name)
+				continue
+			}
+			mirrored = true
 			t.fillPluginFromHTTPRequestMirrorFilter(plugins, namespace, filter.RequestMirror, apiv2.SchemeHTTP)
 		case gatewayv1.HTTPRouteFilterURLRewrite:
 			t.fillPluginFromURLRewriteFilter(plugins, filter.URLRewrite, matches)
@@ -64,6 +78,8 @@ func (t *Translator) fillPluginsFromHTTPRouteFilters(
 			t.fillPluginFromExtensionRef(plugins, namespace, filter.ExtensionRef, tctx)
 		case gatewayv1.HTTPRouteFilterCORS:
 			t.fillPluginFromHTTPCORSFilter(plugins, filter.CORS)
+		case gatewayv1.HTTPRouteFilterExternalAuth:
+			t.fillPluginFromHTTPExternalAuthFilter(plugins, namespace, filter.ExternalAuth, tctx)
 		}
 	}
 }
@@ -256,7 +272,15 @@ func (t *Translator) fillPluginFromHTTPResponseHeaderFilter(plugins adctypes.Plu
 	plugin.Headers.Remove = append(plugin.Headers.Remove, respHeaderModifier.Remove...)
 }
 
+// minMirrorSampleRatio is the smallest sample_ratio proxy-mirror accepts.
+const minMirrorSampleRatio = 0.00001
+
 func (t *Translator) fillPluginFromHTTPRequestMirrorFilter(plugins adctypes.Plugins, namespace string, reqMirror *gatewayv1.HTTPRequestMirrorFilter, scheme string) {
+	ratio := requestMirrorSampleRatio(reqMirror)
+	if ratio <= 0 {
+		return
+	}
+
 	pluginName := adctypes.PluginProxyMirror
 	obj := plugins[pluginName]
 
@@ -267,6 +291,9 @@ func (t *Translator) fillPluginFromHTTPRequestMirrorFilter(plugins adctypes.Plug
 	} else {
 		plugin = obj.(*adctypes.RequestMirror)
 	}
+	if ratio < 1 {
+		plugin.SampleRatio = max(ratio, minMirrorSampleRatio)
+	}
 
 	var (
 		port = 80
@@ -284,6 +311,78 @@ func (t *Translator) fillPluginFromHTTPRequestMirrorFilter(plugins adctypes.Plug
 	plugin.Host = host
 }
 
+// requestMirrorSampleRatio returns the share of requests to mirror, from 0 to 1.
+// All requests are mirrored when neither percent nor fraction is set.
+func requestMirrorSampleRatio(reqMirror *gatewayv1.HTTPRequestMirrorFilter) float64 {
+	switch {
+	case reqMirror.Percent != nil:
+		return float64(*reqMirror.Percent) / 100
+	case reqMirror.Fraction != nil:
+		denominator := ptr.Deref(reqMirror.Fraction.Denominator, 100)
+		if denominator <= 0 {
+			return 0
+		}
+		return float64(reqMirror.Fraction.Numerator) / float64(denominator)
+	}
+	return 1
+}
+
+// externalAuthSupported reports whether forward-auth can call the auth service as the
+// filter asks. forward-auth only speaks HTTP and calls a fixed URI, so a path cannot be
+// prefixed to the request path.
+func externalAuthSupported(extAuth *gatewayv1.HTTPExternalAuthFilter) bool {
+	return extAuth.ExternalAuthProtocol == gatewayv1.HTTPRouteExternalAuthHTTPProtocol &&
+		(extAuth.HTTPAuthConfig == nil || extAuth.HTTPAuthConfig.Path == "")
+}
+
+// fillPluginFromHTTPExternalAuthFilter translates an HTTP ExternalAuth filter into
+// the forward-auth plugin. A forwarded body is sent with a POST request, without its
+// size cap. Requests are rejected with a 500 when the filter cannot be honored, so that
+// a misconfigured filter never lets them through unauthenticated.
+func (t *Translator) fillPluginFromHTTPExternalAuthFilter(plugins adctypes.Plugins, namespace string, extAuth *gatewayv1.HTTPExternalAuthFilter, tctx *provider.TranslateContext) {
+	if extAuth == nil {
+		return
+	}
+	backendRef := extAuth.BackendRef
+	targetNN := types.NamespacedName{
+		Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(namespace))),
+		Name:      string(backendRef.Name),
+	}
+	// the backend is only resolved for a Service the route is permitted to reference
+	if !externalAuthSupported(extAuth) || tctx.Services[targetNN] == nil {
+		t.Log.V(1).Info("rejecting requests of unsupported ExternalAuth filter", "protocol", extAuth.ExternalAuthProtocol, "backendRef", targetNN)
+		plugins[adctypes.PluginFaultInjection] = &adctypes.FaultInjectionConfig{
+			Abort: &adctypes.FaultInjectionAbortConfig{
+				HTTPStatus: http.StatusInternalServerError,
+				Body:       "External authorization is not available",
+			},
+		}
+		return
+	}
+
+	port := 80
+	if backendRef.Port != nil {
+		port = int(*backendRef.Port)
+	}
+	plugin := &adctypes.ForwardAuthConfig{
+		URI:            fmt.Sprintf("%s://%s.%s.svc.cluster.local:%d", apiv2.SchemeHTTP, targetNN.Name, targetNN.Namespace, port),
+		SSLVerify:      true,
+		RequestHeaders: []string{"Authorization"},
+	}
+	if httpAuth := extAuth.HTTPAuthConfig; httpAuth != nil {
+		for _, header := range httpAuth.AllowedRequestHeaders {
+			if !slices.ContainsFunc(plugin.RequestHeaders, func(h string) bool { return strings.EqualFold(h, header) }) {
+				plugin.RequestHeaders = append(plugin.RequestHeaders, header)
+			}
+		}
+		plugin.UpstreamHeaders = httpAuth.AllowedResponseHeaders
+	}
+	if extAuth.ForwardBody != nil && extAuth.ForwardBody.MaxSize > 0 {
+		plugin.RequestMethod = http.MethodPost
+	}
+	plugins[adctypes.PluginForwardAuth] = plugin
+}
+
 func (t *Translator) fillPluginFromHTTPRequestRedirectFilter(plugins adctypes.Plugins, reqRedirect *gatewayv1.HTTPRequestRedirectFilter) {
 	pluginName := adctypes.PluginRedirect
 	obj := plugins[pluginName]
@@ -321,7 +420,7 @@ func (t *Translator) fillPluginFromHTTPRequestRedirectFilter(plugins adctypes.Pl
 	plugin.URI = uri
 }
 
-func (t *Translator) fillHTTPRoutePoliciesForHTTPRoute(tctx *provider.TranslateContext, routes []*adctypes.Route, rule gatewayv1.HTTPRouteRule) {
+func (t *Translator) fillHTTPRoutePoliciesForHTTPRoute(tctx *provider.TranslateContext, service *adctypes.Service, routes []*adctypes.Route, rule gatewayv1.HTTPRouteRule) {
 	var policies []v1alpha1.HTTPRoutePolicy
 	for _, policy := range tctx.HTTPRoutePolicies {
 		for _, ref := range policy.Spec.TargetRefs {
@@ -332,7 +431,22 @@ func (t *Translator) fillHTTPRoutePoliciesForHTTPRoute(tctx *provider.TranslateC
 		}
 	}
 
+	// the defaults apply where the policies of the rule leave a field unset
+	if defaults := tctx.HTTPRoutePolicyDefaults; defaults != nil {
+		defaults = defaults.DeepCopy()
+		for _, policy := range policies {
+			if policy.Spec.Priority != nil {
+				defaults.Spec.Priority = nil
+			}
+			if len(policy.Spec.Vars) > 0 {
+				defaults.Spec.Vars = nil
+			}
+		}
+		policies = append(policies, *defaults)
+	}
+
 	t.fillHTTPRoutePolicies(routes, policies)
+	t.fillPluginsFromHTTPRoutePolicies(tctx, service.Plugins, policies)
 }
 
 func (t *Translator) fillHTTPRoutePoliciesForIngress(tctx *provider.TranslateContext, routes []*adctypes.Route) {
@@ -342,7 +456,9 @@ func (t *Translator) fillHTTPRoutePoliciesForIngress(tctx *provider.TranslateCon
 func (t *Translator) fillHTTPRoutePolicies(routes []*adctypes.Route, policies []v1alpha1.HTTPRoutePolicy) {
 	for _, policy := range policies {
 		for _, route := range routes {
-			route.Priority = policy.Spec.Priority
+			if policy.Spec.Priority != nil {
+				route.Priority = policy.Spec.Priority
+			}
 			for _, data := range policy.Spec.Vars {
 				var v []adctypes.StringOrSlice
 				if err := json.Unmarshal(data.Raw, &v); err != nil {
@@ -356,6 +472,35 @@ func (t *Translator) fillHTTPRoutePolicies(routes []*adctypes.Route, policies []
 	}
 }
 
+// fillPluginsFromHTTPRoutePolicies adds the plugins of the policies, oldest first, that the
+// filters of the rule or an older policy do not configure already.
+func (t *Translator) fillPluginsFromHTTPRoutePolicies(tctx *provider.TranslateContext, plugins adctypes.Plugins, policies []v1alpha1.HTTPRoutePolicy) {
+	for _, policy := range policies {
+		policyPlugins := make(adctypes.Plugins)
+		if ref := policy.Spec.PluginConfigRef; ref != nil {
+			t.fillPluginFromExtensionRef(policyPlugins, policy.Namespace, &gatewayv1.LocalObjectReference{
+				Kind: internaltypes.KindPluginConfig,
+				Name: gatewayv1.ObjectName(ref.Name),
+			}, tctx)
+		}
+		for _, plugin := range policy.Spec.Plugins {
+			config := make(map[string]any)
+			if len(plugin.Config.Raw) > 0 {
+				if err := json.Unmarshal(plugin.Config.Raw, &config); err != nil {
+					t.Log.Error(err, "HTTPRoutePolicy plugin config unmarshal failed", "plugin", plugin.Name, "policy", utils.NamespacedName(&policy))
+					continue
+				}
+			}
+			policyPlugins[plugin.Name] = config
+		}
+		for name, config := range policyPlugins {
+			if _, ok := plugins[name]; !ok {
+				plugins[name] = config
+			}
+		}
+	}
+}
+
 func (t *Translator) translateEndpointSlice(portName *string, weight int, endpointSlices []discoveryv1.EndpointSlice, endpointFilter func(*discoveryv1.Endpoint) bool) adctypes.UpstreamNodes {
 	nodes := adctypes.UpstreamNodes{}
 	if len(endpointSlices) == 0 {
@@ -549,6 +694,15 @@ func (t *Translator) translateBackendsToUpstreams(
 	// backendRefs of the upstreams above, kept in sync so traffic-split weights
 	// stay bound to their own backend when some backendRefs are skipped.
 	validBackends := make([]gatewayv1.HTTPBackendRef, 0)
+	retry := t.translateHTTPRouteRetry(httpRoute, rule)
+	session, err := translateGatewaySessionPersistence(rule.SessionPersistence)
+	if err != nil {
+		t.Log.Error(err, "ignoring invalid session persistence", "httproute", utils.NamespacedName(httpRoute))
+	}
+	// policySession is the session persistence of the first backend with a
+	// BackendTrafficPolicy that configures one. It issues the token when the rule
+	// sets none.
+	var policySession *sessionPersistence
 
 	for _, backend := range rule.BackendRefs {
 		if backend.Namespace == nil {
@@ -568,7 +722,17 @@ func (t *Translator) translateBackendsToUpstreams(
 			enableWebsocket = ptr.To(true)
 		}
 
-		t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+		policy := findBackendTrafficPolicy(backend.BackendRef, tctx)
+		t.attachBackendTrafficPolicyToUpstream(tctx, policy, upstream)
+		// The session persistence of a BackendTrafficPolicy is only applied here: the
+		// token it hashes on is issued by the plugins of an HTTPRoute service.
+		backendSession := session
+		if backendSession == nil && policy != nil {
+			backendSession = translatePolicySessionPersistence(policy.Spec.SessionPersistence)
+			policySession = cmp.Or(policySession, backendSession)
+		}
+		retry.apply(upstream)
+		backendSession.apply(upstream)
 		upstream.Nodes = upNodes
 		if upstream.Scheme == "" {
 			upstream.Scheme = appProtocolToUpstreamScheme(protocol)
@@ -588,13 +752,26 @@ func (t *Translator) translateBackendsToUpstreams(
 		namespace := string(*backend.Namespace)
 		name := string(backend.Name)
 		upstreamName := adctypes.ComposeUpstreamNameForBackendRef(kind, namespace, name, port)
+		for _, key := range []string{retry.key(), backendSession.key()} {
+			if key != "" {
+				upstreamName += "_" + key
+			}
+		}
 		upstream.Name = upstreamName
 		upstream.Scheme = cmp.Or(upstream.Scheme, apiv2.SchemeHTTP)
+		t.AttachBackendTLSPolicyToUpstream(backend.BackendRef, tctx, upstream)
 		upstream.ID = id.GenID(upstreamName)
 		upstreams = append(upstreams, upstream)
 		validBackends = append(validBackends, backend)
 	}
 
+	if session := cmp.Or(session, policySession); session != nil {
+		if service.Plugins == nil {
+			service.Plugins = make(map[string]any)
+		}
+		maps.Copy(service.Plugins, session.plugins())
+	}
+
 	// Handle multiple backends with traffic-split plugin
 	if len(upstreams) == 0 {
 		// Create a default upstream if no valid backends
@@ -775,7 +952,8 @@ func (t *Translator) TranslateHTTPRoute(tctx *provider.TranslateContext, httpRou
 			}
 		}
 
-		t.fillHTTPRoutePoliciesForHTTPRoute(tctx, routes, rule)
+		t.fillHTTPRoutePoliciesForHTTPRoute(tctx, service, routes, rule)
+		t.fillHTTPRouteTimeouts(httpRoute, rule, routes)
 		service.Routes = routes
 
 		result.Services = append(result.Services, service)
@@ -784,6 +962,127 @@ func (t *Translator) TranslateHTTPRoute(tctx *provider.TranslateContext, httpRou
 	return result, nil
 }
 
+// parseHTTPRouteTimeouts returns the rule timeouts, a zero value means unset.
+// Timeouts the data plane cannot express are ignored, they are reported on the
+// route status by the controller.
+func (t *Translator) parseHTTPRouteTimeouts(httpRoute *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteRule) (request, backendRequest time.Duration) {
+	if rule.Timeouts == nil {
+		return 0, 0
+	}
+	if rule.Timeouts.Request != nil {
+		d, err := internaltypes.ParseProxyTimeout(*rule.Timeouts.Request)
+		if err != nil {
+			t.Log.Error(err, "ignoring unsupported request timeout", "httproute", utils.NamespacedName(httpRoute))
+		} else {
+			request = d
+		}
+	}
+	if rule.Timeouts.BackendRequest != nil {
+		d, err := internaltypes.ParseProxyTimeout(*rule.Timeouts.BackendRequest)
+		if err != nil {
+			t.Log.Error(err, "ignoring unsupported backendRequest timeout", "httproute", utils.NamespacedName(httpRoute))
+		} else {
+			backendRequest = d
+		}
+	}
+	return request, backendRequest
+}
+
+// fillHTTPRouteTimeouts applies the rule timeouts to the generated routes.
+//
+// backendRequest bounds every attempt to the backend and request bounds the whole
+// transaction, so each attempt is bounded by the shorter of the two. For a rule that
+// retries, the cap that request puts on retries is set on the upstreams, see
+// translateHTTPRouteRetry.
+func (t *Translator) fillHTTPRouteTimeouts(httpRoute *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteRule, routes []*adctypes.Route) {
+	request, backendRequest := t.parseHTTPRouteTimeouts(httpRoute, rule)
+
+	// An attempt can never outlast the whole request.
+	attempt := backendRequest
+	if request != 0 && (attempt == 0 || request < attempt) {
+		attempt = request
+	}
+	if attempt == 0 {
+		return
+	}
+	seconds := proxyTimeoutSeconds(attempt)
+	for _, route := range routes {
+		route.Timeout = &adctypes.Timeout{
+			Connect: seconds,
+			Read:    seconds,
+			Send:    seconds,
+		}
+	}
+}
+
+// proxyTimeoutSeconds converts d to the whole seconds route timeouts are expressed in.
+// A fraction of a second is rounded up, so that an attempt is never cut short, but not
+// past the longest timeout the data plane accepts.
+func proxyTimeoutSeconds(d time.Duration) int {
+	return int(min(math.Ceil(d.Seconds()), math.Floor(internaltypes.MaxProxyTimeout.Seconds())))
+}
+
+// httpRouteRetry is the retry behavior a rule applies to its upstreams.
+type httpRouteRetry struct {
+	// Retries is the number of retries of a request, nil keeps the upstream default.
+	Retries *int64
+	// RetryTimeout caps the time spent on a request and its retries, in seconds.
+	RetryTimeout *float64
+}
+
+// key identifies the retry settings. Upstreams are shared by ID, so an upstream of
+// the same backend with different retry settings is given a distinct name.
+func (r *httpRouteRetry) key() string {
+	if r == nil || (r.Retries == nil && r.RetryTimeout == nil) {
+		return ""
+	}
+	var retries int64 = -1
+	if r.Retries != nil {
+		retries = *r.Retries
+	}
+	var retryTimeout float64
+	if r.RetryTimeout != nil {
+		retryTimeout = *r.RetryTimeout
+	}
+	return fmt.Sprintf("retry-%d-%g", retries, retryTimeout)
+}
+
+func (r *httpRouteRetry) apply(upstream *adctypes.Upstream) {
+	if r == nil {
+		return
+	}
+	if r.Retries != nil {
+		upstream.Retries = ptr.To(*r.Retries)
+	}
+	if r.RetryTimeout != nil {
+		upstream.RetryTimeout = ptr.To(*r.RetryTimeout)
+	}
+}
+
+// translateHTTPRouteRetry translates the rule retry attempts (GEP-1731) and the cap the
+// request timeout puts on them. It returns nil when the rule sets no retry, so that rules
+// which only set timeouts keep sharing the upstreams of their backends.
+//
+// The data plane retries on connection errors and timeouts only, it can neither pick
+// the status codes that are retried nor wait between attempts. codes and backoff are
+// reported on the route status by the controller and left out here.
+func (t *Translator) translateHTTPRouteRetry(httpRoute *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteRule) *httpRouteRetry {
+	if rule.Retry == nil {
+		return nil
+	}
+	var retry httpRouteRetry
+	if request, _ := t.parseHTTPRouteTimeouts(httpRoute, rule); request != 0 && request != internaltypes.MaxProxyTimeout {
+		retry.RetryTimeout = ptr.To(request.Seconds())
+	}
+	if rule.Retry.Attempts != nil {
+		retry.Retries = ptr.To(int64(*rule.Retry.Attempts))
+	}
+	if retry.Retries == nil && retry.RetryTimeout == nil {
+		return nil
+	}
+	return &retry
+}
+
 func (t *Translator) translateGatewayHTTPRouteMatch(match *gatewayv1.HTTPRouteMatch) (*adctypes.Route, error) {
 	route := &adctypes.Route{}
 
@@ -19,6 +19,7 @@ package translator
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -401,7 +403,7 @@ func TestAttachBackendTrafficPolicyHealthCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ups := adctypes.NewDefaultUpstream()
-			translator.attachBackendTrafficPolicyToUpstream(tt.policy, ups)
+			translator.attachBackendTrafficPolicyToUpstream(nil, tt.policy, ups)
 			assert.Equal(t, tt.wantChecks, ups.Checks)
 		})
 	}
@@ -463,6 +465,7 @@ func TestAttachBackendTrafficPolicyToUpstreamSectionName(t *testing.T) {
 		name       string
 		ref        gatewayv1.BackendRef
 		policies   map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy
+		defaults   *v1alpha1.BackendTrafficPolicy
 		wantScheme string
 	}{
 		{
@@ -517,18 +520,85 @@ func TestAttachBackendTrafficPolicyToUpstreamSectionName(t *testing.T) {
 			},
 			wantScheme: "",
 		},
+		{
+			name: "Gateway defaults apply to a service without a policy",
+			ref:  newRef(adminPort),
+			policies: map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy{
+				{Namespace: namespace, Name: "p"}: newPolicy("p", webName, apiv2.SchemeHTTP),
+			},
+			defaults:   &v1alpha1.BackendTrafficPolicy{Spec: v1alpha1.BackendTrafficPolicySpec{Scheme: apiv2.SchemeHTTPS}},
+			wantScheme: apiv2.SchemeHTTPS,
+		},
+		{
+			name: "service policy takes precedence over Gateway defaults",
+			ref:  newRef(webPort),
+			policies: map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy{
+				{Namespace: namespace, Name: "p"}: newPolicy("p", webName, apiv2.SchemeHTTP),
+			},
+			defaults:   &v1alpha1.BackendTrafficPolicy{Spec: v1alpha1.BackendTrafficPolicySpec{Scheme: apiv2.SchemeHTTPS}},
+			wantScheme: apiv2.SchemeHTTP,
+		},
 	}
 
 	translator := NewTranslator(logr.Discard(), "")
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tctx := provider.NewDefaultTranslateContext(context.Background())
+			tctx.BackendTrafficPolicies = tt.policies
+			tctx.BackendTrafficPolicyDefaults = tt.defaults
+			tctx.Services = services
 			upstream := adctypes.NewDefaultUpstream()
-			translator.AttachBackendTrafficPolicyToUpstream(tt.ref, tt.policies, upstream, services)
+			translator.AttachBackendTrafficPolicyToUpstream(tt.ref, tctx, upstream)
 			assert.Equal(t, tt.wantScheme, upstream.Scheme)
 		})
 	}
 }
 
+func TestAttachBackendTrafficPolicyTLSToUpstream(t *testing.T) {
+	const namespace = "default"
+
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.Secrets[types.NamespacedName{Namespace: namespace, Name: "client"}] = &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+	newPolicy := func(scheme string, tls *v1alpha1.BackendTLS) *v1alpha1.BackendTrafficPolicy {
+		return &v1alpha1.BackendTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: namespace},
+			Spec:       v1alpha1.BackendTrafficPolicySpec{Scheme: scheme, TLS: tls},
+		}
+	}
+
+	translator := NewTranslator(logr.Discard(), "")
+
+	upstream := adctypes.NewDefaultUpstream()
+	translator.attachBackendTrafficPolicyToUpstream(tctx, newPolicy("", &v1alpha1.BackendTLS{
+		SecretRef: &corev1.LocalObjectReference{Name: "client"},
+		SNI:       "backend.example.com",
+		Verify:    true,
+	}), upstream)
+	assert.Equal(t, apiv2.SchemeHTTPS, upstream.Scheme)
+	assert.Equal(t, apiv2.PassHostRewrite, upstream.PassHost)
+	assert.Equal(t, "backend.example.com", upstream.UpstreamHost)
+	assert.Equal(t, &adctypes.ClientTLS{Cert: "cert", Key: "key", Verify: true}, upstream.TLS)
+
+	upstream = adctypes.NewDefaultUpstream()
+	translator.attachBackendTrafficPolicyToUpstream(tctx, newPolicy(apiv2.SchemeGRPC, &v1alpha1.BackendTLS{
+		SecretRef: &corev1.LocalObjectReference{Name: "missing"},
+	}), upstream)
+	assert.Equal(t, apiv2.SchemeGRPCS, upstream.Scheme)
+	assert.Nil(t, upstream.TLS, "a Secret that is not found should not configure a client certificate")
+
+	upstream = adctypes.NewDefaultUpstream()
+	translator.attachBackendTrafficPolicyToUpstream(tctx, newPolicy(apiv2.SchemeTCP, &v1alpha1.BackendTLS{
+		SecretRef: &corev1.LocalObjectReference{Name: "client"},
+	}), upstream)
+	assert.Equal(t, apiv2.SchemeTLS, upstream.Scheme)
+	assert.Equal(t, "cert", upstream.TLS.Cert)
+}
+
 func TestTranslateHTTPRouteTrafficSplitWeightsSkipUnresolvedBackends(t *testing.T) {
 	const namespace = "default"
 
@@ -599,3 +669,624 @@ func TestTranslateHTTPRouteTrafficSplitWeightsSkipUnresolvedBackends(t *testing.
 	assert.Equal(t, 30, cfg.Rules[0].WeightedUpstreams[1].Weight)
 	assert.Equal(t, svc.Upstreams[0].ID, cfg.Rules[0].WeightedUpstreams[1].UpstreamID)
 }
+
+func TestAttachBackendTLSPolicyToUpstream(t *testing.T) {
+	const (
+		namespace   = "default"
+		serviceName = "backend"
+		webPort     = int32(443)
+		webName     = "web"
+	)
+
+	newTranslateContext := func(policies ...*gatewayv1.BackendTLSPolicy) *provider.TranslateContext {
+		tctx := provider.NewDefaultTranslateContext(context.Background())
+		tctx.Services[types.NamespacedName{Namespace: namespace, Name: serviceName}] = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: webName, Port: webPort}},
+			},
+		}
+		for _, policy := range policies {
+			tctx.BackendTLSPolicies[types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}] = policy
+		}
+		return tctx
+	}
+
+	newPolicy := func(name, sectionName, hostname string) *gatewayv1.BackendTLSPolicy {
+		targetRef := gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Name: gatewayv1.ObjectName(serviceName),
+				Kind: gatewayv1.Kind(internaltypes.KindService),
+			},
+		}
+		if sectionName != "" {
+			targetRef.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
+		}
+		return &gatewayv1.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{targetRef},
+				Validation: gatewayv1.BackendTLSPolicyValidation{
+					CACertificateRefs: []gatewayv1.LocalObjectReference{{
+						Kind: gatewayv1.Kind(internaltypes.KindConfigMap),
+						Name: "ca",
+					}},
+					Hostname: gatewayv1.PreciseHostname(hostname),
+				},
+			},
+		}
+	}
+
+	ref := gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name:      gatewayv1.ObjectName(serviceName),
+			Namespace: ptr.To(gatewayv1.Namespace(namespace)),
+			Port:      ptr.To(webPort),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		scheme     string
+		policies   []*gatewayv1.BackendTLSPolicy
+		wantScheme string
+		wantSNI    string
+	}{
+		{
+			name:       "no policy keeps plain text",
+			scheme:     apiv2.SchemeHTTP,
+			wantScheme: apiv2.SchemeHTTP,
+		},
+		{
+			name:       "http upstream is upgraded to https",
+			scheme:     apiv2.SchemeHTTP,
+			policies:   []*gatewayv1.BackendTLSPolicy{newPolicy("p", "", "backend.example.com")},
+			wantScheme: apiv2.SchemeHTTPS,
+			wantSNI:    "backend.example.com",
+		},
+		{
+			name:       "grpc upstream is upgraded to grpcs",
+			scheme:     apiv2.SchemeGRPC,
+			policies:   []*gatewayv1.BackendTLSPolicy{newPolicy("p", "", "backend.example.com")},
+			wantScheme: apiv2.SchemeGRPCS,
+			wantSNI:    "backend.example.com",
+		},
+		{
+			name:   "port-specific policy takes precedence over whole-service policy",
+			scheme: apiv2.SchemeHTTP,
+			policies: []*gatewayv1.BackendTLSPolicy{
+				newPolicy("generic", "", "generic.example.com"),
+				newPolicy("specific", webName, "specific.example.com"),
+			},
+			wantScheme: apiv2.SchemeHTTPS,
+			wantSNI:    "specific.example.com",
+		},
+		{
+			name:       "sectionName does not match the backend port name",
+			scheme:     apiv2.SchemeHTTP,
+			policies:   []*gatewayv1.BackendTLSPolicy{newPolicy("p", "admin", "backend.example.com")},
+			wantScheme: apiv2.SchemeHTTP,
+		},
+	}
+
+	translator := NewTranslator(logr.Discard(), "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := adctypes.NewDefaultUpstream()
+			upstream.Scheme = tt.scheme
+			translator.AttachBackendTLSPolicyToUpstream(ref, newTranslateContext(tt.policies...), upstream)
+			assert.Equal(t, tt.wantScheme, upstream.Scheme)
+			// The hostname reaches the handshake as the upstream host; APISIX has
+			// nowhere to carry the CA bundle.
+			assert.Nil(t, upstream.TLS)
+			if tt.wantSNI == "" {
+				assert.Empty(t, upstream.PassHost)
+				assert.Empty(t, upstream.UpstreamHost)
+				return
+			}
+			assert.Equal(t, apiv2.PassHostRewrite, upstream.PassHost)
+			assert.Equal(t, tt.wantSNI, upstream.UpstreamHost)
+		})
+	}
+}
+
+func TestTranslateHTTPRouteTimeouts(t *testing.T) {
+	const (
+		namespace   = "default"
+		serviceName = "backend"
+		portNumber  = int32(80)
+	)
+
+	tests := []struct {
+		name             string
+		timeouts         *gatewayv1.HTTPRouteTimeouts
+		retry            *gatewayv1.HTTPRouteRetry
+		wantTimeout      *adctypes.Timeout
+		wantRetryTimeout *float64
+	}{
+		{
+			name: "no timeouts",
+		},
+		{
+			name:             "request bounds each attempt and the retries",
+			timeouts:         &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("500ms"))},
+			retry:            &gatewayv1.HTTPRouteRetry{Attempts: ptr.To(2)},
+			wantTimeout:      &adctypes.Timeout{Connect: 1, Read: 1, Send: 1},
+			wantRetryTimeout: ptr.To(0.5),
+		},
+		{
+			name: "backendRequest bounds each attempt",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("10s")),
+				BackendRequest: ptr.To(gatewayv1.Duration("2s")),
+			},
+			retry:            &gatewayv1.HTTPRouteRetry{},
+			wantTimeout:      &adctypes.Timeout{Connect: 2, Read: 2, Send: 2},
+			wantRetryTimeout: ptr.To(float64(10)),
+		},
+		{
+			name:        "request without retry leaves the upstream unchanged",
+			timeouts:    &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("10s"))},
+			wantTimeout: &adctypes.Timeout{Connect: 10, Read: 10, Send: 10},
+		},
+		{
+			name:        "zero duration uses the longest timeout",
+			timeouts:    &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptr.To(gatewayv1.Duration("0s"))},
+			wantTimeout: &adctypes.Timeout{Connect: 2147483, Read: 2147483, Send: 2147483},
+		},
+		{
+			name:     "unsupported duration is ignored",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("9999h"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator := NewTranslator(logr.Discard(), "")
+			tctx := provider.NewDefaultTranslateContext(context.Background())
+
+			serviceKey := types.NamespacedName{Namespace: namespace, Name: serviceName}
+			tctx.Services[serviceKey] = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: portNumber}},
+				},
+			}
+			tctx.EndpointSlices[serviceKey] = []discoveryv1.EndpointSlice{{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend-1", Namespace: namespace},
+				Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(portNumber)}},
+				Endpoints: []discoveryv1.Endpoint{{
+					Addresses:  []string{"10.0.0.1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+				}},
+			}}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: namespace},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{{
+						BackendRefs: []gatewayv1.HTTPBackendRef{{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: gatewayv1.ObjectName(serviceName),
+									Port: ptr.To(portNumber),
+								},
+							},
+						}},
+						Timeouts: tt.timeouts,
+						Retry:    tt.retry,
+					}},
+				},
+			}
+
+			result, err := translator.TranslateHTTPRoute(tctx, route)
+			require.NoError(t, err)
+			require.Len(t, result.Services, 1)
+			require.Len(t, result.Services[0].Routes, 1)
+			assert.Equal(t, tt.wantTimeout, result.Services[0].Routes[0].Timeout)
+			assert.Equal(t, tt.wantRetryTimeout, result.Services[0].Upstream.RetryTimeout)
+		})
+	}
+}
+
+func TestTranslateHTTPRouteRetry(t *testing.T) {
+	const (
+		namespace  = "default"
+		portNumber = int32(80)
+	)
+
+	translator := NewTranslator(logr.Discard(), "")
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	backendRefs := make([]gatewayv1.HTTPBackendRef, 0, 2)
+	for i, name := range []string{"backend-a", "backend-b"} {
+		serviceKey := types.NamespacedName{Namespace: namespace, Name: name}
+		tctx.Services[serviceKey] = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: portNumber}},
+			},
+		}
+		tctx.EndpointSlices[serviceKey] = []discoveryv1.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-1", Namespace: namespace},
+			Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(portNumber)}},
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{fmt.Sprintf("10.0.0.%d", i+1)},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+			}},
+		}}
+		backendRefs = append(backendRefs, gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(name),
+					Port: ptr.To(portNumber),
+				},
+			},
+		})
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: backendRefs,
+					Retry: &gatewayv1.HTTPRouteRetry{
+						// codes and backoff are reported on the route status, only
+						// attempts reaches the data plane.
+						Codes:    []gatewayv1.HTTPRouteRetryStatusCode{502, 503},
+						Attempts: ptr.To(2),
+						Backoff:  ptr.To(gatewayv1.Duration("100ms")),
+					},
+				},
+				{
+					BackendRefs: backendRefs,
+				},
+				{
+					BackendRefs: backendRefs,
+					Timeouts:    &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("10s"))},
+				},
+			},
+		},
+	}
+
+	result, err := translator.TranslateHTTPRoute(tctx, route)
+	require.NoError(t, err)
+	require.Len(t, result.Services, 3)
+
+	withRetry, withoutRetry, withTimeout := result.Services[0], result.Services[1], result.Services[2]
+	require.NotNil(t, withRetry.Upstream)
+	require.Len(t, withRetry.Upstreams, 1)
+	assert.Equal(t, ptr.To(int64(2)), withRetry.Upstream.Retries)
+	assert.Equal(t, ptr.To(int64(2)), withRetry.Upstreams[0].Retries)
+	// Nothing but the split between the two backends.
+	assert.Len(t, withRetry.Plugins, 1)
+	assert.Contains(t, withRetry.Plugins, "traffic-split")
+
+	require.Len(t, withoutRetry.Upstreams, 1)
+	assert.Nil(t, withoutRetry.Upstreams[0].Retries)
+
+	// Both rules reference backend-b, the upstream is split since the retry settings differ.
+	assert.NotEqual(t, withRetry.Upstreams[0].ID, withoutRetry.Upstreams[0].ID)
+
+	// A rule that only sets timeouts does not retry differently, it shares the upstream.
+	require.Len(t, withTimeout.Upstreams, 1)
+	assert.Nil(t, withTimeout.Upstreams[0].RetryTimeout)
+	assert.Equal(t, withoutRetry.Upstreams[0].ID, withTimeout.Upstreams[0].ID)
+}
+
+func TestTranslateHTTPRouteSessionPersistence(t *testing.T) {
+	const (
+		namespace  = "default"
+		portNumber = int32(80)
+	)
+
+	translator := NewTranslator(logr.Discard(), "")
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	serviceKey := types.NamespacedName{Namespace: namespace, Name: "backend"}
+	tctx.Services[serviceKey] = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceKey.Name, Namespace: namespace},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: portNumber}},
+		},
+	}
+	tctx.EndpointSlices[serviceKey] = []discoveryv1.EndpointSlice{{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend-1", Namespace: namespace},
+		Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(portNumber)}},
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+		}},
+	}}
+	tctx.BackendTrafficPolicies[types.NamespacedName{Namespace: namespace, Name: "sticky"}] = &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "sticky", Namespace: namespace},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TargetRefs: []v1alpha1.BackendPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Kind: "Service",
+					Name: gatewayv1.ObjectName(serviceKey.Name),
+				},
+			}},
+			SessionPersistence: &v1alpha1.SessionPersistence{
+				Type:        "Header",
+				SessionName: "x-session",
+			},
+		},
+	}
+	backendRefs := []gatewayv1.HTTPBackendRef{{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(serviceKey.Name),
+				Port: ptr.To(portNumber),
+			},
+		},
+	}}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: backendRefs,
+					SessionPersistence: &gatewayv1.SessionPersistence{
+						SessionName:     ptr.To("sticky"),
+						AbsoluteTimeout: ptr.To(gatewayv1.Duration("1h")),
+						Type:            ptr.To(gatewayv1.CookieBasedSessionPersistence),
+						CookieConfig: &gatewayv1.CookieConfig{
+							LifetimeType: ptr.To(gatewayv1.PermanentCookieLifetimeType),
+						},
+					},
+				},
+				{
+					BackendRefs: backendRefs,
+				},
+			},
+		},
+	}
+
+	result, err := translator.TranslateHTTPRoute(tctx, route)
+	require.NoError(t, err)
+	require.Len(t, result.Services, 2)
+
+	// The rule session persistence takes precedence over the BackendTrafficPolicy.
+	// Both hash on the token the pre-function sets before balancing.
+	cookie := result.Services[0]
+	require.NotNil(t, cookie.Upstream)
+	assert.Equal(t, adctypes.Chash, cookie.Upstream.Type)
+	assert.Equal(t, "header", cookie.Upstream.HashOn)
+	assert.Equal(t, sessionHashHeader, cookie.Upstream.Key)
+	accept, ok := cookie.Plugins[adctypes.PluginServerlessPreFunction].(*adctypes.ServerlessConfig)
+	require.True(t, ok)
+	assert.Equal(t, "rewrite", accept.Phase)
+	require.Len(t, accept.Functions, 1)
+	assert.Contains(t, accept.Functions[0], `local name, header, absolute = "sticky", false, 3600`)
+	assert.Contains(t, accept.Functions[0], `core.request.set_header(ctx, "X-APISIX-Session", value)`)
+	issue, ok := cookie.Plugins[adctypes.PluginServerlessPostFunction].(*adctypes.ServerlessConfig)
+	require.True(t, ok)
+	assert.Equal(t, "header_filter", issue.Phase)
+	require.Len(t, issue.Functions, 1)
+	assert.Contains(t, issue.Functions[0], `local name, header = "sticky", false`)
+	assert.Contains(t, issue.Functions[0], `local absolute, idle, permanent = 3600, 0, true`)
+
+	header := result.Services[1]
+	require.NotNil(t, header.Upstream)
+	assert.Equal(t, adctypes.Chash, header.Upstream.Type)
+	assert.Equal(t, "header", header.Upstream.HashOn)
+	assert.Equal(t, sessionHashHeader, header.Upstream.Key)
+	accept, ok = header.Plugins[adctypes.PluginServerlessPreFunction].(*adctypes.ServerlessConfig)
+	require.True(t, ok)
+	assert.Contains(t, accept.Functions[0], `local name, header, absolute = "x-session", true, 0`)
+	issue, ok = header.Plugins[adctypes.PluginServerlessPostFunction].(*adctypes.ServerlessConfig)
+	require.True(t, ok)
+	assert.Contains(t, issue.Functions[0], `local name, header = "x-session", true`)
+
+	// Other route kinds have no plugins issuing the token, the policy leaves their
+	// upstreams balanced as configured.
+	upstream := adctypes.NewDefaultUpstream()
+	translator.attachBackendTrafficPolicyToUpstream(tctx, tctx.BackendTrafficPolicies[types.NamespacedName{Namespace: namespace, Name: "sticky"}], upstream)
+	assert.NotEqual(t, adctypes.Chash, upstream.Type)
+	assert.Empty(t, upstream.HashOn)
+}
+
+func TestFillPluginsFromHTTPRouteRequestMirrorFilters(t *testing.T) {
+	mirror := func(name string, percent *int32, fraction *gatewayv1.Fraction) gatewayv1.HTTPRouteFilter {
+		return gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+				BackendRef: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: ptr.To(gatewayv1.PortNumber(8080))},
+				Percent:    percent,
+				Fraction:   fraction,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		filters []gatewayv1.HTTPRouteFilter
+		want    *adctypes.RequestMirror
+	}{
+		{
+			name:    "all requests by default",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", nil, nil)},
+			want:    &adctypes.RequestMirror{Host: "http://shadow.default.svc.cluster.local:8080"},
+		},
+		{
+			name:    "percent",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", ptr.To(int32(25)), nil)},
+			want:    &adctypes.RequestMirror{Host: "http://shadow.default.svc.cluster.local:8080", SampleRatio: 0.25},
+		},
+		{
+			name:    "fraction with the default denominator",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", nil, &gatewayv1.Fraction{Numerator: 5})},
+			want:    &adctypes.RequestMirror{Host: "http://shadow.default.svc.cluster.local:8080", SampleRatio: 0.05},
+		},
+		{
+			name:    "fraction below the smallest sample ratio",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", nil, &gatewayv1.Fraction{Numerator: 1, Denominator: ptr.To(int32(1000000))})},
+			want:    &adctypes.RequestMirror{Host: "http://shadow.default.svc.cluster.local:8080", SampleRatio: 0.00001},
+		},
+		{
+			name:    "full fraction",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", nil, &gatewayv1.Fraction{Numerator: 3, Denominator: ptr.To(int32(3))})},
+			want:    &adctypes.RequestMirror{Host: "http://shadow.default.svc.cluster.local:8080"},
+		},
+		{
+			name:    "zero percent mirrors nothing",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("shadow", ptr.To(int32(0)), nil)},
+		},
+		{
+			name:    "only the first mirror is applied",
+			filters: []gatewayv1.HTTPRouteFilter{mirror("first", ptr.To(int32(10)), nil), mirror("second", nil, nil)},
+			want:    &adctypes.RequestMirror{Host: "http://first.default.svc.cluster.local:8080", SampleRatio: 0.1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			translator := NewTranslator(logr.Discard(), "")
+			plugins := make(adctypes.Plugins)
+			translator.fillPluginsFromHTTPRouteFilters(plugins, "default", tc.filters, nil, provider.NewDefaultTranslateContext(context.Background()))
+			if tc.want == nil {
+				assert.NotContains(t, plugins, adctypes.PluginProxyMirror)
+				return
+			}
+			assert.Equal(t, tc.want, plugins[adctypes.PluginProxyMirror])
+		})
+	}
+}
+
+func TestFillPluginsFromHTTPRouteExternalAuthFilter(t *testing.T) {
+	extAuth := func(protocol gatewayv1.HTTPRouteExternalAuthProtocol, namespace string, http *gatewayv1.HTTPAuthConfig, body *gatewayv1.ForwardBodyConfig) gatewayv1.HTTPRouteFilter {
+		ref := gatewayv1.BackendObjectReference{Name: "auth", Port: ptr.To(gatewayv1.PortNumber(9000))}
+		if namespace != "" {
+			ref.Namespace = ptr.To(gatewayv1.Namespace(namespace))
+		}
+		return gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterExternalAuth,
+			ExternalAuth: &gatewayv1.HTTPExternalAuthFilter{
+				ExternalAuthProtocol: protocol,
+				BackendRef:           ref,
+				HTTPAuthConfig:       http,
+				ForwardBody:          body,
+			},
+		}
+	}
+	abort := &adctypes.FaultInjectionConfig{
+		Abort: &adctypes.FaultInjectionAbortConfig{
+			HTTPStatus: 500,
+			Body:       "External authorization is not available",
+		},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		filter      gatewayv1.HTTPRouteFilter
+		want        *adctypes.ForwardAuthConfig
+		wantAborted bool
+	}{
+		{
+			name:   "defaults",
+			filter: extAuth(gatewayv1.HTTPRouteExternalAuthHTTPProtocol, "", nil, nil),
+			want: &adctypes.ForwardAuthConfig{
+				URI:            "http://auth.default.svc.cluster.local:9000",
+				SSLVerify:      true,
+				RequestHeaders: []string{"Authorization"},
+			},
+		},
+		{
+			name: "headers",
+			filter: extAuth(gatewayv1.HTTPRouteExternalAuthHTTPProtocol, "", &gatewayv1.HTTPAuthConfig{
+				AllowedRequestHeaders:  []string{"authorization", "X-Tenant"},
+				AllowedResponseHeaders: []string{"X-User-ID"},
+			}, nil),
+			want: &adctypes.ForwardAuthConfig{
+				URI:             "http://auth.default.svc.cluster.local:9000",
+				SSLVerify:       true,
+				RequestHeaders:  []string{"Authorization", "X-Tenant"},
+				UpstreamHeaders: []string{"X-User-ID"},
+			},
+		},
+		{
+			name: "path",
+			filter: extAuth(gatewayv1.HTTPRouteExternalAuthHTTPProtocol, "", &gatewayv1.HTTPAuthConfig{
+				Path: "/verify",
+			}, nil),
+			wantAborted: true,
+		},
+		{
+			name:   "forward body",
+			filter: extAuth(gatewayv1.HTTPRouteExternalAuthHTTPProtocol, "", nil, &gatewayv1.ForwardBodyConfig{MaxSize: 1024}),
+			want: &adctypes.ForwardAuthConfig{
+				URI:            "http://auth.default.svc.cluster.local:9000",
+				SSLVerify:      true,
+				RequestHeaders: []string{"Authorization"},
+				RequestMethod:  "POST",
+			},
+		},
+		{
+			name:        "unresolved backend",
+			filter:      extAuth(gatewayv1.HTTPRouteExternalAuthHTTPProtocol, "other", nil, nil),
+			wantAborted: true,
+		},
+		{
+			name:        "grpc",
+			filter:      extAuth(gatewayv1.HTTPRouteExternalAuthGRPCProtocol, "", nil, nil),
+			wantAborted: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			translator := NewTranslator(logr.Discard(), "")
+			tctx := provider.NewDefaultTranslateContext(context.Background())
+			tctx.Services[types.NamespacedName{Namespace: "default", Name: "auth"}] = &corev1.Service{}
+			plugins := make(adctypes.Plugins)
+			translator.fillPluginsFromHTTPRouteFilters(plugins, "default", []gatewayv1.HTTPRouteFilter{tc.filter}, nil, tctx)
+			if tc.wantAborted {
+				assert.NotContains(t, plugins, adctypes.PluginForwardAuth)
+				assert.Equal(t, abort, plugins[adctypes.PluginFaultInjection])
+				return
+			}
+			assert.Equal(t, tc.want, plugins[adctypes.PluginForwardAuth])
+		})
+	}
+}
+
+func TestFillPluginsFromHTTPRoutePolicies(t *testing.T) {
+	plugin := func(name, config string) v1alpha1.Plugin {
+		return v1alpha1.Plugin{Name: name, Config: apiextensionsv1.JSON{Raw: []byte(config)}}
+	}
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.PluginConfigs[types.NamespacedName{Namespace: "default", Name: "limits"}] = &v1alpha1.PluginConfig{
+		Spec: v1alpha1.PluginConfigSpec{Plugins: []v1alpha1.Plugin{
+			plugin("limit-count", `{"count":3}`),
+			plugin("cors", `{}`),
+		}},
+	}
+	policies := []v1alpha1.HTTPRoutePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "older"},
+			Spec: v1alpha1.HTTPRoutePolicySpec{
+				Plugins:         []v1alpha1.Plugin{plugin("limit-count", `{"count":1}`)},
+				PluginConfigRef: &corev1.LocalObjectReference{Name: "limits"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "newer"},
+			Spec: v1alpha1.HTTPRoutePolicySpec{
+				Plugins: []v1alpha1.Plugin{
+					plugin("limit-count", `{"count":2}`),
+					plugin(adctypes.PluginProxyRewrite, `{"uri":"/policy"}`),
+					plugin("key-auth", `{}`),
+				},
+			},
+		},
+	}
+	plugins := adctypes.Plugins{
+		adctypes.PluginProxyRewrite: map[string]any{"uri": "/filter"},
+	}
+
+	NewTranslator(logr.Discard(), "").fillPluginsFromHTTPRoutePolicies(tctx, plugins, policies)
+
+	assert.Equal(t, adctypes.Plugins{
+		adctypes.PluginProxyRewrite: map[string]any{"uri": "/filter"},
+		"limit-count":               map[string]any{"count": float64(1)},
+		"cors":                      map[string]any{},
+		"key-auth":                  map[string]any{},
+	}, plugins)
+}
@@ -189,7 +189,7 @@ func (t *Translator) resolveIngressUpstream(
 		ns = config.ServiceNamespace
 	}
 	backendRef := convertBackendRef(ns, backendService.Name, internaltypes.KindService)
-	t.AttachBackendTrafficPolicyToUpstream(backendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+	t.AttachBackendTrafficPolicyToUpstream(backendRef, tctx, upstream)
 	if config != nil {
 		upConfig := config.Upstream
 		if upConfig.Scheme != "" {
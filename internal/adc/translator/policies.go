@@ -29,6 +29,8 @@ import (
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	sslutils "github.com/apache/apisix-ingress-controller/internal/ssl"
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -40,9 +42,35 @@ func convertBackendRef(namespace, name, kind string) gatewayv1.BackendRef {
 	return backendRef
 }
 
-func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendRef, policies map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy, upstream *adctypes.Upstream, services map[types.NamespacedName]*corev1.Service) {
+func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendRef, tctx *provider.TranslateContext, upstream *adctypes.Upstream) {
+	t.attachBackendTrafficPolicyToUpstream(tctx, findBackendTrafficPolicy(ref, tctx), upstream)
+}
+
+// AttachBackendTrafficPolicyToUpstreamWithScheme is AttachBackendTrafficPolicyToUpstream for
+// the upstream of a route whose protocol is not HTTP, such as a GRPCRoute or a TCPRoute: a
+// policy with TLS but no scheme makes it a TLS variant of scheme rather than `https`.
+func (t *Translator) AttachBackendTrafficPolicyToUpstreamWithScheme(ref gatewayv1.BackendRef, tctx *provider.TranslateContext, upstream *adctypes.Upstream, scheme string) {
+	policy := findBackendTrafficPolicy(ref, tctx)
+	if policy != nil && policy.Spec.TLS != nil && policy.Spec.Scheme == "" {
+		policy = policy.DeepCopy()
+		policy.Spec.Scheme = scheme
+	}
+	t.attachBackendTrafficPolicyToUpstream(tctx, policy, upstream)
+}
+
+// findBackendTrafficPolicy returns the BackendTrafficPolicy that applies to the
+// backend ref, falling back to the defaults of the Gateways of the route, or nil
+// when there is none.
+func findBackendTrafficPolicy(ref gatewayv1.BackendRef, tctx *provider.TranslateContext) *v1alpha1.BackendTrafficPolicy {
+	if policy := findServiceBackendTrafficPolicy(ref, tctx.BackendTrafficPolicies, tctx.Services); policy != nil {
+		return policy
+	}
+	return tctx.BackendTrafficPolicyDefaults
+}
+
+func findServiceBackendTrafficPolicy(ref gatewayv1.BackendRef, policies map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy, services map[types.NamespacedName]*corev1.Service) *v1alpha1.BackendTrafficPolicy {
 	if len(policies) == 0 {
-		return
+		return nil
 	}
 	// Resolve the backend ref group/kind, applying the Gateway API defaults
 	// (empty group = core, Service kind) so a targetRef is only matched against
@@ -82,14 +110,10 @@ func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendR
 			genericPolicy = po
 		}
 	}
-	policy := specificPolicy
-	if policy == nil {
-		policy = genericPolicy
-	}
-	if policy == nil {
-		return
+	if specificPolicy != nil {
+		return specificPolicy
 	}
-	t.attachBackendTrafficPolicyToUpstream(policy, upstream)
+	return genericPolicy
 }
 
 // backendRefMatchesSectionName reports whether the backend ref resolves to the
@@ -111,7 +135,7 @@ func backendRefMatchesSectionName(ref gatewayv1.BackendRef, namespace, sectionNa
 	return false
 }
 
-func (t *Translator) attachBackendTrafficPolicyToUpstream(policy *v1alpha1.BackendTrafficPolicy, upstream *adctypes.Upstream) {
+func (t *Translator) attachBackendTrafficPolicyToUpstream(tctx *provider.TranslateContext, policy *v1alpha1.BackendTrafficPolicy, upstream *adctypes.Upstream) {
 	if policy == nil {
 		return
 	}
@@ -137,6 +161,102 @@ func (t *Translator) attachBackendTrafficPolicyToUpstream(policy *v1alpha1.Backe
 	if policy.Spec.HealthCheck != nil {
 		upstream.Checks = translateBTPHealthCheck(policy.Spec.HealthCheck)
 	}
+	if policy.Spec.TLS != nil {
+		t.attachBackendTLSToUpstream(tctx, policy.Namespace, policy.Spec.TLS, upstream)
+	}
+}
+
+// attachBackendTLSToUpstream makes the upstream reach the backend over TLS, presenting the
+// client certificate of the Secret that the policy references. As for BackendTLSPolicy, the
+// SNI is passed as upstream_host, which APISIX sends in the handshake.
+func (t *Translator) attachBackendTLSToUpstream(tctx *provider.TranslateContext, namespace string, config *v1alpha1.BackendTLS, upstream *adctypes.Upstream) {
+	switch upstream.Scheme {
+	case apiv2.SchemeGRPC, apiv2.SchemeGRPCS:
+		upstream.Scheme = apiv2.SchemeGRPCS
+	case apiv2.SchemeTCP, apiv2.SchemeTLS:
+		upstream.Scheme = apiv2.SchemeTLS
+	case apiv2.SchemeUDP:
+		t.Log.Info("ignoring the TLS of BackendTrafficPolicy for a udp upstream")
+		return
+	default:
+		upstream.Scheme = apiv2.SchemeHTTPS
+	}
+	if config.SNI != "" {
+		upstream.PassHost = apiv2.PassHostRewrite
+		upstream.UpstreamHost = string(config.SNI)
+	}
+
+	tls := &adctypes.ClientTLS{Verify: config.Verify}
+	if config.SecretRef != nil {
+		secretNN := types.NamespacedName{Namespace: namespace, Name: config.SecretRef.Name}
+		cert, key, err := sslutils.ExtractKeyPair(tctx.Secrets[secretNN], true)
+		if err != nil {
+			t.Log.Error(err, "failed to extract the client certificate of BackendTrafficPolicy", "secret", secretNN)
+		} else {
+			tls.Cert, tls.Key = string(cert), string(key)
+		}
+	}
+	if tls.Cert != "" || tls.Verify {
+		upstream.TLS = tls
+	}
+}
+
+// AttachBackendTLSPolicyToUpstream configures the upstream to reach the backend over TLS
+// when a BackendTLSPolicy targets the backend Service. As for BackendTrafficPolicy, a
+// targetRef with sectionName takes precedence over a whole-Service targetRef.
+func (t *Translator) AttachBackendTLSPolicyToUpstream(ref gatewayv1.BackendRef, tctx *provider.TranslateContext, upstream *adctypes.Upstream) {
+	if len(tctx.BackendTLSPolicies) == 0 {
+		return
+	}
+	if ref.Group != nil && *ref.Group != "" {
+		return
+	}
+	if ref.Kind != nil && *ref.Kind != internaltypes.KindService {
+		return
+	}
+	var genericPolicy, specificPolicy *gatewayv1.BackendTLSPolicy
+	for _, po := range tctx.BackendTLSPolicies {
+		if ref.Namespace != nil && string(*ref.Namespace) != po.Namespace {
+			continue
+		}
+		for _, targetRef := range po.Spec.TargetRefs {
+			if targetRef.Group != "" || targetRef.Kind != internaltypes.KindService || targetRef.Name != ref.Name {
+				continue
+			}
+			if targetRef.SectionName != nil && *targetRef.SectionName != "" {
+				if backendRefMatchesSectionName(ref, po.Namespace, string(*targetRef.SectionName), tctx.Services) {
+					specificPolicy = po
+				}
+				continue
+			}
+			genericPolicy = po
+		}
+	}
+	policy := specificPolicy
+	if policy == nil {
+		policy = genericPolicy
+	}
+	if policy == nil {
+		return
+	}
+	t.attachBackendTLSPolicyToUpstream(policy, upstream)
+}
+
+// attachBackendTLSPolicyToUpstream makes the upstream reach the backend over TLS. APISIX
+// takes the server name it sends in the handshake from the upstream host, so the policy
+// hostname is passed as upstream_host, which also makes it the Host header the backend sees.
+//
+// APISIX has no way to verify the certificate of an HTTP upstream, neither against
+// caCertificateRefs nor against the system CAs; the policy status says so.
+func (t *Translator) attachBackendTLSPolicyToUpstream(policy *gatewayv1.BackendTLSPolicy, upstream *adctypes.Upstream) {
+	switch upstream.Scheme {
+	case apiv2.SchemeGRPC, apiv2.SchemeGRPCS:
+		upstream.Scheme = apiv2.SchemeGRPCS
+	default:
+		upstream.Scheme = apiv2.SchemeHTTPS
+	}
+	upstream.PassHost = apiv2.PassHostRewrite
+	upstream.UpstreamHost = string(policy.Spec.Validation.Hostname)
 }
 
 func translateBTPHealthCheck(hc *v1alpha1.HealthCheck) *adctypes.UpstreamHealthCheck {
@@ -260,6 +380,21 @@ func (t *Translator) AttachL4RoutePolicyPlugins(
 	}
 }
 
+// AttachL4RoutePolicyDefaults adds the default plugins that the policies targeting the
+// Gateways of the route configure, unless the route configures them already.
+func (t *Translator) AttachL4RoutePolicyDefaults(defaults *v1alpha1.L4RoutePolicy, plugins adctypes.Plugins) {
+	if defaults == nil {
+		return
+	}
+	defaultPlugins := make(adctypes.Plugins)
+	t.mergeL4PolicyPlugins(defaults, defaultPlugins)
+	for name, config := range defaultPlugins {
+		if _, ok := plugins[name]; !ok {
+			plugins[name] = config
+		}
+	}
+}
+
 func (t *Translator) mergeL4PolicyPlugins(policy *v1alpha1.L4RoutePolicy, plugins adctypes.Plugins) {
 	for _, plugin := range policy.Spec.Plugins {
 		cfg := make(map[string]any)
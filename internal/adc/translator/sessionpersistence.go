@@ -0,0 +1,219 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+)
+
+// DefaultSessionName is the cookie or header name used when a session
+// persistence configuration leaves the session name empty.
+const DefaultSessionName = "apisix_session"
+
+// sessionHashHeader is the request header the upstreams of a session are hashed on. It
+// is set before the upstream node is picked, so a new session is balanced on the token
+// it is about to be issued, and every later request carrying that token lands on the
+// node that served the first one.
+const sessionHashHeader = "X-APISIX-Session"
+
+// sessionAcceptFunction is the Lua function that reads the session token of a request, or
+// mints a new one, before the upstream node is picked. It runs in the rewrite phase and
+// copies the token to sessionHashHeader for the chash balancer. The token ends with its
+// issue time so the absolute timeout can be enforced on the data plane.
+const sessionAcceptFunction = `return function(conf, ctx)
+  local core = require("apisix.core")
+  local name, header, absolute = %q, %t, %d
+  local now = ngx.time()
+  local value
+  if header then
+    value = core.request.header(ctx, name)
+    if type(value) == "table" then
+      value = value[1]
+    end
+  else
+    value = ngx.var["cookie_" .. name]
+  end
+  local issued = value and tonumber(value:match("%%.(%%d+)$"))
+  if value and absolute > 0 and (not issued or now - issued >= absolute) then
+    value = nil
+  end
+  local minted = not value
+  if minted then
+    issued = now
+    value = ngx.var.request_id .. "." .. now
+  end
+  ctx.session_persistence = { value = value, issued = issued or now, minted = minted }
+  core.request.set_header(ctx, %q, value)
+end`
+
+// sessionIssueFunction is the Lua function that hands the session token the request was
+// balanced on back to the client. It runs in the header_filter phase: a new token is
+// always returned, a cookie with an idle timeout is refreshed on every response.
+const sessionIssueFunction = `return function(conf, ctx)
+  local session = ctx.session_persistence
+  if not session then
+    return
+  end
+  local name, header = %q, %t
+  local absolute, idle, permanent = %d, %d, %t
+  if not session.minted and (header or idle == 0) then
+    return
+  end
+  if header then
+    ngx.header[name] = session.value
+    return
+  end
+  local cookie = name .. "=" .. session.value .. "; Path=/; HttpOnly"
+  if permanent then
+    local max_age = idle
+    if absolute > 0 then
+      local left = absolute - (ngx.time() - session.issued)
+      if max_age == 0 or left < max_age then
+        max_age = left
+      end
+    end
+    if max_age > 0 then
+      cookie = cookie .. "; Max-Age=" .. max_age
+    end
+  end
+  local cookies = ngx.header["Set-Cookie"]
+  if type(cookies) == "table" then
+    table.insert(cookies, cookie)
+  elseif cookies then
+    cookies = { cookies, cookie }
+  else
+    cookies = cookie
+  end
+  ngx.header["Set-Cookie"] = cookies
+end`
+
+// sessionPersistence is the session persistence applied to the upstreams of
+// an HTTPRoute rule or of a BackendTrafficPolicy target.
+type sessionPersistence struct {
+	// Header selects header based sessions instead of cookie based ones.
+	Header bool
+	// Name is the name of the cookie or header carrying the session token.
+	Name string
+	// AbsoluteTimeout expires a session after it was issued, zero never expires it.
+	AbsoluteTimeout time.Duration
+	// IdleTimeout expires a cookie session after it was last used, zero never expires it.
+	IdleTimeout time.Duration
+	// Permanent gives the session cookie a Max-Age instead of the browser session lifetime.
+	Permanent bool
+}
+
+// translateGatewaySessionPersistence translates the session persistence of an
+// HTTPRoute rule. A timeout that is not a valid duration is reported as an error.
+func translateGatewaySessionPersistence(sp *gatewayv1.SessionPersistence) (*sessionPersistence, error) {
+	if sp == nil {
+		return nil, nil
+	}
+	session := &sessionPersistence{
+		Header: sp.Type != nil && *sp.Type == gatewayv1.HeaderBasedSessionPersistence,
+		Name:   DefaultSessionName,
+	}
+	if sp.SessionName != nil && *sp.SessionName != "" {
+		session.Name = *sp.SessionName
+	}
+	if sp.AbsoluteTimeout != nil {
+		d, err := time.ParseDuration(string(*sp.AbsoluteTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("invalid absoluteTimeout %s: %w", *sp.AbsoluteTimeout, err)
+		}
+		session.AbsoluteTimeout = d
+	}
+	if sp.CookieConfig != nil && sp.CookieConfig.LifetimeType != nil {
+		session.Permanent = *sp.CookieConfig.LifetimeType == gatewayv1.PermanentCookieLifetimeType
+	}
+	return session, nil
+}
+
+// translatePolicySessionPersistence translates the session persistence of a
+// BackendTrafficPolicy.
+func translatePolicySessionPersistence(sp *v1alpha1.SessionPersistence) *sessionPersistence {
+	if sp == nil {
+		return nil
+	}
+	session := &sessionPersistence{
+		Header:    sp.Type == string(gatewayv1.HeaderBasedSessionPersistence),
+		Name:      DefaultSessionName,
+		Permanent: sp.CookieLifetimeType == string(gatewayv1.PermanentCookieLifetimeType),
+	}
+	if sp.SessionName != "" {
+		session.Name = sp.SessionName
+	}
+	if sp.AbsoluteTimeout != nil {
+		session.AbsoluteTimeout = sp.AbsoluteTimeout.Duration
+	}
+	if sp.IdleTimeout != nil {
+		session.IdleTimeout = sp.IdleTimeout.Duration
+	}
+	return session
+}
+
+// key identifies the upstream hash settings. Upstreams are shared by ID, so an
+// upstream of the same backend hashed on a session is given a distinct name. Every
+// session is hashed on sessionHashHeader, whatever carries it to the client.
+func (s *sessionPersistence) key() string {
+	if s == nil {
+		return ""
+	}
+	return "session"
+}
+
+// apply hashes the upstream on the session token.
+func (s *sessionPersistence) apply(upstream *adctypes.Upstream) {
+	if s == nil {
+		return
+	}
+	upstream.Type = adctypes.Chash
+	upstream.HashOn = "header"
+	upstream.Key = sessionHashHeader
+}
+
+// plugins returns the serverless-pre-function and serverless-post-function configs that
+// read or mint the session token before balancing and return it to the client.
+func (s *sessionPersistence) plugins() map[string]any {
+	return map[string]any{
+		adctypes.PluginServerlessPreFunction: &adctypes.ServerlessConfig{
+			Phase: "rewrite",
+			Functions: []string{fmt.Sprintf(sessionAcceptFunction,
+				s.Name, s.Header, ceilSeconds(s.AbsoluteTimeout), sessionHashHeader,
+			)},
+		},
+		adctypes.PluginServerlessPostFunction: &adctypes.ServerlessConfig{
+			Phase: "header_filter",
+			Functions: []string{fmt.Sprintf(sessionIssueFunction,
+				s.Name, s.Header,
+				ceilSeconds(s.AbsoluteTimeout), ceilSeconds(s.IdleTimeout), s.Permanent,
+			)},
+		},
+	}
+}
+
+// ceilSeconds rounds d up to whole seconds, the resolution of cookie Max-Age.
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
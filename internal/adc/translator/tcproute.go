@@ -100,6 +100,7 @@ func (t *Translator) buildL4StreamRoutes(tctx *provider.TranslateContext, namesp
 		// applies plugins from the stream_route, not from the service.
 		streamRoute.Plugins = make(adctypes.Plugins)
 		t.AttachL4RoutePolicyPlugins(tctx.L4RoutePolicies, namespace, name, routeKind, streamRoute.Plugins)
+		t.AttachL4RoutePolicyDefaults(tctx.L4RoutePolicyDefaults, streamRoute.Plugins)
 		streamRoutes = append(streamRoutes, streamRoute)
 	}
 	return streamRoutes
@@ -131,7 +132,7 @@ func (t *Translator) TranslateTCPRoute(tctx *provider.TranslateContext, tcpRoute
 			if len(upNodes) == 0 {
 				continue
 			}
-			t.AttachBackendTrafficPolicyToUpstream(backend, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+			t.AttachBackendTrafficPolicyToUpstreamWithScheme(backend, tctx, upstream, apiv2.SchemeTCP)
 			upstream.Nodes = upNodes
 			var (
 				kind string
@@ -62,7 +62,7 @@ func (t *Translator) TranslateTLSRoute(tctx *provider.TranslateContext, tlsRoute
 				continue
 			}
 			// TODO: Confirm BackendTrafficPolicy attachment with e2e test case.
-			t.AttachBackendTrafficPolicyToUpstream(backend, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+			t.AttachBackendTrafficPolicyToUpstreamWithScheme(backend, tctx, upstream, apiv2.SchemeTCP)
 			upstream.Nodes = upNodes
 			var (
 				kind string
@@ -156,6 +156,7 @@ func (t *Translator) TranslateTLSRoute(tctx *provider.TranslateContext, tlsRoute
 			// each stream_route carries its own copy of the plugins.
 			streamRoute.Plugins = make(adctypes.Plugins)
 			t.AttachL4RoutePolicyPlugins(tctx.L4RoutePolicies, tlsRoute.Namespace, tlsRoute.Name, "TLSRoute", streamRoute.Plugins)
+			t.AttachL4RoutePolicyDefaults(tctx.L4RoutePolicyDefaults, streamRoute.Plugins)
 			service.StreamRoutes = append(service.StreamRoutes, streamRoute)
 		}
 
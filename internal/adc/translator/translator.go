@@ -82,4 +82,5 @@ type TranslateResult struct {
 	GlobalRules    adctypes.GlobalRule
 	PluginMetadata adctypes.PluginMetadata
 	Consumers      []*adctypes.Consumer
+	ConsumerGroups []*adctypes.ConsumerGroup
 }
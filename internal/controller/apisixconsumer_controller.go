@@ -112,6 +112,7 @@ func (r *ApisixConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		r.Log.Error(err, "failed to update provider", "ApisixConsumer", utils.NamespacedName(ac))
 		return ctrl.Result{}, err
 	}
+	err = consumerGroupNotFound(tctx, ac.Namespace, ac.Spec.GroupRef)
 	return ctrl.Result{}, nil
 }
 
@@ -150,6 +151,9 @@ func (r *ApisixConsumerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.listApisixConsumerForSecret),
 		).
+		Watches(&v1alpha1.ConsumerGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.listApisixConsumerForConsumerGroup),
+		).
 		Named("apisixconsumer").
 		Complete(r)
 }
@@ -199,6 +203,18 @@ func (r *ApisixConsumerReconciler) listApisixConsumerForSecret(ctx context.Conte
 	)
 }
 
+func (r *ApisixConsumerReconciler) listApisixConsumerForConsumerGroup(ctx context.Context, obj client.Object) []reconcile.Request {
+	return ListRequests(
+		ctx,
+		r.Client,
+		r.Log,
+		&apiv2.ApisixConsumerList{},
+		client.MatchingFields{
+			indexer.ConsumerGroupRef: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+		},
+	)
+}
+
 func (r *ApisixConsumerReconciler) processSpec(ctx context.Context, tctx *provider.TranslateContext, ac *apiv2.ApisixConsumer) error {
 	var secretRef *corev1.LocalObjectReference
 	if ap := ac.Spec.AuthParameter; ap != nil {
@@ -257,7 +273,7 @@ func (r *ApisixConsumerReconciler) processSpec(ctx context.Context, tctx *provid
 			tctx.Secrets[namespacedName] = secret
 		}
 	}
-	return nil
+	return processConsumerGroup(ctx, r.Client, tctx, ac.Namespace, ac.Spec.GroupRef)
 }
 
 func (r *ApisixConsumerReconciler) updateStatus(consumer *apiv2.ApisixConsumer, err error) {
@@ -0,0 +1,325 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	sslutils "github.com/apache/apisix-ingress-controller/internal/ssl"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// BackendTLSPolicyPredicateFunc behaves like BackendTrafficPolicyPredicateFunc: when
+// targetRefs are removed from a policy, a generic event carrying the removed refs is
+// sent so the routes of the no longer targeted Services are reconciled as well.
+func BackendTLSPolicyPredicateFunc(channel chan event.GenericEvent) predicate.Predicate {
+	return predicate.Funcs{
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj, ok := e.ObjectOld.(*gatewayv1.BackendTLSPolicy)
+			newObj, ok2 := e.ObjectNew.(*gatewayv1.BackendTLSPolicy)
+			if !ok || !ok2 {
+				return false
+			}
+			oldRefMap := make(map[string]gatewayv1.LocalPolicyTargetReferenceWithSectionName)
+			for _, ref := range oldObj.Spec.TargetRefs {
+				oldRefMap[fmt.Sprintf("%s/%s/%s", ref.Group, ref.Kind, ref.Name)] = ref
+			}
+			for _, ref := range newObj.Spec.TargetRefs {
+				delete(oldRefMap, fmt.Sprintf("%s/%s/%s", ref.Group, ref.Kind, ref.Name))
+			}
+			if len(oldRefMap) > 0 {
+				targetRefs := make([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, 0, len(oldRefMap))
+				for _, ref := range oldRefMap {
+					targetRefs = append(targetRefs, ref)
+				}
+				dump := oldObj.DeepCopy()
+				dump.Spec.TargetRefs = targetRefs
+				channel <- event.GenericEvent{
+					Object: dump,
+				}
+			}
+			return true
+		},
+	}
+}
+
+// listBackendTLSPolicyTargetServices returns the Services targeted by the policy.
+func listBackendTLSPolicyTargetServices(policy *gatewayv1.BackendTLSPolicy) []types.NamespacedName {
+	var services []types.NamespacedName
+	seen := make(map[types.NamespacedName]struct{})
+	for _, ref := range policy.Spec.TargetRefs {
+		if ref.Group != "" || ref.Kind != internaltypes.KindService {
+			continue
+		}
+		nn := types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)}
+		if _, ok := seen[nn]; ok {
+			continue
+		}
+		seen[nn] = struct{}{}
+		services = append(services, nn)
+	}
+	return services
+}
+
+// listBackendTLSPoliciesForCACertificate returns the BackendTLSPolicies that reference the
+// given ConfigMap or Secret as a CA certificate.
+func listBackendTLSPoliciesForCACertificate(ctx context.Context, c client.Client, obj client.Object) ([]gatewayv1.BackendTLSPolicy, error) {
+	var field string
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		field = indexer.ConfigMapIndexRef
+	case *corev1.Secret:
+		field = indexer.SecretIndexRef
+	default:
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	var list gatewayv1.BackendTLSPolicyList
+	if err := c.List(ctx, &list, client.MatchingFields{
+		field: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+	}); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ProcessBackendTLSPolicy finds the BackendTLSPolicies targeting the Services in tctx,
+// resolves their CA certificate references, and populates tctx.BackendTLSPolicies with the accepted policies. The Accepted and
+// ResolvedRefs conditions are set for every parentRef of the route being translated.
+func ProcessBackendTLSPolicy(
+	c client.Client,
+	log logr.Logger,
+	tctx *provider.TranslateContext,
+) {
+	servicePortNameMap := map[string]bool{}
+	policyMap := map[types.NamespacedName]*gatewayv1.BackendTLSPolicy{}
+	for _, service := range tctx.Services {
+		var list gatewayv1.BackendTLSPolicyList
+		if err := c.List(tctx, &list,
+			client.MatchingFields{
+				indexer.PolicyTargetRefs: indexer.GenIndexKeyWithGK("", internaltypes.KindService, service.Namespace, service.Name),
+			},
+		); err != nil {
+			log.Error(err, "failed to list BackendTLSPolicy for Service")
+			continue
+		}
+		if len(list.Items) == 0 {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			servicePortNameMap[fmt.Sprintf("%s/%s/%s", service.Namespace, service.Name, port.Name)] = true
+		}
+		for _, p := range list.Items {
+			policyMap[utils.NamespacedName(&p)] = p.DeepCopy()
+		}
+	}
+	if len(policyMap) == 0 {
+		return
+	}
+
+	// Deterministic conflict resolution: oldest creationTimestamp wins; tie-break by namespace/name.
+	policies := make([]*gatewayv1.BackendTLSPolicy, 0, len(policyMap))
+	for _, p := range policyMap {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		ti := policies[i].CreationTimestamp.Time
+		tj := policies[j].CreationTimestamp.Time
+		if ti.Equal(tj) {
+			return utils.NamespacedName(policies[i]).String() < utils.NamespacedName(policies[j]).String()
+		}
+		return ti.Before(tj)
+	})
+
+	conflicts := map[string]*gatewayv1.BackendTLSPolicy{}
+	for _, policy := range policies {
+		accepted, resolvedRefs := resolveBackendTLSPolicyCACertificates(c, tctx, policy)
+		if accepted.Status == metav1.ConditionTrue {
+			for _, targetRef := range policy.Spec.TargetRefs {
+				if targetRef.Group != "" || targetRef.Kind != internaltypes.KindService {
+					continue
+				}
+				key := PolicyTargetKey{
+					NsName:    types.NamespacedName{Namespace: policy.Namespace, Name: string(targetRef.Name)},
+					GroupKind: schema.GroupKind{Group: "", Kind: internaltypes.KindService},
+				}
+				if targetRef.SectionName != nil {
+					key.SectionName = string(*targetRef.SectionName)
+					if !servicePortNameMap[fmt.Sprintf("%s/%s/%s", policy.Namespace, targetRef.Name, key.SectionName)] {
+						accepted = NewPolicyCondition(policy.Generation, false,
+							fmt.Sprintf("No section name %s found in Service %s/%s", key.SectionName, policy.Namespace, targetRef.Name))
+						break
+					}
+				}
+				if winner, ok := conflicts[key.String()]; ok && winner != policy {
+					accepted = NewPolicyConflictCondition(policy.Generation,
+						fmt.Sprintf("Unable to target Service %s/%s, because it conflicts with BackendTLSPolicy %s/%s",
+							policy.Namespace, targetRef.Name, winner.Namespace, winner.Name))
+					break
+				}
+				conflicts[key.String()] = policy
+			}
+		}
+		if accepted.Status == metav1.ConditionTrue {
+			tctx.BackendTLSPolicies[utils.NamespacedName(policy)] = policy
+		}
+
+		if updated := setBackendTLSPolicyAncestors(policy, tctx.RouteParentRefs, accepted, resolvedRefs); updated {
+			tctx.StatusUpdaters = append(tctx.StatusUpdaters, status.Update{
+				NamespacedName: utils.NamespacedName(policy),
+				Resource:       policy.DeepCopy(),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					cp := obj.(*gatewayv1.BackendTLSPolicy).DeepCopy()
+					cp.Status = policy.Status
+					return cp
+				}),
+			})
+		}
+	}
+}
+
+// resolveBackendTLSPolicyCACertificates validates spec.validation.caCertificateRefs. It
+// returns the Accepted and ResolvedRefs conditions of the policy.
+//
+// The data plane cannot verify the certificate of an HTTP upstream, so an accepted policy
+// only originates TLS to the backend. The Accepted message says so rather than implying
+// the upstream is validated.
+func resolveBackendTLSPolicyCACertificates(
+	c client.Client,
+	tctx *provider.TranslateContext,
+	policy *gatewayv1.BackendTLSPolicy,
+) (accepted, resolvedRefs metav1.Condition) {
+	resolvedRefs = newBackendTLSPolicyResolvedRefsCondition(policy.Generation, string(gatewayv1.BackendTLSPolicyReasonResolvedRefs), "All references have been resolved")
+	accepted = NewPolicyCondition(policy.Generation, true, backendTLSPolicyAcceptedMessage)
+
+	for _, ref := range policy.Spec.Validation.CACertificateRefs {
+		nn := types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)}
+		var err error
+		switch {
+		case ref.Group == "" && ref.Kind == internaltypes.KindConfigMap:
+			var cm corev1.ConfigMap
+			if err = c.Get(tctx, nn, &cm); err == nil {
+				_, err = sslutils.ExtractCAFromConfigMap(&cm)
+			}
+		case ref.Group == "" && ref.Kind == internaltypes.KindSecret:
+			var secret corev1.Secret
+			if err = c.Get(tctx, nn, &secret); err == nil {
+				_, err = sslutils.ExtractCAFromSecret(&secret)
+			}
+		default:
+			resolvedRefs = newBackendTLSPolicyResolvedRefsCondition(policy.Generation, string(gatewayv1.BackendTLSPolicyReasonInvalidKind),
+				fmt.Sprintf("Unsupported kind %s/%s for CA certificate %s", ref.Group, ref.Kind, ref.Name))
+		}
+		if err != nil {
+			resolvedRefs = newBackendTLSPolicyResolvedRefsCondition(policy.Generation, string(gatewayv1.BackendTLSPolicyReasonInvalidCACertificateRef),
+				fmt.Sprintf("Invalid CA certificate %s %s: %v", ref.Kind, nn, err))
+		}
+	}
+	if resolvedRefs.Status == metav1.ConditionFalse {
+		accepted = metav1.Condition{
+			Type:               string(gatewayv1.PolicyConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(gatewayv1.BackendTLSPolicyReasonNoValidCACertificate),
+			Message:            resolvedRefs.Message,
+			ObservedGeneration: policy.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	return accepted, resolvedRefs
+}
+
+// backendTLSPolicyAcceptedMessage is the Accepted message of every BackendTLSPolicy the
+// controller applies.
+const backendTLSPolicyAcceptedMessage = "Policy has been accepted. TLS is originated to the backend with the " +
+	"hostname as SNI, but the backend certificate is not verified: the data plane does not support " +
+	"validating it against caCertificateRefs or wellKnownCACertificates"
+
+func newBackendTLSPolicyResolvedRefsCondition(observedGeneration int64, reason, message string) metav1.Condition {
+	conditionStatus := metav1.ConditionFalse
+	if reason == string(gatewayv1.BackendTLSPolicyReasonResolvedRefs) {
+		conditionStatus = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               string(gatewayv1.BackendTLSPolicyConditionResolvedRefs),
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            cutils.TruncateConditionMessage(message),
+		ObservedGeneration: observedGeneration,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// setBackendTLSPolicyAncestors sets the given conditions on the policy ancestor status of
+// every parentRef, it reports whether the status was changed.
+func setBackendTLSPolicyAncestors(policy *gatewayv1.BackendTLSPolicy, parentRefs []gatewayv1.ParentReference, conditions ...metav1.Condition) bool {
+	updated := false
+	controllerName := gatewayv1alpha2.GatewayController(config.ControllerConfig.ControllerName)
+	for _, parent := range parentRefs {
+		idx := -1
+		for i, ancestor := range policy.Status.Ancestors {
+			if parentRefValueEqual(parent, ancestor.AncestorRef) && ancestor.ControllerName == controllerName {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			policy.Status.Ancestors = append(policy.Status.Ancestors, gatewayv1.PolicyAncestorStatus{
+				AncestorRef:    parent,
+				ControllerName: controllerName,
+				Conditions:     append([]metav1.Condition(nil), conditions...),
+			})
+			updated = true
+			continue
+		}
+		ancestor := &policy.Status.Ancestors[idx]
+		for _, condition := range conditions {
+			if VerifyConditions(&ancestor.Conditions, condition) {
+				meta.SetStatusCondition(&ancestor.Conditions, condition)
+				updated = true
+			}
+		}
+	}
+	return updated
+}
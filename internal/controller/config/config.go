@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
@@ -52,9 +53,23 @@ func NewDefaultConfig() *Config {
 		LeaderElection:   NewLeaderElection(),
 		ExecADCTimeout:   types.TimeDuration{Duration: 15 * time.Second},
 		ProviderConfig: ProviderConfig{
-			Type:          ProviderTypeAPI7EE,
-			SyncPeriod:    types.TimeDuration{Duration: 0},
-			InitSyncDelay: types.TimeDuration{Duration: 20 * time.Minute},
+			Type:            ProviderTypeAPI7EE,
+			SyncPeriod:      types.TimeDuration{Duration: 0},
+			InitSyncDelay:   types.TimeDuration{Duration: 20 * time.Minute},
+			Executor:        ExecutorTypeADC,
+			SyncConcurrency: DefaultSyncConcurrency,
+			DriftDetection: DriftDetectionConfig{
+				Policy: DriftPolicyAlert,
+			},
+			Batch: BatchConfig{
+				MaxSize: DefaultBatchMaxSize,
+			},
+			CircuitBreaker: CircuitBreakerConfig{
+				Cooldown: types.TimeDuration{Duration: DefaultCircuitBreakerCooldown},
+			},
+			Persistence: PersistenceConfig{
+				Interval: types.TimeDuration{Duration: DefaultPersistenceInterval},
+			},
 		},
 		Webhook:               NewWebhookConfig(),
 		ListenerPortMatchMode: ListenerPortMatchModeOff,
@@ -138,10 +153,65 @@ func (c *Config) Validate() error {
 	if err := validateWebhook(c.Webhook); err != nil {
 		return err
 	}
+	if err := validateMesh(c.Mesh); err != nil {
+		return err
+	}
 	return nil
 }
 
 func validateProvider(config ProviderConfig) error {
+	switch config.Executor {
+	case "", ExecutorTypeADC:
+	case ExecutorTypeNative:
+		if config.Type == ProviderTypeAPI7EE {
+			return fmt.Errorf("executor %s is not supported by the %s provider", config.Executor, config.Type)
+		}
+	default:
+		return fmt.Errorf("invalid executor: %q (must be adc or native)", config.Executor)
+	}
+
+	if config.SyncConcurrency < 0 {
+		return fmt.Errorf("sync_concurrency must not be negative")
+	}
+
+	if config.DryRun && config.Type == ProviderTypeAPI7EE {
+		return fmt.Errorf("dry_run is not supported by the %s provider", config.Type)
+	}
+
+	switch config.DriftDetection.Policy {
+	case "", DriftPolicyAlert, DriftPolicyCorrect:
+	default:
+		return fmt.Errorf("invalid drift_detection.policy: %q (must be alert or correct)", config.DriftDetection.Policy)
+	}
+	if config.DriftDetection.Period.Duration < 0 {
+		return fmt.Errorf("drift_detection.period must not be negative")
+	}
+	if config.DriftDetection.Period.Duration > 0 && config.Type == ProviderTypeAPI7EE {
+		return fmt.Errorf("drift_detection is not supported by the %s provider", config.Type)
+	}
+
+	if config.Batch.Window.Duration < 0 {
+		return fmt.Errorf("batch.window must not be negative")
+	}
+	if config.Batch.MaxSize < 0 {
+		return fmt.Errorf("batch.max_size must not be negative")
+	}
+	// The other providers already coalesce changes into full syncs, see sync_period.
+	if config.Batch.Window.Duration > 0 && config.Type != ProviderTypeAPI7EE {
+		return fmt.Errorf("batch is not supported by the %s provider", config.Type)
+	}
+
+	if config.CircuitBreaker.FailureThreshold < 0 {
+		return fmt.Errorf("circuit_breaker.failure_threshold must not be negative")
+	}
+	if config.CircuitBreaker.FailureThreshold > 0 && config.CircuitBreaker.Cooldown.Duration <= 0 {
+		return fmt.Errorf("circuit_breaker.cooldown must be greater than 0")
+	}
+
+	if config.Persistence.Enable && config.Persistence.Interval.Duration <= 0 {
+		return fmt.Errorf("persistence.interval must be greater than 0")
+	}
+
 	switch config.Type {
 	case ProviderTypeStandalone, ProviderTypeAPISIX:
 		if config.SyncPeriod.Duration <= 0 {
@@ -180,3 +250,32 @@ func validateWebhook(config *WebhookConfig) error {
 
 	return nil
 }
+
+func validateMesh(config *MeshConfig) error {
+	if config == nil || config.GatewayProxy == "" {
+		return nil
+	}
+	if _, ok := config.GatewayProxyKey(); !ok {
+		return fmt.Errorf("invalid mesh.gateway_proxy: %q (must be namespace/name)", config.GatewayProxy)
+	}
+	return nil
+}
+
+// GatewayProxyKey returns the GatewayProxy mesh routes are programmed into, and
+// false when mesh routes are disabled.
+func (c *MeshConfig) GatewayProxyKey() (k8stypes.NamespacedName, bool) {
+	if c == nil {
+		return k8stypes.NamespacedName{}, false
+	}
+	namespace, name, ok := strings.Cut(c.GatewayProxy, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return k8stypes.NamespacedName{}, false
+	}
+	return k8stypes.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// GetMeshGatewayProxy returns the GatewayProxy mesh routes are programmed into,
+// and false when mesh routes are disabled.
+func GetMeshGatewayProxy() (k8stypes.NamespacedName, bool) {
+	return ControllerConfig.Mesh.GatewayProxyKey()
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -65,6 +66,88 @@ func TestConfigValidateListenerPortMatchMode(t *testing.T) {
 	}
 }
 
+func TestConfigValidateExecutor(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  ProviderType
+		executor  ExecutorType
+		dryRun    bool
+		expectErr string
+	}{
+		{
+			name:     "adc",
+			provider: ProviderTypeAPI7EE,
+			executor: ExecutorTypeADC,
+		},
+		{
+			name:     "empty executor is allowed",
+			provider: ProviderTypeAPI7EE,
+			executor: "",
+		},
+		{
+			name:     "native with apisix",
+			provider: ProviderTypeAPISIX,
+			executor: ExecutorTypeNative,
+		},
+		{
+			name:     "native with apisix-standalone",
+			provider: ProviderTypeStandalone,
+			executor: ExecutorTypeNative,
+		},
+		{
+			name:      "native with api7ee",
+			provider:  ProviderTypeAPI7EE,
+			executor:  ExecutorTypeNative,
+			expectErr: "executor native is not supported by the api7ee provider",
+		},
+		{
+			name:      "invalid executor",
+			provider:  ProviderTypeAPI7EE,
+			executor:  "invalid",
+			expectErr: "invalid executor",
+		},
+		{
+			name:     "dry run with apisix",
+			provider: ProviderTypeAPISIX,
+			dryRun:   true,
+		},
+		{
+			name:      "dry run with api7ee",
+			provider:  ProviderTypeAPI7EE,
+			dryRun:    true,
+			expectErr: "dry_run is not supported by the api7ee provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.ProviderConfig.Type = tt.provider
+			cfg.ProviderConfig.SyncPeriod.Duration = time.Hour
+			cfg.ProviderConfig.Executor = tt.executor
+			cfg.ProviderConfig.DryRun = tt.dryRun
+
+			err := cfg.Validate()
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateSyncConcurrency(t *testing.T) {
+	cfg := NewDefaultConfig()
+	assert.Equal(t, DefaultSyncConcurrency, cfg.ProviderConfig.SyncConcurrency)
+
+	cfg.ProviderConfig.SyncConcurrency = 0
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ProviderConfig.SyncConcurrency = -1
+	assert.ErrorContains(t, cfg.Validate(), "sync_concurrency must not be negative")
+}
+
 func TestNewConfigFromFile(t *testing.T) {
 	// Create a temporary config file
 	fileContent := `
@@ -88,3 +171,136 @@ disable_gateway_api: true
 	assert.Equal(t, "test-controller", cfg.ControllerName)
 	assert.Equal(t, true, cfg.DisableGatewayAPI)
 }
+
+func TestConfigValidateMesh(t *testing.T) {
+	tests := []struct {
+		name         string
+		gatewayProxy string
+		wantErr      bool
+	}{
+		{name: "disabled"},
+		{name: "namespace and name", gatewayProxy: "apisix/mesh"},
+		{name: "name only", gatewayProxy: "mesh", wantErr: true},
+		{name: "empty namespace", gatewayProxy: "/mesh", wantErr: true},
+		{name: "too many segments", gatewayProxy: "apisix/mesh/extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Mesh = &MeshConfig{GatewayProxy: tt.gatewayProxy}
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid mesh.gateway_proxy")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateDriftDetection(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  ProviderType
+		period    time.Duration
+		policy    DriftPolicy
+		expectErr string
+	}{
+		{
+			name:     "disabled by default",
+			provider: ProviderTypeAPI7EE,
+			policy:   DriftPolicyAlert,
+		},
+		{
+			name:     "correct with apisix",
+			provider: ProviderTypeAPISIX,
+			period:   time.Minute,
+			policy:   DriftPolicyCorrect,
+		},
+		{
+			name:      "invalid policy",
+			provider:  ProviderTypeAPISIX,
+			period:    time.Minute,
+			policy:    "ignore",
+			expectErr: "invalid drift_detection.policy",
+		},
+		{
+			name:      "api7ee",
+			provider:  ProviderTypeAPI7EE,
+			period:    time.Minute,
+			policy:    DriftPolicyAlert,
+			expectErr: "drift_detection is not supported by the api7ee provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.ProviderConfig.Type = tt.provider
+			cfg.ProviderConfig.SyncPeriod.Duration = time.Hour
+			cfg.ProviderConfig.DriftDetection.Period.Duration = tt.period
+			cfg.ProviderConfig.DriftDetection.Policy = tt.policy
+
+			err := cfg.Validate()
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  ProviderType
+		window    time.Duration
+		maxSize   int
+		expectErr string
+	}{
+		{
+			name:     "disabled by default",
+			provider: ProviderTypeAPISIX,
+			maxSize:  DefaultBatchMaxSize,
+		},
+		{
+			name:     "api7ee",
+			provider: ProviderTypeAPI7EE,
+			window:   time.Second,
+			maxSize:  DefaultBatchMaxSize,
+		},
+		{
+			name:      "negative max size",
+			provider:  ProviderTypeAPI7EE,
+			window:    time.Second,
+			maxSize:   -1,
+			expectErr: "batch.max_size must not be negative",
+		},
+		{
+			name:      "apisix",
+			provider:  ProviderTypeAPISIX,
+			window:    time.Second,
+			maxSize:   DefaultBatchMaxSize,
+			expectErr: "batch is not supported by the apisix provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.ProviderConfig.Type = tt.provider
+			cfg.ProviderConfig.SyncPeriod.Duration = time.Hour
+			cfg.ProviderConfig.Batch.Window.Duration = tt.window
+			cfg.ProviderConfig.Batch.MaxSize = tt.maxSize
+
+			err := cfg.Validate()
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
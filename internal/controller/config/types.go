@@ -18,6 +18,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -29,6 +31,26 @@ const (
 	ProviderTypeAPISIX     ProviderType = "apisix"
 )
 
+// ExecutorType selects how configuration is pushed to the control plane.
+type ExecutorType string
+
+const (
+	// ExecutorTypeADC pushes through the ADC server.
+	ExecutorTypeADC ExecutorType = "adc"
+	// ExecutorTypeNative talks to the APISIX Admin API, or the standalone config API, directly.
+	ExecutorTypeNative ExecutorType = "native"
+)
+
+// DriftPolicy selects what is done about a data plane that drifted from the desired state.
+type DriftPolicy string
+
+const (
+	// DriftPolicyAlert only reports the drift, leaving it to the next sync of the config.
+	DriftPolicyAlert DriftPolicy = "alert"
+	// DriftPolicyCorrect reports the drift and syncs the desired state right away.
+	DriftPolicyCorrect DriftPolicy = "correct"
+)
+
 type ListenerPortMatchMode string
 
 const (
@@ -54,6 +76,22 @@ const (
 	DefaultProbeAddr   = ":8081"
 	DefaultServerAddr  = ":9092"
 
+	// DefaultSyncConcurrency is how many data plane endpoints, and GatewayProxy configs,
+	// are synced at once unless configured otherwise.
+	DefaultSyncConcurrency = 8
+
+	// DefaultBatchMaxSize is how many objects a batch coalesces at most unless configured
+	// otherwise.
+	DefaultBatchMaxSize = 100
+
+	// DefaultCircuitBreakerCooldown is how long an open circuit skips pushes unless
+	// configured otherwise.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+
+	// DefaultPersistenceInterval is how often the persisted store is brought up to date
+	// unless configured otherwise.
+	DefaultPersistenceInterval = 10 * time.Second
+
 	// Webhook configuration defaults
 	DefaultWebhookTLSCert    = "tls.crt"
 	DefaultWebhookTLSKey     = "tls.key"
@@ -79,6 +117,16 @@ type Config struct {
 	Webhook               *WebhookConfig        `json:"webhook" yaml:"webhook"`
 	DisableGatewayAPI     bool                  `json:"disable_gateway_api" yaml:"disable_gateway_api"`
 	ListenerPortMatchMode ListenerPortMatchMode `json:"listener_port_match_mode" yaml:"listener_port_match_mode"`
+	Mesh                  *MeshConfig           `json:"mesh" yaml:"mesh"`
+}
+
+// MeshConfig configures the routes attached to a Service (GAMMA) rather than to
+// a Gateway.
+type MeshConfig struct {
+	// GatewayProxy is the "namespace/name" of the GatewayProxy describing the
+	// data plane mesh routes are programmed into. Routes with a Service parentRef
+	// are ignored when it is empty.
+	GatewayProxy string `json:"gateway_proxy" yaml:"gateway_proxy"`
 }
 
 type GatewayConfig struct {
@@ -104,6 +152,53 @@ type ProviderConfig struct {
 	Type          ProviderType       `json:"type" yaml:"type"`
 	SyncPeriod    types.TimeDuration `json:"sync_period" yaml:"sync_period"`
 	InitSyncDelay types.TimeDuration `json:"init_sync_delay" yaml:"init_sync_delay"`
+	Executor      ExecutorType       `json:"executor" yaml:"executor"`
+	// SyncConcurrency bounds how many data plane endpoints, and how many GatewayProxy
+	// configs, are synced at once. Zero syncs them one at a time.
+	SyncConcurrency int `json:"sync_concurrency" yaml:"sync_concurrency"`
+	// DryRun stops the provider from writing to any data plane: changes are validated and
+	// diffed against what the data plane holds instead. A GatewayProxy may override it.
+	DryRun bool `json:"dry_run" yaml:"dry_run"`
+	// DriftDetection compares the data planes with the desired state between syncs.
+	DriftDetection DriftDetectionConfig `json:"drift_detection" yaml:"drift_detection"`
+	// Batch coalesces the changes to each GatewayProxy config into one sync. Only the
+	// api7ee provider supports it.
+	Batch BatchConfig `json:"batch" yaml:"batch"`
+	// CircuitBreaker stops pushing to the data plane of a GatewayProxy that does not answer.
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker" yaml:"circuit_breaker"`
+	// Persistence saves the translated configuration, so that a restart restores it.
+	Persistence PersistenceConfig `json:"persistence" yaml:"persistence"`
+}
+
+type DriftDetectionConfig struct {
+	// Period is the time between two checks. Zero disables drift detection.
+	Period types.TimeDuration `json:"period" yaml:"period"`
+	Policy DriftPolicy        `json:"policy" yaml:"policy"`
+}
+
+type BatchConfig struct {
+	// Window is how long changes are coalesced, counted from the first of them. Zero syncs
+	// every change on its own.
+	Window types.TimeDuration `json:"window" yaml:"window"`
+	// MaxSize syncs a batch early once it holds changes to this many objects. Zero bounds
+	// batches by the window alone.
+	MaxSize int `json:"max_size" yaml:"max_size"`
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many pushes in a row may find the data plane unreachable
+	// before the circuit opens. Zero disables the circuit breaker.
+	FailureThreshold int `json:"failure_threshold" yaml:"failure_threshold"`
+	// Cooldown is how long an open circuit skips pushes before it probes the data plane.
+	Cooldown types.TimeDuration `json:"cooldown" yaml:"cooldown"`
+}
+
+type PersistenceConfig struct {
+	// Enable keeps the translated configuration in Secrets in the controller namespace,
+	// one per GatewayProxy.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Interval is how often the Secrets are brought up to date.
+	Interval types.TimeDuration `json:"interval" yaml:"interval"`
 }
 
 type WebhookConfig struct {
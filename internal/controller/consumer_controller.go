@@ -20,9 +20,11 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -109,9 +111,24 @@ func (r *ConsumerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&v1alpha1.GatewayProxy{},
 			handler.EnqueueRequestsFromMapFunc(r.listConsumersForGatewayProxy),
 		).
+		Watches(&v1alpha1.ConsumerGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.listConsumersForConsumerGroup),
+		).
 		Complete(r)
 }
 
+func (r *ConsumerReconciler) listConsumersForConsumerGroup(ctx context.Context, obj client.Object) []reconcile.Request {
+	return ListRequests(
+		ctx,
+		r.Client,
+		r.Log,
+		&v1alpha1.ConsumerList{},
+		client.MatchingFields{
+			indexer.ConsumerGroupRef: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+		},
+	)
+}
+
 func (r *ConsumerReconciler) listConsumersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
 	secret, ok := obj.(*corev1.Secret)
 	if !ok {
@@ -239,6 +256,8 @@ func (r *ConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if err := r.processSpec(ctx, tctx, consumer); err != nil {
 		r.Log.Error(err, "failed to process consumer spec", "consumer", utils.NamespacedName(consumer))
 		statusErr = err
+	} else if err := consumerGroupNotFound(tctx, consumer.Namespace, consumer.Spec.GroupRef); err != nil {
+		statusErr = err
 	}
 
 	if err := r.Provider.Update(ctx, tctx, consumer); err != nil {
@@ -246,9 +265,35 @@ func (r *ConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		statusErr = err
 	}
 
-	r.updateStatus(consumer, statusErr)
+	now := time.Now()
+	credentials, next := credentialStatuses(consumer, now)
+	r.updateStatus(consumer, credentials, statusErr)
+
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	// Nothing else triggers a reconcile when a credential starts or stops being accepted.
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
 
-	return ctrl.Result{}, nil
+// credentialStatuses reports whether each credential of consumer is active at now, and the
+// next time one of them starts or stops being accepted, zero if none does.
+func credentialStatuses(consumer *v1alpha1.Consumer, now time.Time) ([]v1alpha1.CredentialStatus, time.Time) {
+	var next time.Time
+	statuses := make([]v1alpha1.CredentialStatus, 0, len(consumer.Spec.Credentials))
+	for _, credential := range consumer.Spec.Credentials {
+		for _, boundary := range []*metav1.Time{credential.NotBefore, credential.NotAfter} {
+			if boundary != nil && boundary.Time.After(now) && (next.IsZero() || boundary.Time.Before(next)) {
+				next = boundary.Time
+			}
+		}
+		statuses = append(statuses, v1alpha1.CredentialStatus{
+			Name:      credential.Name,
+			Active:    utils.CredentialActive(credential, now),
+			ExpiresAt: credential.NotAfter,
+		})
+	}
+	return statuses, next
 }
 
 func (r *ConsumerReconciler) processSpec(ctx context.Context, tctx *provider.TranslateContext, consumer *v1alpha1.Consumer) error {
@@ -290,18 +335,20 @@ func (r *ConsumerReconciler) processSpec(ctx context.Context, tctx *provider.Tra
 		}] = &secret
 
 	}
-	return nil
+	return processConsumerGroup(ctx, r.Client, tctx, consumer.Namespace, consumer.Spec.GroupRef)
 }
 
-func (r *ConsumerReconciler) updateStatus(consumer *v1alpha1.Consumer, err error) {
+func (r *ConsumerReconciler) updateStatus(consumer *v1alpha1.Consumer, credentials []v1alpha1.CredentialStatus, err error) {
 	condition := NewCondition(consumer.Generation, true, "Successfully")
 	if err != nil {
 		condition = NewCondition(consumer.Generation, false, err.Error())
 	}
-	if !VerifyConditions(&consumer.Status.Conditions, condition) {
+	if VerifyConditions(&consumer.Status.Conditions, condition) {
+		meta.SetStatusCondition(&consumer.Status.Conditions, condition)
+	} else if equality.Semantic.DeepEqual(consumer.Status.Credentials, credentials) {
 		return
 	}
-	meta.SetStatusCondition(&consumer.Status.Conditions, condition)
+	consumer.Status.Credentials = credentials
 
 	r.Updater.Update(status.Update{
 		NamespacedName: utils.NamespacedName(consumer),
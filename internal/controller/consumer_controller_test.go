@@ -20,6 +20,7 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
@@ -146,3 +147,33 @@ func TestProcessSpec_SameNamespaceSecretRef_Allowed(t *testing.T) {
 	require.NoError(t, err)
 	require.Contains(t, tctx.Secrets, types.NamespacedName{Namespace: consumerNS, Name: "local-secret"})
 }
+
+func TestCredentialStatuses(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+	consumer := &v1alpha1.Consumer{
+		Spec: v1alpha1.ConsumerSpec{
+			Credentials: []v1alpha1.Credential{
+				{Name: "old", NotAfter: at(2 * time.Hour)},
+				{Name: "new", NotBefore: at(time.Hour)},
+				{Name: "expired", NotAfter: at(-time.Hour)},
+				{Name: "static"},
+			},
+		},
+	}
+
+	statuses, next := credentialStatuses(consumer, now)
+	require.Equal(t, []v1alpha1.CredentialStatus{
+		{Name: "old", Active: true, ExpiresAt: at(2 * time.Hour)},
+		{Name: "new", Active: false},
+		{Name: "expired", Active: false, ExpiresAt: at(-time.Hour)},
+		{Name: "static", Active: true},
+	}, statuses)
+	require.Equal(t, now.Add(time.Hour), next)
+
+	_, next = credentialStatuses(consumer, now.Add(3*time.Hour))
+	require.True(t, next.IsZero())
+}
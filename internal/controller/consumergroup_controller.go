@@ -0,0 +1,212 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+	pkgutils "github.com/apache/apisix-ingress-controller/pkg/utils"
+)
+
+// ConsumerGroupReconciler reports the members of a ConsumerGroup. A group has nothing to
+// synchronize of its own: it is translated with each of the consumers that refer to it.
+type ConsumerGroupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+
+	Updater status.Updater
+
+	ICGV schema.GroupVersion
+
+	// supportsConsumer is whether Consumers are reconciled, which they are where the
+	// Gateway API is.
+	supportsConsumer bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConsumerGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if !config.ControllerConfig.DisableGatewayAPI {
+		hasGatewayAPI, err := pkgutils.HasAPIResource(mgr, &gatewayv1.Gateway{})
+		if err != nil {
+			return err
+		}
+		hasConsumer, err := pkgutils.HasAPIResource(mgr, &v1alpha1.Consumer{})
+		if err != nil {
+			return err
+		}
+		r.supportsConsumer = hasGatewayAPI && hasConsumer
+	}
+
+	// Members are watched with their status, which tells whether they are valid.
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ConsumerGroup{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(&apiv2.ApisixConsumer{},
+			handler.EnqueueRequestsFromMapFunc(r.listConsumerGroupsForMember),
+		)
+	if r.supportsConsumer {
+		b = b.Watches(&v1alpha1.Consumer{},
+			handler.EnqueueRequestsFromMapFunc(r.listConsumerGroupsForMember),
+		)
+	}
+	return b.Named("consumergroup").Complete(r)
+}
+
+func (r *ConsumerGroupReconciler) listConsumerGroupsForMember(ctx context.Context, obj client.Object) []reconcile.Request {
+	var groupRef *corev1.LocalObjectReference
+	switch member := obj.(type) {
+	case *v1alpha1.Consumer:
+		groupRef = member.Spec.GroupRef
+	case *apiv2.ApisixConsumer:
+		groupRef = member.Spec.GroupRef
+	}
+	if groupRef == nil || groupRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: groupRef.Name},
+	}}
+}
+
+func (r *ConsumerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	group := new(v1alpha1.ConsumerGroup)
+	if err := r.Get(ctx, req.NamespacedName, group); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var (
+		memberCount    int32
+		invalidMembers []v1alpha1.ConsumerGroupMember
+		groupKey       = client.MatchingFields{indexer.ConsumerGroupRef: indexer.GenIndexKey(group.Namespace, group.Name)}
+	)
+	// Consumers another controller manages are neither counted nor reported.
+	addMember := func(kind, name string, conditions []metav1.Condition, conditionType string) {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		if condition != nil && condition.Status == metav1.ConditionFalse {
+			invalidMembers = append(invalidMembers, v1alpha1.ConsumerGroupMember{
+				Kind:   kind,
+				Name:   name,
+				Reason: condition.Message,
+			})
+			return
+		}
+		memberCount++
+	}
+
+	if r.supportsConsumer {
+		consumers := &v1alpha1.ConsumerList{}
+		if err := r.List(ctx, consumers, groupKey); err != nil {
+			r.Log.Error(err, "failed to list consumers", "consumergroup", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+		for i := range consumers.Items {
+			consumer := &consumers.Items[i]
+			if !MatchConsumerGatewayRef(ctx, r.Client, r.Log, consumer) {
+				continue
+			}
+			addMember(internaltypes.KindConsumer, consumer.Name, consumer.Status.Conditions, ConditionTypeAvailable)
+		}
+	}
+
+	apisixConsumers := &apiv2.ApisixConsumerList{}
+	if err := r.List(ctx, apisixConsumers, groupKey); err != nil {
+		r.Log.Error(err, "failed to list apisix consumers", "consumergroup", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	for i := range apisixConsumers.Items {
+		ac := &apisixConsumers.Items[i]
+		if !MatchesIngressClass(r.Client, r.Log, ac, r.ICGV.String()) {
+			continue
+		}
+		addMember(internaltypes.KindApisixConsumer, ac.Name, ac.Status.Conditions, string(apiv2.ConditionTypeAccepted))
+	}
+
+	slices.SortFunc(invalidMembers, func(a, b v1alpha1.ConsumerGroupMember) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	group.Status.MemberCount = memberCount
+	group.Status.InvalidMembers = invalidMembers
+	meta.SetStatusCondition(&group.Status.Conditions, NewCondition(group.Generation, true, "Successfully"))
+
+	r.Updater.Update(status.Update{
+		NamespacedName: utils.NamespacedName(group),
+		Resource:       group.DeepCopy(),
+		Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+			cp := obj.(*v1alpha1.ConsumerGroup).DeepCopy()
+			cp.Status = group.Status
+			return cp
+		}),
+	})
+	return ctrl.Result{}, nil
+}
+
+// processConsumerGroup adds the ConsumerGroup that groupRef refers to, in namespace, to tctx.
+// A group that does not exist is not an error here, see consumerGroupNotFound.
+func processConsumerGroup(ctx context.Context, c client.Client, tctx *provider.TranslateContext, namespace string, groupRef *corev1.LocalObjectReference) error {
+	if groupRef == nil || groupRef.Name == "" {
+		return nil
+	}
+	nn := types.NamespacedName{Namespace: namespace, Name: groupRef.Name}
+	group := &v1alpha1.ConsumerGroup{}
+	if err := c.Get(ctx, nn, group); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	tctx.ConsumerGroups[nn] = group
+	return nil
+}
+
+// consumerGroupNotFound reports a consumer that refers to a ConsumerGroup tctx does not hold.
+// Such a consumer is still synchronized, without the plugins of the group, but it is not
+// accepted until the group is created.
+func consumerGroupNotFound(tctx *provider.TranslateContext, namespace string, groupRef *corev1.LocalObjectReference) error {
+	if groupRef == nil || groupRef.Name == "" {
+		return nil
+	}
+	if _, ok := tctx.ConsumerGroups[types.NamespacedName{Namespace: namespace, Name: groupRef.Name}]; ok {
+		return nil
+	}
+	return fmt.Errorf("ConsumerGroup %s/%s not found", namespace, groupRef.Name)
+}
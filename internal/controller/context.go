@@ -18,12 +18,20 @@
 package controller
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 type RouteParentRefContext struct {
 	Gateway *gatewayv1.Gateway
+	// ListenerSet is the ListenerSet the parentRef referenced, whose listeners
+	// were matched instead of the Gateway's. It is nil for a Gateway parentRef.
+	ListenerSet *gatewayv1.ListenerSet
+	// Service is the Service a mesh (GAMMA) parentRef referenced, in which case
+	// Gateway is nil. ServicePort is the port the parentRef selected, if any.
+	Service     *corev1.Service
+	ServicePort *gatewayv1.PortNumber
 
 	ListenerName string
 	Listener     *gatewayv1.Listener
@@ -18,12 +18,14 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -55,6 +57,8 @@ type GatewayReconciler struct { //nolint:revive
 	Provider provider.Provider
 
 	Updater status.Updater
+
+	supportsListenerSet bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -95,12 +99,31 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForConfigMap),
+		).
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForPublishService),
 		)
 
+	supportsListenerSet, err := pkgutils.HasAPIResource(mgr, &gatewayv1.ListenerSet{})
+	if err != nil {
+		return err
+	}
+	r.supportsListenerSet = supportsListenerSet
+	if r.supportsListenerSet {
+		bdr.Watches(
+			&gatewayv1.ListenerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForListenerSet),
+		)
+	}
 	if GetEnableReferenceGrant() {
+		var referenceGrantFilter predicate.Predicate = referenceGrantPredicates(KindGateway)
+		if r.supportsListenerSet {
+			referenceGrantFilter = predicate.Or(referenceGrantFilter, referenceGrantPredicates(KindListenerSet))
+		}
 		bdr.Watches(&v1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.listReferenceGrantsForGateway),
-			builder.WithPredicates(referenceGrantPredicates(KindGateway)),
+			builder.WithPredicates(referenceGrantFilter),
 		)
 	}
 	hasTCPRoute, err := pkgutils.HasAPIResource(mgr, &gatewayv1alpha2.TCPRoute{})
@@ -165,6 +188,7 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	r.Log.Info("gateway has been accepted", "gateway", gateway.GetName())
 	type conditionStatus struct {
 		status bool
+		reason string
 		msg    string
 	}
 	acceptStatus := conditionStatus{
@@ -176,6 +200,14 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	tctx := provider.NewDefaultTranslateContext(ctx)
 
 	r.processListenerConfig(tctx, gateway)
+	var listenerSets *gatewayListenerSets
+	if r.supportsListenerSet {
+		var err error
+		if listenerSets, err = resolveGatewayListenerSets(ctx, r.Client, gateway); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.processListenerSetConfig(tctx, gateway, listenerSets)
+	}
 	if err := r.processInfrastructure(tctx, gateway); err != nil {
 		acceptStatus = conditionStatus{
 			status: false,
@@ -183,7 +215,13 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
-	var addrs []gatewayv1.GatewayStatusAddress
+	var (
+		addrs    []gatewayv1.GatewayStatusAddress
+		addrErr  error
+		infraErr error
+		// clearAddrs drops the published addresses once the requested ones cannot be assigned.
+		clearAddrs bool
+	)
 
 	rk := utils.NamespacedNameKind(gateway)
 
@@ -194,7 +232,16 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			msg:    "gateway proxy not found",
 		}
 	} else {
-		if len(gateway.Status.Addresses) != len(gatewayProxy.Spec.StatusAddress) {
+		// retried once the status is updated
+		infraErr = r.applyGatewayInfrastructure(ctx, gateway, &gatewayProxy)
+		if len(gateway.Spec.Addresses) > 0 {
+			var assigned []gatewayv1.GatewayStatusAddress
+			if assigned, addrErr = r.processGatewayAddresses(ctx, gateway, &gatewayProxy); addrErr == nil &&
+				!equality.Semantic.DeepEqual(gateway.Status.Addresses, assigned) {
+				addrs = assigned
+			}
+			clearAddrs = addrErr != nil && len(gateway.Status.Addresses) > 0
+		} else if len(gateway.Status.Addresses) != len(gatewayProxy.Spec.StatusAddress) {
 			for _, addr := range gatewayProxy.Spec.StatusAddress {
 				if addr == "" {
 					continue
@@ -221,12 +268,42 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
-	accepted := SetGatewayConditionAccepted(gateway, acceptStatus.status, acceptStatus.msg)
-	programmed := SetGatewayConditionProgrammed(gateway, conditionProgrammedStatus, conditionProgrammedMsg)
-	if accepted || programmed || len(addrs) > 0 || len(listenerStatuses) > 0 {
+	var attachedListenerSetsChanged bool
+	if listenerSets != nil {
+		attachedListenerSets, err := r.updateListenerSetStatus(ctx, gateway, listenerSets, acceptStatus.status)
+		if err != nil {
+			r.Log.Error(err, "failed to get listenerset status", "gateway", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+		if gateway.Status.AttachedListenerSets == nil || *gateway.Status.AttachedListenerSets != attachedListenerSets {
+			attachedListenerSetsChanged = true
+			gateway.Status.AttachedListenerSets = &attachedListenerSets
+		}
+	}
+
+	conditionProgrammedReason := string(gatewayv1.GatewayReasonProgrammed)
+	var addrReasonErr internaltypes.ReasonError
+	if errors.As(addrErr, &addrReasonErr) {
+		if addrReasonErr.Reason == string(gatewayv1.GatewayReasonUnsupportedAddress) {
+			acceptStatus = conditionStatus{
+				status: false,
+				reason: addrReasonErr.Reason,
+				msg:    addrReasonErr.Message,
+			}
+		} else {
+			conditionProgrammedStatus, conditionProgrammedReason, conditionProgrammedMsg = false, addrReasonErr.Reason, addrReasonErr.Message
+		}
+	}
+
+	accepted := SetGatewayConditionAcceptedWithReason(gateway, acceptStatus.status, cmp.Or(acceptStatus.reason, string(gatewayv1.GatewayReasonAccepted)), acceptStatus.msg)
+	programmed := SetGatewayConditionProgrammedWithReason(gateway, conditionProgrammedStatus, conditionProgrammedReason, conditionProgrammedMsg)
+	if accepted || programmed || len(addrs) > 0 || clearAddrs || len(listenerStatuses) > 0 || attachedListenerSetsChanged {
 		if len(addrs) > 0 {
 			gateway.Status.Addresses = addrs
 		}
+		if clearAddrs {
+			gateway.Status.Addresses = nil
+		}
 		if len(listenerStatuses) > 0 {
 			gateway.Status.Listeners = listenerStatuses
 		}
@@ -246,10 +323,10 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			}),
 		})
 
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, infraErr
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, infraErr
 }
 
 func (r *GatewayReconciler) matchesGatewayClass(obj client.Object) bool {
@@ -349,18 +426,32 @@ func (r *GatewayReconciler) listGatewaysForStatusParentRefs(ctx context.Context,
 		if parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName {
 			continue
 		}
-		if parentRef.Kind != nil && *parentRef.Kind != internaltypes.KindGateway {
-			continue
-		}
 		if parentRef.Namespace != nil {
 			gatewayNamespace = string(*parentRef.Namespace)
 		}
-
-		gateway := new(gatewayv1.Gateway)
-		if err := r.Get(ctx, client.ObjectKey{
+		gatewayKey := client.ObjectKey{
 			Namespace: gatewayNamespace,
 			Name:      string(parentRef.Name),
-		}, gateway); err != nil {
+		}
+		switch {
+		case parentRef.Kind == nil || *parentRef.Kind == internaltypes.KindGateway:
+		case *parentRef.Kind == internaltypes.KindListenerSet && r.supportsListenerSet:
+			// A route attached through a ListenerSet counts towards the
+			// ListenerSet status, which its parent Gateway reports.
+			listenerSet := new(gatewayv1.ListenerSet)
+			if err := r.Get(ctx, gatewayKey, listenerSet); err != nil {
+				continue
+			}
+			var ok bool
+			if gatewayKey, ok = listenerSetParentGateway(listenerSet); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+
+		gateway := new(gatewayv1.Gateway)
+		if err := r.Get(ctx, gatewayKey, gateway); err != nil {
 			continue
 		}
 
@@ -369,15 +460,33 @@ func (r *GatewayReconciler) listGatewaysForStatusParentRefs(ctx context.Context,
 		}
 
 		reqs = append(reqs, reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Namespace: gatewayNamespace,
-				Name:      string(parentRef.Name),
-			},
+			NamespacedName: gatewayKey,
 		})
 	}
 	return reqs
 }
 
+func (r *GatewayReconciler) listGatewaysForListenerSet(ctx context.Context, obj client.Object) []reconcile.Request {
+	listenerSet, ok := obj.(*gatewayv1.ListenerSet)
+	if !ok {
+		r.Log.Error(
+			errors.New("unexpected object type"),
+			"ListenerSet watch predicate received unexpected object type",
+			"expected", FullTypeName(new(gatewayv1.ListenerSet)), "found", FullTypeName(obj),
+		)
+		return nil
+	}
+	gatewayKey, ok := listenerSetParentGateway(listenerSet)
+	if !ok {
+		return nil
+	}
+	gateway := new(gatewayv1.Gateway)
+	if err := r.Get(ctx, gatewayKey, gateway); err != nil || !r.checkGatewayClass(gateway) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: gatewayKey}}
+}
+
 func (r *GatewayReconciler) listGatewaysForSecret(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
 	secret, ok := obj.(*corev1.Secret)
 	if !ok {
@@ -403,6 +512,20 @@ func (r *GatewayReconciler) listGatewaysForSecret(ctx context.Context, obj clien
 			},
 		})
 	}
+	if r.supportsListenerSet {
+		var listenerSetList gatewayv1.ListenerSetList
+		if err := r.List(ctx, &listenerSetList, client.MatchingFields{
+			indexer.SecretIndexRef: indexer.GenIndexKey(secret.GetNamespace(), secret.GetName()),
+		}); err != nil {
+			r.Log.Error(err, "failed to list listenersets")
+			return requests
+		}
+		for i := range listenerSetList.Items {
+			if gatewayKey, ok := listenerSetParentGateway(&listenerSetList.Items[i]); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: gatewayKey})
+			}
+		}
+	}
 	return requests
 }
 
@@ -468,6 +591,25 @@ func (r *GatewayReconciler) listReferenceGrantsForGateway(ctx context.Context, o
 			}
 		}
 	}
+
+	if !r.supportsListenerSet {
+		return requests
+	}
+	// Certificates of ListenerSet listeners are granted to the ListenerSet, whose
+	// status and listeners are reconciled with its parent Gateway.
+	for _, from := range grant.Spec.From {
+		if from.Kind != KindListenerSet || string(from.Group) != gatewayv1.GroupName {
+			continue
+		}
+		var listenerSets gatewayv1.ListenerSetList
+		if err := r.List(ctx, &listenerSets, client.InNamespace(string(from.Namespace))); err != nil {
+			r.Log.Error(err, "failed to list listenersets in watch predicate", "ReferenceGrant", grant.GetName())
+			return requests
+		}
+		for i := range listenerSets.Items {
+			requests = append(requests, r.listGatewaysForListenerSet(ctx, &listenerSets.Items[i])...)
+		}
+	}
 	return requests
 }
 
@@ -475,6 +617,95 @@ func (r *GatewayReconciler) processInfrastructure(tctx *provider.TranslateContex
 	return ProcessGatewayProxy(r.Client, r.Log, tctx, gateway, utils.NamespacedNameKind(gateway))
 }
 
+// processListenerSetConfig merges the accepted listeners of the ListenerSets
+// into the translation of the Gateway and loads their certificates.
+func (r *GatewayReconciler) processListenerSetConfig(tctx *provider.TranslateContext, gateway *gatewayv1.Gateway, listenerSets *gatewayListenerSets) {
+	for _, ls := range listenerSets.Items {
+		listeners := listenerSets.acceptedListeners(ls)
+		if len(listeners) == 0 {
+			continue
+		}
+		accepted := make(map[gatewayv1.SectionName]bool, len(listeners))
+		for _, listener := range listeners {
+			accepted[listener.Name] = true
+		}
+		merged := ls.DeepCopy()
+		merged.Spec.Listeners = utils.Filter(merged.Spec.Listeners, func(entry gatewayv1.ListenerEntry) bool {
+			return accepted[entry.Name]
+		})
+		for _, listener := range listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, ref := range listener.TLS.CertificateRefs {
+				if ref.Kind != nil && *ref.Kind != KindSecret {
+					continue
+				}
+				ns := ls.Namespace
+				if ref.Namespace != nil {
+					ns = string(*ref.Namespace)
+				}
+				if !checkReferenceGrant(tctx, r.Client,
+					v1beta1.ReferenceGrantFrom{
+						Group:     gatewayv1.GroupName,
+						Kind:      KindListenerSet,
+						Namespace: v1beta1.Namespace(ls.Namespace),
+					},
+					gatewayv1.ObjectReference{
+						Group:     corev1.GroupName,
+						Kind:      KindSecret,
+						Name:      ref.Name,
+						Namespace: ref.Namespace,
+					},
+				) {
+					r.Log.V(1).Info("skipping cross-namespace certificateRef not permitted by any ReferenceGrant",
+						"listenerset", utils.NamespacedName(ls), "listener", listener.Name)
+					continue
+				}
+				secret := corev1.Secret{}
+				nn := types.NamespacedName{Namespace: ns, Name: string(ref.Name)}
+				if err := r.Get(tctx, nn, &secret); err != nil {
+					r.Log.Error(err, "failed to get secret", "listenerset", utils.NamespacedName(ls), "secret", nn)
+					continue
+				}
+				tctx.Secrets[nn] = &secret
+			}
+			r.processListenerFrontendValidation(tctx, gateway, listener)
+		}
+		tctx.ListenerSets = append(tctx.ListenerSets, merged)
+	}
+}
+
+// updateListenerSetStatus queues the status of the ListenerSets attached to the
+// Gateway and returns the number of accepted ones.
+func (r *GatewayReconciler) updateListenerSetStatus(ctx context.Context, gateway *gatewayv1.Gateway, listenerSets *gatewayListenerSets, gatewayAccepted bool) (int32, error) {
+	var attached int32
+	for _, ls := range listenerSets.Items {
+		lsStatus, accepted, err := getListenerSetStatus(ctx, r.Client, gateway, ls, listenerSets, gatewayAccepted)
+		if err != nil {
+			return 0, err
+		}
+		if accepted {
+			attached++
+		}
+		r.Updater.Update(status.Update{
+			NamespacedName: utils.NamespacedName(ls),
+			Resource:       &gatewayv1.ListenerSet{},
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				t, ok := obj.(*gatewayv1.ListenerSet)
+				if !ok {
+					err := fmt.Errorf("unsupported object type %T", obj)
+					panic(err)
+				}
+				tCopy := t.DeepCopy()
+				tCopy.Status = lsStatus
+				return tCopy
+			}),
+		})
+	}
+	return attached, nil
+}
+
 func (r *GatewayReconciler) processListenerConfig(tctx *provider.TranslateContext, gateway *gatewayv1.Gateway) {
 	listeners := gateway.Spec.Listeners
 	for _, listener := range listeners {
@@ -524,62 +755,68 @@ func (r *GatewayReconciler) processListenerConfig(tctx *provider.TranslateContex
 				tctx.Secrets[types.NamespacedName{Namespace: ns, Name: string(ref.Name)}] = &secret
 			}
 		}
-		// frontendValidation references CA ConfigMaps or Secrets used for downstream mTLS.
-		// In Gateway API v1.6 it is declared at the Gateway level (spec.tls.frontend);
-		// resolve the config that applies to this HTTPS listener by its port.
-		if validation := internaltypes.FrontendTLSValidationForListener(gateway, listener); validation != nil {
-			for _, ref := range validation.CACertificateRefs {
-				ns := gateway.GetNamespace()
-				if ref.Namespace != nil {
-					ns = string(*ref.Namespace)
-				}
-				nn := types.NamespacedName{Namespace: ns, Name: string(ref.Name)}
-				kind := KindConfigMap
-				if ref.Kind != "" {
-					kind = string(ref.Kind)
-				}
-				// A cross-namespace CA ref must be authorized by a ReferenceGrant, or the
-				// data plane would enable downstream mTLS with a CA the target namespace
-				// never permitted. The listener status already reports RefNotPermitted.
-				if !checkReferenceGrant(context.Background(), r.Client,
-					v1beta1.ReferenceGrantFrom{
-						Group:     gatewayv1.GroupName,
-						Kind:      KindGateway,
-						Namespace: v1beta1.Namespace(gateway.Namespace),
-					},
-					gatewayv1.ObjectReference{
-						Group:     corev1.GroupName,
-						Kind:      gatewayv1.Kind(kind),
-						Name:      ref.Name,
-						Namespace: ref.Namespace,
-					},
-				) {
-					r.Log.V(1).Info("skipping cross-namespace caCertificateRef not permitted by any ReferenceGrant",
-						"listener", listener.Name, "ref", nn)
+		r.processListenerFrontendValidation(tctx, gateway, listener)
+	}
+}
+
+// processListenerFrontendValidation loads the CA ConfigMaps and Secrets of the
+// frontendValidation that applies to the listener.
+func (r *GatewayReconciler) processListenerFrontendValidation(tctx *provider.TranslateContext, gateway *gatewayv1.Gateway, listener gatewayv1.Listener) {
+	// frontendValidation references CA ConfigMaps or Secrets used for downstream mTLS.
+	// In Gateway API v1.6 it is declared at the Gateway level (spec.tls.frontend);
+	// resolve the config that applies to this HTTPS listener by its port.
+	if validation := internaltypes.FrontendTLSValidationForListener(gateway, listener); validation != nil {
+		for _, ref := range validation.CACertificateRefs {
+			ns := gateway.GetNamespace()
+			if ref.Namespace != nil {
+				ns = string(*ref.Namespace)
+			}
+			nn := types.NamespacedName{Namespace: ns, Name: string(ref.Name)}
+			kind := KindConfigMap
+			if ref.Kind != "" {
+				kind = string(ref.Kind)
+			}
+			// A cross-namespace CA ref must be authorized by a ReferenceGrant, or the
+			// data plane would enable downstream mTLS with a CA the target namespace
+			// never permitted. The listener status already reports RefNotPermitted.
+			if !checkReferenceGrant(context.Background(), r.Client,
+				v1beta1.ReferenceGrantFrom{
+					Group:     gatewayv1.GroupName,
+					Kind:      KindGateway,
+					Namespace: v1beta1.Namespace(gateway.Namespace),
+				},
+				gatewayv1.ObjectReference{
+					Group:     corev1.GroupName,
+					Kind:      gatewayv1.Kind(kind),
+					Name:      ref.Name,
+					Namespace: ref.Namespace,
+				},
+			) {
+				r.Log.V(1).Info("skipping cross-namespace caCertificateRef not permitted by any ReferenceGrant",
+					"listener", listener.Name, "ref", nn)
+				continue
+			}
+			switch kind {
+			case KindConfigMap:
+				configMap := corev1.ConfigMap{}
+				if err := r.Get(context.Background(), nn, &configMap); err != nil {
+					r.Log.Error(err, "failed to get CA configmap", "namespace", ns, "name", ref.Name)
+					SetGatewayListenerConditionProgrammed(gateway, string(listener.Name), false, err.Error())
+					SetGatewayListenerConditionResolvedRefs(gateway, string(listener.Name), false, err.Error())
 					continue
 				}
-				switch kind {
-				case KindConfigMap:
-					configMap := corev1.ConfigMap{}
-					if err := r.Get(context.Background(), nn, &configMap); err != nil {
-						r.Log.Error(err, "failed to get CA configmap", "namespace", ns, "name", ref.Name)
-						SetGatewayListenerConditionProgrammed(gateway, string(listener.Name), false, err.Error())
-						SetGatewayListenerConditionResolvedRefs(gateway, string(listener.Name), false, err.Error())
-						continue
-					}
-					r.Log.Info("Setting CA configmap for listener", "listener", listener.Name, "configmap", configMap.Name, "namespace", ns)
-					tctx.ConfigMaps[nn] = &configMap
-				case KindSecret:
-					caSecret := corev1.Secret{}
-					if err := r.Get(context.Background(), nn, &caSecret); err != nil {
-						r.Log.Error(err, "failed to get CA secret", "namespace", ns, "name", ref.Name)
-						SetGatewayListenerConditionProgrammed(gateway, string(listener.Name), false, err.Error())
-						SetGatewayListenerConditionResolvedRefs(gateway, string(listener.Name), false, err.Error())
-						continue
-					}
-					r.Log.Info("Setting CA secret for listener", "listener", listener.Name, "secret", caSecret.Name, "namespace", ns)
-					tctx.Secrets[nn] = &caSecret
+				r.Log.Info("Setting CA configmap for listener", "listener", listener.Name, "configmap", configMap.Name, "namespace", ns)
+				tctx.ConfigMaps[nn] = &configMap
+			case KindSecret:
+				caSecret := corev1.Secret{}
+				if err := r.Get(context.Background(), nn, &caSecret); err != nil {
+					r.Log.Error(err, "failed to get CA secret", "namespace", ns, "name", ref.Name)
+					SetGatewayListenerConditionProgrammed(gateway, string(listener.Name), false, err.Error())
+					SetGatewayListenerConditionResolvedRefs(gateway, string(listener.Name), false, err.Error())
+					continue
 				}
+				r.Log.Info("Setting CA secret for listener", "listener", listener.Name, "secret", caSecret.Name, "namespace", ns)
+				tctx.Secrets[nn] = &caSecret
 			}
 		}
 	}
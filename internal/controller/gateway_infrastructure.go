@@ -0,0 +1,309 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// getPublishService returns the PublishService of a GatewayProxy, nil if it has none.
+// A PublishService without namespace is looked up in the namespace of the GatewayProxy.
+func getPublishService(ctx context.Context, c client.Client, gatewayProxy *v1alpha1.GatewayProxy) (*corev1.Service, error) {
+	if gatewayProxy.Spec.PublishService == "" {
+		return nil, nil
+	}
+	namespace, name, err := SplitMetaNamespaceKey(gatewayProxy.Spec.PublishService)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publishService format: %s, expected format: namespace/name", gatewayProxy.Spec.PublishService)
+	}
+	svc := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cmp.Or(namespace, gatewayProxy.Namespace), Name: name}, svc); err != nil {
+		return nil, fmt.Errorf("failed to get publish service %s: %w", gatewayProxy.Spec.PublishService, err)
+	}
+	return svc, nil
+}
+
+// gatewayProxyAddresses returns the addresses the data plane of a GatewayProxy can
+// be reached at: its StatusAddress and the external addresses of its PublishService.
+func gatewayProxyAddresses(ctx context.Context, c client.Client, gatewayProxy *v1alpha1.GatewayProxy) ([]gatewayv1.GatewayStatusAddress, error) {
+	values := slices.Clone(gatewayProxy.Spec.StatusAddress)
+	svc, err := getPublishService(ctx, c, gatewayProxy)
+	if err != nil {
+		return nil, err
+	}
+	if svc != nil {
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			values = append(values, ingress.IP, ingress.Hostname)
+		}
+		values = append(values, svc.Spec.ExternalIPs...)
+	}
+
+	addrs := make([]gatewayv1.GatewayStatusAddress, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		addr := gatewayAddress(value)
+		if !slices.ContainsFunc(addrs, func(a gatewayv1.GatewayStatusAddress) bool { return sameGatewayAddress(a, addr) }) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
+}
+
+// gatewayAddress returns the value as an IPAddress if it is an IP, as a Hostname otherwise.
+func gatewayAddress(value string) gatewayv1.GatewayStatusAddress {
+	addrType := gatewayv1.HostnameAddressType
+	if _, err := netip.ParseAddr(value); err == nil {
+		addrType = gatewayv1.IPAddressType
+	}
+	return gatewayv1.GatewayStatusAddress{Type: &addrType, Value: value}
+}
+
+// sameGatewayAddress reports whether two addresses are the same, comparing IPs by value.
+func sameGatewayAddress(a, b gatewayv1.GatewayStatusAddress) bool {
+	addrType := ptr.Deref(a.Type, gatewayv1.IPAddressType)
+	if addrType != ptr.Deref(b.Type, gatewayv1.IPAddressType) {
+		return false
+	}
+	if addrType == gatewayv1.IPAddressType {
+		ipA, errA := netip.ParseAddr(a.Value)
+		ipB, errB := netip.ParseAddr(b.Value)
+		if errA == nil && errB == nil {
+			return ipA.Unmap() == ipB.Unmap()
+		}
+	}
+	return a.Value == b.Value
+}
+
+// assignGatewayAddresses matches the addresses requested by a Gateway against the
+// addresses available to it. A static address must be one of the available
+// addresses, and an empty value is assigned the first available address of its type.
+func assignGatewayAddresses(requested []gatewayv1.GatewaySpecAddress, available []gatewayv1.GatewayStatusAddress) ([]gatewayv1.GatewayStatusAddress, error) {
+	assigned := make([]gatewayv1.GatewayStatusAddress, 0, len(requested))
+	isAssigned := func(addr gatewayv1.GatewayStatusAddress) bool {
+		return slices.ContainsFunc(assigned, func(a gatewayv1.GatewayStatusAddress) bool { return sameGatewayAddress(a, addr) })
+	}
+	for _, req := range requested {
+		addrType := ptr.Deref(req.Type, gatewayv1.IPAddressType)
+		if addrType != gatewayv1.IPAddressType && addrType != gatewayv1.HostnameAddressType {
+			return nil, internaltypes.ReasonError{
+				Reason:  string(gatewayv1.GatewayReasonUnsupportedAddress),
+				Message: fmt.Sprintf("unsupported address type %q, only IPAddress and Hostname are supported", addrType),
+			}
+		}
+		addr := gatewayv1.GatewayStatusAddress{Type: &addrType, Value: req.Value}
+
+		if req.Value == "" {
+			i := slices.IndexFunc(available, func(a gatewayv1.GatewayStatusAddress) bool {
+				return ptr.Deref(a.Type, gatewayv1.IPAddressType) == addrType && !isAssigned(a)
+			})
+			if i < 0 {
+				return nil, internaltypes.ReasonError{
+					Reason:  string(gatewayv1.GatewayReasonAddressNotAssigned),
+					Message: fmt.Sprintf("no %s address of the GatewayProxy is left to assign", addrType),
+				}
+			}
+			assigned = append(assigned, available[i])
+			continue
+		}
+
+		if addrType == gatewayv1.IPAddressType {
+			if _, err := netip.ParseAddr(req.Value); err != nil {
+				return nil, internaltypes.ReasonError{
+					Reason:  string(gatewayv1.GatewayReasonAddressNotUsable),
+					Message: fmt.Sprintf("address %q is not a valid IP address", req.Value),
+				}
+			}
+		}
+		if !slices.ContainsFunc(available, func(a gatewayv1.GatewayStatusAddress) bool { return sameGatewayAddress(a, addr) }) {
+			if len(available) == 0 {
+				return nil, internaltypes.ReasonError{
+					Reason:  string(gatewayv1.GatewayReasonAddressNotAssigned),
+					Message: fmt.Sprintf("address %q is not assigned, the GatewayProxy has no statusAddress and its publishService has no external address", req.Value),
+				}
+			}
+			return nil, internaltypes.ReasonError{
+				Reason:  string(gatewayv1.GatewayReasonAddressNotUsable),
+				Message: fmt.Sprintf("address %q is neither a statusAddress of the GatewayProxy nor an external address of its publishService", req.Value),
+			}
+		}
+		if !isAssigned(addr) {
+			assigned = append(assigned, addr)
+		}
+	}
+	return assigned, nil
+}
+
+// processGatewayAddresses assigns the addresses requested by a Gateway from the
+// addresses of its GatewayProxy.
+func (r *GatewayReconciler) processGatewayAddresses(ctx context.Context, gateway *gatewayv1.Gateway, gatewayProxy *v1alpha1.GatewayProxy) ([]gatewayv1.GatewayStatusAddress, error) {
+	available, err := gatewayProxyAddresses(ctx, r.Client, gatewayProxy)
+	if err != nil {
+		return nil, internaltypes.ReasonError{
+			Reason:  string(gatewayv1.GatewayReasonAddressNotAssigned),
+			Message: err.Error(),
+		}
+	}
+	return assignGatewayAddresses(gateway.Spec.Addresses, available)
+}
+
+// gatewayInfrastructureAnnotation records on a PublishService the infrastructure label
+// and annotation keys each Gateway applied to it, so that a key dropped from a Gateway
+// is removed from the Service unless another Gateway still sets it.
+const gatewayInfrastructureAnnotation = "apisix.apache.org/gateway-infrastructure"
+
+// gatewayInfrastructureKeys are the label and annotation keys a Gateway applied.
+type gatewayInfrastructureKeys struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// ownedGatewayInfrastructure returns the keys recorded on the Service, by Gateway.
+// A record that cannot be parsed is dropped, the keys it held are then kept as is.
+func ownedGatewayInfrastructure(svc *corev1.Service) map[string]gatewayInfrastructureKeys {
+	owned := make(map[string]gatewayInfrastructureKeys)
+	if value, ok := svc.Annotations[gatewayInfrastructureAnnotation]; ok {
+		_ = json.Unmarshal([]byte(value), &owned)
+	}
+	return owned
+}
+
+// applyGatewayInfrastructure copies the infrastructure labels and annotations of a
+// Gateway onto the PublishService of its GatewayProxy, when the GatewayProxy lets the
+// controller manage it. As several Gateways may share the Service, a key the Gateway
+// no longer sets is only removed when no other Gateway sets it either.
+func (r *GatewayReconciler) applyGatewayInfrastructure(ctx context.Context, gateway *gatewayv1.Gateway, gatewayProxy *v1alpha1.GatewayProxy) error {
+	if !gatewayProxy.Spec.ManagePublishService {
+		return nil
+	}
+	svc, err := getPublishService(ctx, r.Client, gatewayProxy)
+	if err != nil || svc == nil {
+		return err
+	}
+
+	var current gatewayInfrastructureKeys
+	if infra := gateway.Spec.Infrastructure; infra != nil {
+		for key := range infra.Labels {
+			current.Labels = append(current.Labels, string(key))
+		}
+		for key := range infra.Annotations {
+			current.Annotations = append(current.Annotations, string(key))
+		}
+		slices.Sort(current.Labels)
+		slices.Sort(current.Annotations)
+	}
+	owned := ownedGatewayInfrastructure(svc)
+	owner := utils.NamespacedName(gateway).String()
+	previous := owned[owner]
+	if len(previous.Labels) == 0 && len(previous.Annotations) == 0 &&
+		len(current.Labels) == 0 && len(current.Annotations) == 0 {
+		return nil
+	}
+	delete(owned, owner)
+	setByOthers := func(keys func(gatewayInfrastructureKeys) []string, key string) bool {
+		for _, other := range owned {
+			if slices.Contains(keys(other), key) {
+				return true
+			}
+		}
+		return false
+	}
+	labelKeys := func(k gatewayInfrastructureKeys) []string { return k.Labels }
+	annotationKeys := func(k gatewayInfrastructureKeys) []string { return k.Annotations }
+
+	patched := svc.DeepCopy()
+	for _, key := range previous.Labels {
+		if !slices.Contains(current.Labels, key) && !setByOthers(labelKeys, key) {
+			delete(patched.Labels, key)
+		}
+	}
+	for _, key := range previous.Annotations {
+		if !slices.Contains(current.Annotations, key) && !setByOthers(annotationKeys, key) {
+			delete(patched.Annotations, key)
+		}
+	}
+	if infra := gateway.Spec.Infrastructure; infra != nil {
+		for key, value := range infra.Labels {
+			if patched.Labels == nil {
+				patched.Labels = make(map[string]string, len(infra.Labels))
+			}
+			patched.Labels[string(key)] = string(value)
+		}
+		for key, value := range infra.Annotations {
+			if patched.Annotations == nil {
+				patched.Annotations = make(map[string]string, len(infra.Annotations))
+			}
+			patched.Annotations[string(key)] = string(value)
+		}
+	}
+
+	if len(current.Labels) > 0 || len(current.Annotations) > 0 {
+		owned[owner] = current
+	}
+	if len(owned) == 0 {
+		delete(patched.Annotations, gatewayInfrastructureAnnotation)
+	} else {
+		record, err := json.Marshal(owned)
+		if err != nil {
+			return err
+		}
+		if patched.Annotations == nil {
+			patched.Annotations = make(map[string]string, 1)
+		}
+		patched.Annotations[gatewayInfrastructureAnnotation] = string(record)
+	}
+	if maps.Equal(svc.Labels, patched.Labels) && maps.Equal(svc.Annotations, patched.Annotations) {
+		return nil
+	}
+	r.Log.V(1).Info("applying gateway infrastructure to publish service", "gateway", gateway.Name, "service", client.ObjectKeyFromObject(svc))
+	return r.Patch(ctx, patched, client.MergeFrom(svc))
+}
+
+// listGatewaysForPublishService lists the Gateways whose GatewayProxy publishes the Service.
+func (r *GatewayReconciler) listGatewaysForPublishService(ctx context.Context, obj client.Object) []reconcile.Request {
+	var gatewayProxies v1alpha1.GatewayProxyList
+	if err := r.List(ctx, &gatewayProxies, client.MatchingFields{
+		indexer.PublishServiceIndexRef: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+	}); err != nil {
+		r.Log.Error(err, "failed to list gateway proxies for publish service", "service", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range gatewayProxies.Items {
+		requests = append(requests, r.listGatewaysForGatewayProxy(ctx, &gatewayProxies.Items[i])...)
+	}
+	return requests
+}
@@ -0,0 +1,246 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestAssignGatewayAddresses(t *testing.T) {
+	available := []gatewayv1.GatewayStatusAddress{
+		gatewayAddress("10.0.0.1"),
+		gatewayAddress("2001:db8::1"),
+		gatewayAddress("lb.example.com"),
+	}
+	ip := func(value string) gatewayv1.GatewaySpecAddress {
+		return gatewayv1.GatewaySpecAddress{Value: value}
+	}
+	typed := func(addrType gatewayv1.AddressType, value string) gatewayv1.GatewaySpecAddress {
+		return gatewayv1.GatewaySpecAddress{Type: &addrType, Value: value}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		requested  []gatewayv1.GatewaySpecAddress
+		available  []gatewayv1.GatewayStatusAddress
+		want       []string
+		wantReason gatewayv1.GatewayConditionReason
+	}{
+		{
+			name:      "static addresses",
+			requested: []gatewayv1.GatewaySpecAddress{ip("10.0.0.1"), ip("2001:db8:0::1"), typed(gatewayv1.HostnameAddressType, "lb.example.com")},
+			available: available,
+			want:      []string{"10.0.0.1", "2001:db8:0::1", "lb.example.com"},
+		},
+		{
+			name:      "empty values are assigned",
+			requested: []gatewayv1.GatewaySpecAddress{ip("10.0.0.1"), ip(""), typed(gatewayv1.HostnameAddressType, "")},
+			available: available,
+			want:      []string{"10.0.0.1", "2001:db8::1", "lb.example.com"},
+		},
+		{
+			name:       "no address left to assign",
+			requested:  []gatewayv1.GatewaySpecAddress{ip(""), ip(""), ip("")},
+			available:  available,
+			wantReason: gatewayv1.GatewayReasonAddressNotAssigned,
+		},
+		{
+			name:       "unknown static address",
+			requested:  []gatewayv1.GatewaySpecAddress{ip("10.0.0.2")},
+			available:  available,
+			wantReason: gatewayv1.GatewayReasonAddressNotUsable,
+		},
+		{
+			name:       "invalid IP address",
+			requested:  []gatewayv1.GatewaySpecAddress{ip("lb.example.com")},
+			available:  available,
+			wantReason: gatewayv1.GatewayReasonAddressNotUsable,
+		},
+		{
+			name:       "no address available",
+			requested:  []gatewayv1.GatewaySpecAddress{ip("10.0.0.1")},
+			wantReason: gatewayv1.GatewayReasonAddressNotAssigned,
+		},
+		{
+			name:       "named address",
+			requested:  []gatewayv1.GatewaySpecAddress{typed(gatewayv1.NamedAddressType, "internal")},
+			available:  available,
+			wantReason: gatewayv1.GatewayReasonUnsupportedAddress,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assigned, err := assignGatewayAddresses(tc.requested, tc.available)
+			if tc.wantReason != "" {
+				assert.True(t, types.IsSomeReasonError(err, tc.wantReason), "unexpected error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			values := make([]string, 0, len(assigned))
+			for _, addr := range assigned {
+				values = append(values, addr.Value)
+			}
+			assert.Equal(t, tc.want, values)
+		})
+	}
+}
+
+func gatewayInfrastructureTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	return scheme
+}
+
+func TestGatewayProxyAddresses(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix-gateway"},
+		Spec:       corev1.ServiceSpec{ExternalIPs: []string{"192.168.0.10"}},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+			{IP: "10.0.0.1"},
+			{Hostname: "lb.example.com"},
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(gatewayInfrastructureTestScheme(t)).WithObjects(svc).Build()
+	gatewayProxy := &v1alpha1.GatewayProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+		Spec: v1alpha1.GatewayProxySpec{
+			PublishService: "apisix-gateway",
+			StatusAddress:  []string{"10.0.0.1", "172.16.0.1"},
+		},
+	}
+
+	addrs, err := gatewayProxyAddresses(context.Background(), c, gatewayProxy)
+	require.NoError(t, err)
+	assert.Equal(t, []gatewayv1.GatewayStatusAddress{
+		gatewayAddress("10.0.0.1"),
+		gatewayAddress("172.16.0.1"),
+		gatewayAddress("lb.example.com"),
+		gatewayAddress("192.168.0.10"),
+	}, addrs)
+}
+
+func TestApplyGatewayInfrastructure(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "apisix",
+			Name:      "apisix-gateway",
+			Labels:    map[string]string{"app": "apisix"},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		Spec: gatewayv1.GatewaySpec{
+			Infrastructure: &gatewayv1.GatewayInfrastructure{
+				Labels:      map[gatewayv1.LabelKey]gatewayv1.LabelValue{"team": "lb"},
+				Annotations: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{"lb.example.com/pool": "public"},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		manage bool
+		want   *corev1.Service
+	}{
+		{
+			name: "unmanaged publish service",
+			want: svc,
+		},
+		{
+			name:   "managed publish service",
+			manage: true,
+			want: &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": "apisix", "team": "lb"},
+				Annotations: map[string]string{
+					"lb.example.com/pool":           "public",
+					gatewayInfrastructureAnnotation: `{"default/gateway":{"labels":["team"],"annotations":["lb.example.com/pool"]}}`,
+				},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(gatewayInfrastructureTestScheme(t)).WithObjects(svc.DeepCopy()).Build()
+			r := &GatewayReconciler{Client: c, Log: logr.Discard()}
+			gatewayProxy := &v1alpha1.GatewayProxy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+				Spec: v1alpha1.GatewayProxySpec{
+					PublishService:       "apisix/apisix-gateway",
+					ManagePublishService: tc.manage,
+				},
+			}
+			require.NoError(t, r.applyGatewayInfrastructure(context.Background(), gateway, gatewayProxy))
+
+			var got corev1.Service
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(svc), &got))
+			assert.Equal(t, tc.want.Labels, got.Labels)
+			assert.Equal(t, tc.want.Annotations, got.Annotations)
+		})
+	}
+}
+
+func TestApplyGatewayInfrastructurePrunesDroppedKeys(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apisix", Name: "apisix-gateway"},
+	}
+	gatewayProxy := &v1alpha1.GatewayProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+		Spec: v1alpha1.GatewayProxySpec{
+			PublishService:       "apisix/apisix-gateway",
+			ManagePublishService: true,
+		},
+	}
+	gateway := func(name string, labels map[gatewayv1.LabelKey]gatewayv1.LabelValue) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.GatewaySpec{
+				Infrastructure: &gatewayv1.GatewayInfrastructure{Labels: labels},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(gatewayInfrastructureTestScheme(t)).WithObjects(svc).Build()
+	r := &GatewayReconciler{Client: c, Log: logr.Discard()}
+	apply := func(gw *gatewayv1.Gateway) map[string]string {
+		require.NoError(t, r.applyGatewayInfrastructure(context.Background(), gw, gatewayProxy))
+		var got corev1.Service
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(svc), &got))
+		return got.Labels
+	}
+
+	assert.Equal(t, map[string]string{"team": "lb", "tier": "edge"}, apply(gateway("a", map[gatewayv1.LabelKey]gatewayv1.LabelValue{"team": "lb", "tier": "edge"})))
+	assert.Equal(t, map[string]string{"team": "lb", "tier": "edge"}, apply(gateway("b", map[gatewayv1.LabelKey]gatewayv1.LabelValue{"team": "lb"})))
+	// team is still set by gateway b, tier was only set by gateway a
+	assert.Equal(t, map[string]string{"team": "lb"}, apply(gateway("a", nil)))
+	assert.Empty(t, apply(gateway("b", nil)))
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +37,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
 
@@ -116,9 +118,14 @@ func (r *GatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		LastTransitionTime: meta.Now(),
 	}
 
-	if !IsConditionPresentAndEqual(gc.Status.Conditions, condition) {
-		r.Log.Info("gatewayclass has been accepted", "gatewayclass", gc.Name)
-		setGatewayClassCondition(gc, condition)
+	supportedFeatures := internaltypes.GatewayClassSupportedFeatures()
+	conditionChanged := !IsConditionPresentAndEqual(gc.Status.Conditions, condition)
+	if conditionChanged || !equality.Semantic.DeepEqual(gc.Status.SupportedFeatures, supportedFeatures) {
+		if conditionChanged {
+			r.Log.Info("gatewayclass has been accepted", "gatewayclass", gc.Name)
+			setGatewayClassCondition(gc, condition)
+		}
+		gc.Status.SupportedFeatures = supportedFeatures
 		r.Updater.Update(status.Update{
 			NamespacedName: utils.NamespacedName(gc),
 			Resource:       gc.DeepCopy(),
@@ -46,6 +46,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/provider"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 	"github.com/apache/apisix-ingress-controller/internal/utils"
+	pkgutils "github.com/apache/apisix-ingress-controller/pkg/utils"
 )
 
 // GRPCRouteReconciler reconciles a GatewayClass object.
@@ -61,15 +62,31 @@ type GRPCRouteReconciler struct { //nolint:revive
 
 	Updater status.Updater
 	Readier readiness.ReadinessManager
+
+	// supportsListenerSet indicates whether the ListenerSet API is installed.
+	supportsListenerSet bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.genericEvent = make(chan event.GenericEvent, 100)
 
+	supportsBackendTLSPolicy, err := pkgutils.HasAPIResource(mgr, &gatewayv1.BackendTLSPolicy{})
+	if err != nil {
+		return err
+	}
+
+	eventFilters := []predicate.Predicate{
+		predicate.GenerationChangedPredicate{},
+		predicate.NewPredicateFuncs(TypePredicate[*corev1.Secret]()),
+	}
+	if supportsBackendTLSPolicy {
+		eventFilters = append(eventFilters, predicate.NewPredicateFuncs(TypePredicate[*corev1.ConfigMap]()))
+	}
+
 	bdr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.GRPCRoute{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(eventFilters...)).
 		Watches(&discoveryv1.EndpointSlice{},
 			handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesByServiceRef),
 		).
@@ -101,6 +118,9 @@ func (r *GRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				BackendTrafficPolicyPredicateFunc(r.genericEvent),
 			),
 		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(backendTrafficPolicySecretMapFunc(r.Client, r.Log, r.listGRPCRoutesForBackendTrafficPolicy)),
+		).
 		Watches(&v1alpha1.GatewayProxy{},
 			handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForGatewayProxy),
 		).
@@ -111,6 +131,32 @@ func (r *GRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			),
 		)
 
+	if supportsBackendTLSPolicy {
+		bdr.Watches(&gatewayv1.BackendTLSPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForBackendTLSPolicy),
+			builder.WithPredicates(
+				BackendTLSPolicyPredicateFunc(r.genericEvent),
+			),
+		).
+			Watches(&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForCACertificate),
+			).
+			Watches(&corev1.Secret{},
+				handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForCACertificate),
+			)
+	}
+
+	supportsListenerSet, err := pkgutils.HasAPIResource(mgr, &gatewayv1.ListenerSet{})
+	if err != nil {
+		return err
+	}
+	r.supportsListenerSet = supportsListenerSet
+	if r.supportsListenerSet {
+		bdr.Watches(&gatewayv1.ListenerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForGateway),
+		)
+	}
+
 	if GetEnableReferenceGrant() {
 		bdr.Watches(&v1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.listGRPCRoutesForReferenceGrant),
@@ -228,13 +274,24 @@ func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		backendRefErr = err
 	}
 
-	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
+	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx, gateways)
+	ProcessBackendTLSPolicy(r.Client, r.Log, tctx)
+
+	mirrors := make([]int, 0, len(gr.Spec.Rules))
+	for _, rule := range gr.Spec.Rules {
+		mirrors = append(mirrors, len(utils.Filter(rule.Filters, func(filter gatewayv1.GRPCRouteFilter) bool {
+			return filter.Type == gatewayv1.GRPCRouteFilterRequestMirror
+		})))
+	}
+	if msg := partiallyAcceptedMessage(requestMirrorsNote(mirrors)); acceptStatus.status && msg != "" {
+		acceptStatus.msg = msg
+	}
 
 	// TODO: diff the old and new status
 	gr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
 	for _, gateway := range gateways {
 		parentStatus := gatewayv1.RouteParentStatus{}
-		SetRouteParentRef(&parentStatus, gateway.Gateway.Name, gateway.Gateway.Namespace)
+		SetRouteParentRefFromContext(&parentStatus, gateway)
 		for _, condition := range gateway.Conditions {
 			parentStatus.Conditions = MergeCondition(parentStatus.Conditions, condition)
 		}
@@ -305,6 +362,9 @@ func (r *GRPCRouteReconciler) listGRPCRoutesForBackendTrafficPolicy(ctx context.
 	}
 	grpcRouteList := []gatewayv1.GRPCRoute{}
 	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) != types.KindService {
+			continue
+		}
 		service := &corev1.Service{}
 		if err := r.Get(ctx, client.ObjectKey{
 			Namespace: policy.Namespace,
@@ -341,29 +401,76 @@ func (r *GRPCRouteReconciler) listGRPCRoutesForBackendTrafficPolicy(ctx context.
 			})
 		}
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, backendPolicyTargetRefs(policy.Spec.TargetRefs)) {
+		requests = append(requests, r.listGRPCRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
 
-func (r *GRPCRouteReconciler) listGRPCRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
-	gateway, ok := obj.(*gatewayv1.Gateway)
+func (r *GRPCRouteReconciler) listGRPCRoutesForBackendTLSPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy, ok := obj.(*gatewayv1.BackendTLSPolicy)
 	if !ok {
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to Gateway")
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTLSPolicy")
+		return nil
 	}
-	grList := &gatewayv1.GRPCRouteList{}
-	if err := r.List(ctx, grList, client.MatchingFields{
-		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
-	}); err != nil {
-		r.Log.Error(err, "failed to list grpcroutes by gateway", "gateway", gateway.Name)
+
+	var requests []reconcile.Request
+	keys := make(map[k8stypes.NamespacedName]struct{})
+	for _, service := range listBackendTLSPolicyTargetServices(policy) {
+		grList := &gatewayv1.GRPCRouteList{}
+		if err := r.List(ctx, grList, client.MatchingFields{
+			indexer.ServiceIndexRef: indexer.GenIndexKey(service.Namespace, service.Name),
+		}); err != nil {
+			r.Log.Error(err, "failed to list grpcroutes by service reference", "service", service)
+			return nil
+		}
+		for _, gr := range grList.Items {
+			key := utils.NamespacedName(&gr)
+			if _, ok := keys[key]; ok {
+				continue
+			}
+			keys[key] = struct{}{}
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
+
+// listGRPCRoutesForCACertificate lists the GRPCRoutes affected by a ConfigMap or Secret
+// used as CA certificate by a BackendTLSPolicy.
+func (r *GRPCRouteReconciler) listGRPCRoutesForCACertificate(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	policies, err := listBackendTLSPoliciesForCACertificate(ctx, r.Client, obj)
+	if err != nil {
+		r.Log.Error(err, "failed to list BackendTLSPolicies by CA certificate", "namespace", obj.GetNamespace(), "name", obj.GetName())
 		return nil
 	}
-	requests := make([]reconcile.Request, 0, len(grList.Items))
-	for _, gr := range grList.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Namespace: gr.Namespace,
-				Name:      gr.Name,
-			},
-		})
+	for i := range policies {
+		requests = append(requests, r.listGRPCRoutesForBackendTLSPolicy(ctx, &policies[i])...)
+	}
+	return requests
+}
+
+// listGRPCRoutesForGateway lists the GRPCRoutes attached to a Gateway or a ListenerSet.
+// For a Gateway, routes attached through its ListenerSets are listed as well.
+func (r *GRPCRouteReconciler) listGRPCRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, key := range routeParentIndexKeys(ctx, r.Client, r.Log, obj, r.supportsListenerSet) {
+		grList := &gatewayv1.GRPCRouteList{}
+		if err := r.List(ctx, grList, client.MatchingFields{
+			indexer.ParentRefs: key,
+		}); err != nil {
+			r.Log.Error(err, "failed to list grpcroutes by parent", "parent", key)
+			return nil
+		}
+		for _, gr := range grList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: gr.Namespace,
+					Name:      gr.Name,
+				},
+			})
+		}
 	}
 	return requests
 }
@@ -372,8 +479,10 @@ func (r *GRPCRouteReconciler) listGRPCRouteForGenericEvent(ctx context.Context,
 	switch obj.(type) {
 	case *v1alpha1.BackendTrafficPolicy:
 		return r.listGRPCRoutesForBackendTrafficPolicy(ctx, obj)
+	case *gatewayv1.BackendTLSPolicy:
+		return r.listGRPCRoutesForBackendTLSPolicy(ctx, obj)
 	default:
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTrafficPolicy")
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTrafficPolicy or BackendTLSPolicy")
 		return nil
 	}
 }
@@ -21,6 +21,9 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -43,6 +46,7 @@ import (
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -68,6 +72,9 @@ type HTTPRouteReconciler struct { //nolint:revive
 
 	// supportsEndpointSlice indicates whether the cluster supports EndpointSlice API
 	supportsEndpointSlice bool
+
+	// supportsListenerSet indicates whether the ListenerSet API is installed.
+	supportsListenerSet bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -81,13 +88,28 @@ func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	r.supportsEndpointSlice = supportsEndpointSlice
 
+	supportsBackendTLSPolicy, err := pkgutils.HasAPIResource(mgr, &gatewayv1.BackendTLSPolicy{})
+	if err != nil {
+		return err
+	}
+
 	eventFilters := []predicate.Predicate{
 		predicate.GenerationChangedPredicate{},
+		predicate.NewPredicateFuncs(TypePredicate[*corev1.Secret]()),
 	}
 
 	if !r.supportsEndpointSlice {
 		eventFilters = append(eventFilters, predicate.NewPredicateFuncs(TypePredicate[*corev1.Endpoints]()))
 	}
+	if supportsBackendTLSPolicy {
+		eventFilters = append(eventFilters, predicate.NewPredicateFuncs(TypePredicate[*corev1.ConfigMap]()))
+	}
+	// Services are watched as the parents of mesh routes, whose cluster IPs and
+	// ports are not tracked by the generation.
+	_, meshEnabled := config.GetMeshGatewayProxy()
+	if meshEnabled {
+		eventFilters = append(eventFilters, predicate.NewPredicateFuncs(TypePredicate[*corev1.Service]()))
+	}
 
 	bdr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.HTTPRoute{}).
@@ -128,6 +150,9 @@ func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				BackendTrafficPolicyPredicateFunc(r.genericEvent),
 			),
 		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(backendTrafficPolicySecretMapFunc(r.Client, r.Log, r.listHTTPRoutesForBackendTrafficPolicy)),
+		).
 		Watches(&v1alpha1.HTTPRoutePolicy{},
 			handler.EnqueueRequestsFromMapFunc(r.listHTTPRouteByHTTPRoutePolicy),
 			builder.WithPredicates(httpRoutePolicyPredicateFuncs(r.genericEvent)),
@@ -142,6 +167,38 @@ func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			),
 		)
 
+	if supportsBackendTLSPolicy {
+		bdr.Watches(&gatewayv1.BackendTLSPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForBackendTLSPolicy),
+			builder.WithPredicates(
+				BackendTLSPolicyPredicateFunc(r.genericEvent),
+			),
+		).
+			Watches(&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForCACertificate),
+			).
+			Watches(&corev1.Secret{},
+				handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForCACertificate),
+			)
+	}
+
+	supportsListenerSet, err := pkgutils.HasAPIResource(mgr, &gatewayv1.ListenerSet{})
+	if err != nil {
+		return err
+	}
+	r.supportsListenerSet = supportsListenerSet
+	if r.supportsListenerSet {
+		bdr.Watches(&gatewayv1.ListenerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForGateway),
+		)
+	}
+
+	if meshEnabled {
+		bdr.Watches(&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForParentService),
+		)
+	}
+
 	if GetEnableReferenceGrant() {
 		bdr.Watches(&v1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.listHTTPRoutesForReferenceGrant),
@@ -179,6 +236,8 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	type ResourceStatus struct {
 		status bool
+		// reason overrides the reason derived from the message when set.
+		reason string
 		msg    string
 	}
 
@@ -187,15 +246,32 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		status: true,
 		msg:    "Route is accepted",
 	}
+	// reject records err as the reason the route is not accepted. The reason always
+	// belongs to the message it is reported with, a later error replaces both.
+	reject := func(err error) {
+		acceptStatus.status = false
+		acceptStatus.msg = err.Error()
+		acceptStatus.reason = ""
+		if types.IsSomeReasonError(err, gatewayv1.RouteReasonUnsupportedValue) {
+			acceptStatus.reason = string(gatewayv1.RouteReasonUnsupportedValue)
+		}
+	}
 
 	gateways, err := ParseRouteParentRefs(ctx, r.Client, r.Log, hr, hr.Spec.ParentRefs)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	services, err := ParseRouteServiceParentRefs(ctx, r.Client, hr, hr.Spec.ParentRefs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-	if len(gateways) == 0 {
+	if len(gateways) == 0 && len(services) == 0 {
 		return ctrl.Result{}, nil
 	}
+	if len(gateways) > 0 {
+		rejectMixedMeshParents(services, hr.GetGeneration())
+	}
 
 	tctx := provider.NewDefaultTranslateContext(ctx)
 
@@ -203,8 +279,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	rk := utils.NamespacedNameKind(hr)
 	for _, gateway := range gateways {
 		if err := ProcessGatewayProxy(r.Client, r.Log, tctx, gateway.Gateway, rk); err != nil {
-			acceptStatus.status = false
-			acceptStatus.msg = err.Error()
+			reject(err)
 		}
 		// Populate listeners for port-based routing.
 		// Use Listeners slice if available (multiple listener support)
@@ -216,6 +291,11 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 		tctx.HasExplicitListenerMatch = tctx.HasExplicitListenerMatch || gateway.ExplicitListenerMatch
 	}
+	if isRouteAccepted(services) {
+		if err := ProcessMeshGatewayProxy(r.Client, r.Log, tctx, rk); err != nil {
+			reject(err)
+		}
+	}
 
 	var backendRefErr error
 	if err := r.processHTTPRoute(tctx, hr); err != nil {
@@ -223,14 +303,12 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if types.IsSomeReasonError(err, gatewayv1.RouteReasonInvalidKind) {
 			backendRefErr = err
 		} else {
-			acceptStatus.status = false
-			acceptStatus.msg = err.Error()
+			reject(err)
 		}
 	}
 
-	if err := r.processHTTPRoutePolicies(tctx, hr); err != nil {
-		acceptStatus.status = false
-		acceptStatus.msg = err.Error()
+	if err := r.processHTTPRoutePolicies(tctx, hr, gateways); err != nil {
+		reject(err)
 	}
 
 	// Store the backend reference error for later use.
@@ -239,23 +317,46 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		backendRefErr = err
 	}
 
-	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
+	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx, gateways)
+	ProcessBackendTLSPolicy(r.Client, r.Log, tctx)
 
-	filteredHTTPRoute, err := filterHostnames(gateways, hr.DeepCopy())
-	if err != nil {
-		acceptStatus.status = false
-		acceptStatus.msg = err.Error()
+	var filteredHTTPRoute *gatewayv1.HTTPRoute
+	if len(gateways) > 0 {
+		filteredHTTPRoute, err = filterHostnames(gateways, hr.DeepCopy())
+		if err != nil {
+			reject(err)
+		}
+	} else {
+		// Mesh routes ignore hostnames and match the requests sent to their Services.
+		filteredHTTPRoute = hr.DeepCopy()
+		filteredHTTPRoute.Spec.Hostnames = meshRouteHostnames(services)
+	}
+
+	mirrors := make([]int, 0, len(hr.Spec.Rules))
+	for _, rule := range hr.Spec.Rules {
+		mirrors = append(mirrors, len(utils.Filter(rule.Filters, func(filter gatewayv1.HTTPRouteFilter) bool {
+			return filter.Type == gatewayv1.HTTPRouteFilterRequestMirror
+		})))
+	}
+	if msg := partiallyAcceptedMessage(requestMirrorsNote(mirrors), externalAuthForwardBodyNote(hr.Spec.Rules)); acceptStatus.status && msg != "" {
+		acceptStatus.msg = msg
 	}
 
+	parents := make([]RouteParentRefContext, 0, len(gateways)+len(services))
+	parents = append(append(parents, gateways...), services...)
 	// TODO: diff the old and new status
-	hr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
-	for _, gateway := range gateways {
+	hr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(parents))
+	for _, gateway := range parents {
 		parentStatus := gatewayv1.RouteParentStatus{}
-		SetRouteParentRef(&parentStatus, gateway.Gateway.Name, gateway.Gateway.Namespace)
+		SetRouteParentRefFromContext(&parentStatus, gateway)
 		for _, condition := range gateway.Conditions {
 			parentStatus.Conditions = MergeCondition(parentStatus.Conditions, condition)
 		}
-		SetRouteConditionAccepted(&parentStatus, hr.GetGeneration(), acceptStatus.status, acceptStatus.msg)
+		if acceptStatus.reason != "" {
+			SetRouteConditionAcceptedWithReason(&parentStatus, hr.GetGeneration(), acceptStatus.status, acceptStatus.reason, acceptStatus.msg)
+		} else {
+			SetRouteConditionAccepted(&parentStatus, hr.GetGeneration(), acceptStatus.status, acceptStatus.msg)
+		}
 		SetRouteConditionResolvedRefs(&parentStatus, hr.GetGeneration(), backendRefErr)
 
 		hr.Status.Parents = append(hr.Status.Parents, parentStatus)
@@ -277,7 +378,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	})
 	UpdateStatus(r.Updater, r.Log, tctx)
 
-	if isRouteAccepted(gateways) && err == nil {
+	if isRouteAccepted(parents) && err == nil {
 		routeToUpdate := hr
 		if filteredHTTPRoute != nil {
 			r.Log.V(1).Info("filtered httproute", "httproute", utils.NamespacedName(filteredHTTPRoute))
@@ -373,6 +474,17 @@ func (r *HTTPRouteReconciler) listHTTPRoutesByExtensionRef(ctx context.Context,
 			},
 		})
 	}
+
+	var policyList v1alpha1.HTTPRoutePolicyList
+	if err := r.List(ctx, &policyList, client.MatchingFields{
+		indexer.PluginConfigIndexRef: indexer.GenIndexKey(namespace, name),
+	}); err != nil {
+		r.Log.Error(err, "failed to list httproutepolicies by plugin config reference", "pluginconfig", name)
+		return requests
+	}
+	for i := range policyList.Items {
+		requests = append(requests, r.listHTTPRouteByHTTPRoutePolicy(ctx, &policyList.Items[i])...)
+	}
 	return requests
 }
 
@@ -385,6 +497,9 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForBackendTrafficPolicy(ctx context.
 
 	httprouteList := []gatewayv1.HTTPRoute{}
 	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) != types.KindService {
+			continue
+		}
 		service := &corev1.Service{}
 		if err := r.Get(ctx, client.ObjectKey{
 			Namespace: policy.Namespace,
@@ -421,29 +536,76 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForBackendTrafficPolicy(ctx context.
 			})
 		}
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, backendPolicyTargetRefs(policy.Spec.TargetRefs)) {
+		requests = append(requests, r.listHTTPRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
 
-func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
-	gateway, ok := obj.(*gatewayv1.Gateway)
+func (r *HTTPRouteReconciler) listHTTPRoutesForBackendTLSPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy, ok := obj.(*gatewayv1.BackendTLSPolicy)
 	if !ok {
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to Gateway")
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTLSPolicy")
+		return nil
 	}
-	hrList := &gatewayv1.HTTPRouteList{}
-	if err := r.List(ctx, hrList, client.MatchingFields{
-		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
-	}); err != nil {
-		r.Log.Error(err, "failed to list httproutes by gateway", "gateway", gateway.Name)
+
+	var requests []reconcile.Request
+	keys := make(map[k8stypes.NamespacedName]struct{})
+	for _, service := range listBackendTLSPolicyTargetServices(policy) {
+		hrList := &gatewayv1.HTTPRouteList{}
+		if err := r.List(ctx, hrList, client.MatchingFields{
+			indexer.ServiceIndexRef: indexer.GenIndexKey(service.Namespace, service.Name),
+		}); err != nil {
+			r.Log.Error(err, "failed to list httproutes by service reference", "service", service)
+			return nil
+		}
+		for _, hr := range hrList.Items {
+			key := utils.NamespacedName(&hr)
+			if _, ok := keys[key]; ok {
+				continue
+			}
+			keys[key] = struct{}{}
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
+
+// listHTTPRoutesForCACertificate lists the HTTPRoutes affected by a ConfigMap or Secret
+// used as CA certificate by a BackendTLSPolicy.
+func (r *HTTPRouteReconciler) listHTTPRoutesForCACertificate(ctx context.Context, obj client.Object) (requests []reconcile.Request) {
+	policies, err := listBackendTLSPoliciesForCACertificate(ctx, r.Client, obj)
+	if err != nil {
+		r.Log.Error(err, "failed to list BackendTLSPolicies by CA certificate", "namespace", obj.GetNamespace(), "name", obj.GetName())
 		return nil
 	}
-	requests := make([]reconcile.Request, 0, len(hrList.Items))
-	for _, hr := range hrList.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Namespace: hr.Namespace,
-				Name:      hr.Name,
-			},
-		})
+	for i := range policies {
+		requests = append(requests, r.listHTTPRoutesForBackendTLSPolicy(ctx, &policies[i])...)
+	}
+	return requests
+}
+
+// listHTTPRoutesForGateway lists the HTTPRoutes attached to a Gateway or a ListenerSet.
+// For a Gateway, routes attached through its ListenerSets are listed as well.
+func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, key := range routeParentIndexKeys(ctx, r.Client, r.Log, obj, r.supportsListenerSet) {
+		hrList := &gatewayv1.HTTPRouteList{}
+		if err := r.List(ctx, hrList, client.MatchingFields{
+			indexer.ParentRefs: key,
+		}); err != nil {
+			r.Log.Error(err, "failed to list httproutes by parent", "parent", key)
+			return nil
+		}
+		for _, hr := range hrList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: hr.Namespace,
+					Name:      hr.Name,
+				},
+			})
+		}
 	}
 	return requests
 }
@@ -496,6 +658,10 @@ func (r *HTTPRouteReconciler) listHTTPRouteByHTTPRoutePolicy(ctx context.Context
 		keys[key] = struct{}{}
 		requests = append(requests, reconcile.Request{NamespacedName: key})
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(httpRoutePolicy.Namespace, httpRoutePolicy.Spec.TargetRefs) {
+		requests = append(requests, r.listHTTPRoutesForGateway(ctx, gateway)...)
+	}
 
 	return requests
 }
@@ -506,8 +672,10 @@ func (r *HTTPRouteReconciler) listHTTPRouteForGenericEvent(ctx context.Context,
 		return r.listHTTPRoutesForBackendTrafficPolicy(ctx, obj)
 	case *v1alpha1.HTTPRoutePolicy:
 		return r.listHTTPRouteByHTTPRoutePolicy(ctx, obj)
+	case *gatewayv1.BackendTLSPolicy:
+		return r.listHTTPRoutesForBackendTLSPolicy(ctx, obj)
 	default:
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTrafficPolicy or HTTPRoutePolicy")
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to BackendTrafficPolicy, BackendTLSPolicy or HTTPRoutePolicy")
 		return nil
 	}
 }
@@ -600,7 +768,28 @@ func (r *HTTPRouteReconciler) processHTTPRouteBackendRefs(tctx *provider.Transla
 func (r *HTTPRouteReconciler) processHTTPRoute(tctx *provider.TranslateContext, httpRoute *gatewayv1.HTTPRoute) error {
 	var terror error
 	for _, rule := range httpRoute.Spec.Rules {
+		if err := validateHTTPRouteTimeouts(rule.Timeouts); err != nil {
+			terror = err
+		}
+		if err := validateHTTPRouteRetry(rule.Retry); err != nil {
+			terror = err
+		}
+		if err := validateHTTPRouteSessionPersistence(rule.SessionPersistence); err != nil {
+			terror = err
+		}
 		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1.HTTPRouteFilterExternalAuth && filter.ExternalAuth != nil {
+				if err := validateHTTPRouteExternalAuth(filter.ExternalAuth); err != nil {
+					terror = err
+					continue
+				}
+				// the auth service is resolved like a backend, so that it is
+				// subject to the same ReferenceGrant and existence checks
+				backendRef := filter.ExternalAuth.BackendRef
+				backendRef.Namespace = cmp.Or(backendRef.Namespace, (*gatewayv1.Namespace)(&httpRoute.Namespace))
+				tctx.BackendRefs = append(tctx.BackendRefs, gatewayv1.BackendRef{BackendObjectReference: backendRef})
+				continue
+			}
 			if filter.Type != gatewayv1.HTTPRouteFilterExtensionRef || filter.ExtensionRef == nil {
 				continue
 			}
@@ -637,6 +826,152 @@ func (r *HTTPRouteReconciler) processHTTPRoute(tctx *provider.TranslateContext,
 	return terror
 }
 
+// validateHTTPRouteTimeouts reports the rule timeouts the data plane cannot express
+// as UnsupportedValue.
+func validateHTTPRouteTimeouts(timeouts *gatewayv1.HTTPRouteTimeouts) error {
+	if timeouts == nil {
+		return nil
+	}
+	var request, backendRequest time.Duration
+	for _, t := range []struct {
+		field string
+		value *gatewayv1.Duration
+		dur   *time.Duration
+	}{
+		{"request", timeouts.Request, &request},
+		{"backendRequest", timeouts.BackendRequest, &backendRequest},
+	} {
+		if t.value == nil {
+			continue
+		}
+		d, err := types.ParseProxyTimeout(*t.value)
+		if err != nil {
+			return types.ReasonError{
+				Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+				Message: fmt.Sprintf("unsupported timeouts.%s: %v", t.field, err),
+			}
+		}
+		*t.dur = d
+	}
+	if request != 0 && backendRequest > request {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("timeouts.backendRequest %s must not be longer than timeouts.request %s", *timeouts.BackendRequest, *timeouts.Request),
+		}
+	}
+	return nil
+}
+
+// validateHTTPRouteRetry reports retry codes and backoff as UnsupportedValue. The data
+// plane retries on connection errors and timeouts only, and without waiting between
+// attempts, so only attempts is translated.
+func validateHTTPRouteRetry(retry *gatewayv1.HTTPRouteRetry) error {
+	if retry == nil {
+		return nil
+	}
+	if len(retry.Codes) > 0 {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: "retry.codes is not supported, requests are only retried on connection errors and timeouts",
+		}
+	}
+	if retry.Backoff != nil {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("retry.backoff %s is not supported, retries are not delayed", *retry.Backoff),
+		}
+	}
+	return nil
+}
+
+// sessionNameRegexp matches the session names usable as both a cookie name and
+// an nginx variable suffix.
+var sessionNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateHTTPRouteSessionPersistence reports the session persistence settings
+// the data plane cannot express as UnsupportedValue.
+func validateHTTPRouteSessionPersistence(sp *gatewayv1.SessionPersistence) error {
+	if sp == nil {
+		return nil
+	}
+	if sp.SessionName != nil && !sessionNameRegexp.MatchString(*sp.SessionName) {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("unsupported sessionPersistence.sessionName %q, only letters, digits, '_' and '-' are allowed", *sp.SessionName),
+		}
+	}
+	if sp.AbsoluteTimeout != nil {
+		if d, err := time.ParseDuration(string(*sp.AbsoluteTimeout)); err != nil || d <= 0 {
+			return types.ReasonError{
+				Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+				Message: fmt.Sprintf("unsupported sessionPersistence.absoluteTimeout %s", *sp.AbsoluteTimeout),
+			}
+		}
+	}
+	return nil
+}
+
+// partiallyAcceptedMessage returns the Accepted message of a route that is programmed
+// without some of its settings, or "" when notes are all empty. Each note tells which
+// part is left out.
+func partiallyAcceptedMessage(notes ...string) string {
+	notes = slices.DeleteFunc(notes, func(note string) bool { return note == "" })
+	if len(notes) == 0 {
+		return ""
+	}
+	return "Route is accepted, " + strings.Join(notes, "; ")
+}
+
+// requestMirrorsNote describes the RequestMirror filters left out of a route whose rules
+// hold the given numbers of them. proxy-mirror mirrors a request to one backend, so only
+// the first filter of a rule is applied.
+func requestMirrorsNote(mirrors []int) string {
+	var ignored int
+	for _, n := range mirrors {
+		if n > 1 {
+			ignored += n - 1
+		}
+	}
+	if ignored == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d RequestMirror filters are ignored: only the first RequestMirror filter of a rule is supported", ignored)
+}
+
+// externalAuthForwardBodyNote describes the forwardBody size caps left out of the
+// ExternalAuth filters of the rules. forward-auth sends the whole body with a POST
+// request, it cannot stop at maxSize.
+func externalAuthForwardBodyNote(rules []gatewayv1.HTTPRouteRule) string {
+	for _, rule := range rules {
+		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1.HTTPRouteFilterExternalAuth && filter.ExternalAuth != nil &&
+				filter.ExternalAuth.ForwardBody != nil && filter.ExternalAuth.ForwardBody.MaxSize > 0 {
+				return "ExternalAuth forwardBody.maxSize is not enforced: the whole request body is forwarded to the auth service"
+			}
+		}
+	}
+	return ""
+}
+
+// validateHTTPRouteExternalAuth reports the ExternalAuth settings the data plane
+// cannot express as UnsupportedValue. forward-auth only calls HTTP auth services at a
+// fixed URI.
+func validateHTTPRouteExternalAuth(extAuth *gatewayv1.HTTPExternalAuthFilter) error {
+	if extAuth.ExternalAuthProtocol != gatewayv1.HTTPRouteExternalAuthHTTPProtocol {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("unsupported ExternalAuth protocol %q, only HTTP is supported", extAuth.ExternalAuthProtocol),
+		}
+	}
+	if extAuth.HTTPAuthConfig != nil && extAuth.HTTPAuthConfig.Path != "" {
+		return types.ReasonError{
+			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("unsupported ExternalAuth path %q, the auth request path cannot be prefixed to the request path", extAuth.HTTPAuthConfig.Path),
+		}
+	}
+	return nil
+}
+
 func httpRoutePolicyPredicateFuncs(channel chan event.GenericEvent) predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -711,6 +1046,49 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForGatewayProxy(ctx context.Context,
 		}
 	}
 
+	// mesh routes are programmed into the GatewayProxy configured for the mesh
+	if key, ok := config.GetMeshGatewayProxy(); ok && key == utils.NamespacedName(gatewayProxy) {
+		httpRouteList := &gatewayv1.HTTPRouteList{}
+		if err := r.List(ctx, httpRouteList, client.MatchingFields{
+			indexer.ParentRefKinds: indexer.GenGroupKindKey(corev1.GroupName, KindService),
+		}); err != nil {
+			r.Log.Error(err, "failed to list httproutes for mesh gateway proxy", "gatewayproxy", gatewayProxy.GetName())
+			return requests
+		}
+		for _, httpRoute := range httpRouteList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: httpRoute.Namespace,
+					Name:      httpRoute.Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// listHTTPRoutesForParentService lists the mesh HTTPRoutes attached to a Service.
+func (r *HTTPRouteReconciler) listHTTPRoutesForParentService(ctx context.Context, obj client.Object) []reconcile.Request {
+	hrList := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, hrList, client.MatchingFields{
+		indexer.ParentRefs: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+	}); err != nil {
+		r.Log.Error(err, "failed to list httproutes by parent service", "service", utils.NamespacedName(obj))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(hrList.Items))
+	for _, hr := range hrList.Items {
+		if !slices.ContainsFunc(hr.Spec.ParentRefs, isServiceParentRef) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Namespace: hr.Namespace,
+				Name:      hr.Name,
+			},
+		})
+	}
 	return requests
 }
 
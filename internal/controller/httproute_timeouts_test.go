@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestValidateHTTPRouteTimeouts(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		timeouts    *gatewayv1.HTTPRouteTimeouts
+		unsupported bool
+	}{
+		{
+			name: "no timeouts",
+		},
+		{
+			name:     "sub-second request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("500ms"))},
+		},
+		{
+			name: "zero request timeout disables it",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("0s")),
+				BackendRequest: ptr.To(gatewayv1.Duration("10s")),
+			},
+		},
+		{
+			name:        "timeout longer than the data plane accepts",
+			timeouts:    &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptr.To(gatewayv1.Duration("9999h"))},
+			unsupported: true,
+		},
+		{
+			name: "backendRequest longer than request",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("1s")),
+				BackendRequest: ptr.To(gatewayv1.Duration("2s")),
+			},
+			unsupported: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHTTPRouteTimeouts(tc.timeouts)
+			if !tc.unsupported {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, types.IsSomeReasonError(err, gatewayv1.RouteReasonUnsupportedValue), "unexpected error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestValidateHTTPRouteRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		retry       *gatewayv1.HTTPRouteRetry
+		unsupported bool
+	}{
+		{
+			name: "no retry",
+		},
+		{
+			name:  "attempts",
+			retry: &gatewayv1.HTTPRouteRetry{Attempts: ptr.To(3)},
+		},
+		{
+			name:        "codes",
+			retry:       &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}, Attempts: ptr.To(3)},
+			unsupported: true,
+		},
+		{
+			name:        "backoff",
+			retry:       &gatewayv1.HTTPRouteRetry{Backoff: ptr.To(gatewayv1.Duration("100ms"))},
+			unsupported: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHTTPRouteRetry(tc.retry)
+			if !tc.unsupported {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, types.IsSomeReasonError(err, gatewayv1.RouteReasonUnsupportedValue), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestPartiallyAcceptedMessage(t *testing.T) {
+	assert.Empty(t, partiallyAcceptedMessage(requestMirrorsNote(nil)))
+	assert.Empty(t, partiallyAcceptedMessage(requestMirrorsNote([]int{0, 1, 1})))
+
+	msg := partiallyAcceptedMessage(requestMirrorsNote([]int{2, 1, 3}))
+	assert.Contains(t, msg, "Route is accepted")
+	assert.Contains(t, msg, "3 RequestMirror filters are ignored")
+
+	rules := []gatewayv1.HTTPRouteRule{{
+		Filters: []gatewayv1.HTTPRouteFilter{{
+			Type: gatewayv1.HTTPRouteFilterExternalAuth,
+			ExternalAuth: &gatewayv1.HTTPExternalAuthFilter{
+				ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+				ForwardBody:          &gatewayv1.ForwardBodyConfig{MaxSize: 1024},
+			},
+		}},
+	}}
+	msg = partiallyAcceptedMessage(requestMirrorsNote([]int{2}), externalAuthForwardBodyNote(rules))
+	assert.Contains(t, msg, "1 RequestMirror filters are ignored")
+	assert.Contains(t, msg, "forwardBody.maxSize is not enforced", "both notes should be reported")
+}
+
+func TestValidateHTTPRouteExternalAuth(t *testing.T) {
+	assert.NoError(t, validateHTTPRouteExternalAuth(&gatewayv1.HTTPExternalAuthFilter{
+		ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+		HTTPAuthConfig:       &gatewayv1.HTTPAuthConfig{AllowedRequestHeaders: []string{"X-Tenant"}},
+	}))
+	assert.NoError(t, validateHTTPRouteExternalAuth(&gatewayv1.HTTPExternalAuthFilter{
+		ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+		ForwardBody:          &gatewayv1.ForwardBodyConfig{MaxSize: 1024},
+	}), "a body size cap should not fail the route")
+
+	for name, extAuth := range map[string]*gatewayv1.HTTPExternalAuthFilter{
+		"grpc": {ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthGRPCProtocol},
+		"path": {
+			ExternalAuthProtocol: gatewayv1.HTTPRouteExternalAuthHTTPProtocol,
+			HTTPAuthConfig:       &gatewayv1.HTTPAuthConfig{Path: "/verify"},
+		},
+	} {
+		err := validateHTTPRouteExternalAuth(extAuth)
+		assert.True(t, types.IsSomeReasonError(err, gatewayv1.RouteReasonUnsupportedValue), "%s: unexpected error: %v", name, err)
+	}
+}
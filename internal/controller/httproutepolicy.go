@@ -20,15 +20,19 @@ package controller
 import (
 	"cmp"
 	"context"
+	"fmt"
 	"slices"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
@@ -37,7 +41,12 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
 
-func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateContext, httpRoute *gatewayv1.HTTPRoute) error {
+func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateContext, httpRoute *gatewayv1.HTTPRoute, gateways []RouteParentRefContext) error {
+	sources, err := r.processHTTPRoutePolicyDefaults(tctx, newRouteGateways(gateways))
+	if err != nil {
+		return err
+	}
+
 	// list HTTPRoutePolicies, which sectionName is not specified
 	var (
 		list v1alpha1.HTTPRoutePolicyList
@@ -61,6 +70,9 @@ func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateC
 		parentRefs[i] = ref
 	}
 
+	// an older policy takes precedence for the plugins that several policies configure
+	slices.SortFunc(list.Items, compareHTTPRoutePolicies)
+
 	var conflicts = make(map[types.NamespacedName]v1alpha1.HTTPRoutePolicy)
 	for _, rule := range httpRoute.Spec.Rules {
 		var policies = findPoliciesWhichTargetRefTheRule(rule.Name, internaltypes.KindHTTPRoute, list)
@@ -72,9 +84,9 @@ func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateC
 		}
 	}
 
-	for i := range list.Items {
+	var conditions = make(map[types.NamespacedName]metav1.Condition)
+	for _, policy := range list.Items {
 		var (
-			policy         = list.Items[i]
 			namespacedName = types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.GetName()}
 			condition      metav1.Condition
 		)
@@ -82,9 +94,34 @@ func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateC
 			condition.Status = metav1.ConditionFalse
 			condition.Reason = string(gatewayv1.PolicyReasonConflicted)
 			condition.Message = "HTTPRoutePolicy conflict with others target to the HTTPRoute"
+		} else if err := r.processHTTPRoutePolicyPluginConfig(tctx, &policy); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(gatewayv1.PolicyReasonInvalid)
+			condition.Message = err.Error()
 		} else {
 			tctx.HTTPRoutePolicies = append(tctx.HTTPRoutePolicies, policy)
 		}
+		conditions[namespacedName] = condition
+	}
+
+	for namespacedName, overrides := range httpRoutePolicyPluginOverrides(tctx, httpRoute) {
+		condition := conditions[namespacedName]
+		condition.Message = "plugins overridden: " + strings.Join(overrides, "; ")
+		conditions[namespacedName] = condition
+	}
+	for namespacedName, defaults := range httpRoutePolicyInheritedDefaults(tctx, httpRoute) {
+		condition := conditions[namespacedName]
+		condition.Message = strings.Join(slices.DeleteFunc([]string{condition.Message, describeDefaults(defaults, sources)}, func(message string) bool {
+			return message == ""
+		}), "; ")
+		conditions[namespacedName] = condition
+	}
+
+	for i := range list.Items {
+		var (
+			policy    = list.Items[i]
+			condition = conditions[types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.GetName()}]
+		)
 
 		if updated := setAncestorsForHTTPRoutePolicyStatus(parentRefs, &policy, condition); updated {
 			tctx.StatusUpdaters = append(tctx.StatusUpdaters, status.Update{
@@ -102,6 +139,264 @@ func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateC
 	return nil
 }
 
+// processHTTPRoutePolicyDefaults merges the HTTPRoutePolicies that target the Gateways of the
+// route into tctx.HTTPRoutePolicyDefaults, and returns the policy that supplies each default.
+// The plugins of the PluginConfig that a policy references are merged after its own.
+func (r *HTTPRouteReconciler) processHTTPRoutePolicyDefaults(tctx *provider.TranslateContext, gateways routeGateways) (map[string]string, error) {
+	var policies []*v1alpha1.HTTPRoutePolicy
+	for _, key := range gateways.indexKeys() {
+		var list v1alpha1.HTTPRoutePolicyList
+		if err := r.List(context.Background(), &list, client.MatchingFields{indexer.PolicyTargetRefs: key}); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	policies = defaultsPolicies(gateways, policies, func(policy *v1alpha1.HTTPRoutePolicy) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+		return policy.Spec.TargetRefs
+	})
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	var (
+		defaults = &v1alpha1.HTTPRoutePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: policies[0].Namespace, Name: policies[0].Name},
+		}
+		sources = make(map[string]string)
+	)
+	for _, policy := range policies {
+		var (
+			namespacedName = utils.NamespacedName(policy)
+			source         = "HTTPRoutePolicy " + namespacedName.String()
+			condition      metav1.Condition
+		)
+		if err := r.processHTTPRoutePolicyPluginConfig(tctx, policy); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(gatewayv1.PolicyReasonInvalid)
+			condition.Message = err.Error()
+		} else {
+			if defaults.Spec.Priority == nil && policy.Spec.Priority != nil {
+				defaults.Spec.Priority = ptr.To(*policy.Spec.Priority)
+				sources["priority"] = source
+			}
+			if len(defaults.Spec.Vars) == 0 && len(policy.Spec.Vars) > 0 {
+				defaults.Spec.Vars = slices.Clone(policy.Spec.Vars)
+				sources["vars"] = source
+			}
+			plugins := policy.Spec.Plugins
+			if ref := policy.Spec.PluginConfigRef; ref != nil {
+				plugins = append(slices.Clone(plugins), tctx.PluginConfigs[types.NamespacedName{Namespace: policy.Namespace, Name: ref.Name}].Spec.Plugins...)
+			}
+			for _, plugin := range fillDefaultPlugins(&defaults.Spec.Plugins, plugins) {
+				sources[plugin] = source
+			}
+		}
+
+		if updated := setAncestorsForHTTPRoutePolicyStatus(gateways.ancestorRefs(policy.Namespace, policy.Spec.TargetRefs), policy, condition); updated {
+			tctx.StatusUpdaters = append(tctx.StatusUpdaters, status.Update{
+				NamespacedName: namespacedName,
+				Resource:       policy.DeepCopy(),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					cp := obj.(*v1alpha1.HTTPRoutePolicy).DeepCopy()
+					cp.Status = policy.Status
+					return cp
+				}),
+			})
+		}
+	}
+	tctx.HTTPRoutePolicyDefaults = defaults
+	return sources, nil
+}
+
+// httpRoutePolicyInheritedDefaults lists, for each accepted policy, the defaults that the rules
+// it targets inherit: those that neither the policies of the rule nor its filters configure.
+func httpRoutePolicyInheritedDefaults(tctx *provider.TranslateContext, httpRoute *gatewayv1.HTTPRoute) map[types.NamespacedName][]string {
+	defaults := tctx.HTTPRoutePolicyDefaults
+	if defaults == nil {
+		return nil
+	}
+
+	var inherited = make(map[types.NamespacedName][]string)
+	for _, rule := range httpRoute.Spec.Rules {
+		policies := findPoliciesWhichTargetRefTheRule(rule.Name, internaltypes.KindHTTPRoute, v1alpha1.HTTPRoutePolicyList{Items: tctx.HTTPRoutePolicies})
+		if len(policies) == 0 {
+			continue
+		}
+
+		var (
+			fields     []string
+			configured = make(map[string]bool)
+		)
+		for _, filter := range rule.Filters {
+			_, plugins := httpRouteFilterPlugins(tctx, httpRoute.GetNamespace(), filter)
+			for _, plugin := range plugins {
+				configured[plugin] = true
+			}
+		}
+		for _, policy := range policies {
+			for _, plugin := range httpRoutePolicyPlugins(tctx, &policy) {
+				configured[plugin] = true
+			}
+		}
+		if defaults.Spec.Priority != nil && !slices.ContainsFunc(policies, func(policy v1alpha1.HTTPRoutePolicy) bool {
+			return policy.Spec.Priority != nil
+		}) {
+			fields = append(fields, "priority")
+		}
+		if len(defaults.Spec.Vars) > 0 && !slices.ContainsFunc(policies, func(policy v1alpha1.HTTPRoutePolicy) bool {
+			return len(policy.Spec.Vars) > 0
+		}) {
+			fields = append(fields, "vars")
+		}
+		for _, plugin := range defaults.Spec.Plugins {
+			if !configured[plugin.Name] {
+				fields = append(fields, "plugin "+plugin.Name)
+			}
+		}
+
+		for _, policy := range policies {
+			namespacedName := utils.NamespacedName(&policy)
+			inherited[namespacedName] = append(inherited[namespacedName], fields...)
+		}
+	}
+
+	for namespacedName, fields := range inherited {
+		slices.Sort(fields)
+		inherited[namespacedName] = slices.Compact(fields)
+	}
+	return inherited
+}
+
+// processHTTPRoutePolicyPluginConfig loads the PluginConfig that the policy references.
+func (r *HTTPRouteReconciler) processHTTPRoutePolicyPluginConfig(tctx *provider.TranslateContext, policy *v1alpha1.HTTPRoutePolicy) error {
+	if policy.Spec.PluginConfigRef == nil {
+		return nil
+	}
+	var (
+		pluginConfig   v1alpha1.PluginConfig
+		namespacedName = types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.Spec.PluginConfigRef.Name}
+	)
+	if err := r.Get(context.Background(), namespacedName, &pluginConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("PluginConfig %s not found", namespacedName)
+		}
+		return err
+	}
+	tctx.PluginConfigs[namespacedName] = &pluginConfig
+	return nil
+}
+
+// httpRoutePolicyPluginOverrides lists, for each accepted policy, the plugins it configures
+// that something else takes precedence over: a filter of the rule, including an ExtensionRef
+// PluginConfig, or an older policy. A plugin that a GatewayProxy also configures globally is
+// listed as well, since the global one runs too.
+func httpRoutePolicyPluginOverrides(tctx *provider.TranslateContext, httpRoute *gatewayv1.HTTPRoute) map[types.NamespacedName][]string {
+	var (
+		overrides = make(map[types.NamespacedName][]string)
+		global    = make(map[string]string)
+	)
+	for _, gatewayProxy := range tctx.GatewayProxies {
+		for _, plugin := range gatewayProxy.Spec.Plugins {
+			if plugin.Enabled {
+				global[plugin.Name] = "GatewayProxy " + utils.NamespacedName(&gatewayProxy).String()
+			}
+		}
+	}
+
+	for _, rule := range httpRoute.Spec.Rules {
+		// owners maps each plugin of the rule to the source that configures it
+		var owners = make(map[string]string)
+		for _, filter := range rule.Filters {
+			source, plugins := httpRouteFilterPlugins(tctx, httpRoute.GetNamespace(), filter)
+			for _, plugin := range plugins {
+				if _, ok := owners[plugin]; !ok {
+					owners[plugin] = source
+				}
+			}
+		}
+
+		policies := findPoliciesWhichTargetRefTheRule(rule.Name, internaltypes.KindHTTPRoute, v1alpha1.HTTPRoutePolicyList{Items: tctx.HTTPRoutePolicies})
+		for _, policy := range policies {
+			var (
+				namespacedName = utils.NamespacedName(&policy)
+				source         = "HTTPRoutePolicy " + namespacedName.String()
+			)
+			for _, plugin := range httpRoutePolicyPlugins(tctx, &policy) {
+				if owner, ok := owners[plugin]; !ok {
+					owners[plugin] = source
+				} else if owner != source {
+					overrides[namespacedName] = append(overrides[namespacedName], fmt.Sprintf("%s by %s", plugin, owner))
+				}
+				if gatewayProxy, ok := global[plugin]; ok {
+					overrides[namespacedName] = append(overrides[namespacedName], fmt.Sprintf("%s also runs as a global plugin of %s", plugin, gatewayProxy))
+				}
+			}
+		}
+	}
+
+	for namespacedName, messages := range overrides {
+		slices.Sort(messages)
+		overrides[namespacedName] = slices.Compact(messages)
+	}
+	return overrides
+}
+
+// httpRouteFilterPlugins returns a description of the filter and the plugins it configures.
+func httpRouteFilterPlugins(tctx *provider.TranslateContext, namespace string, filter gatewayv1.HTTPRouteFilter) (string, []string) {
+	source := fmt.Sprintf("the %s filter", filter.Type)
+	switch filter.Type {
+	case gatewayv1.HTTPRouteFilterRequestHeaderModifier, gatewayv1.HTTPRouteFilterURLRewrite:
+		return source, []string{adctypes.PluginProxyRewrite}
+	case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+		return source, []string{adctypes.PluginResponseRewrite}
+	case gatewayv1.HTTPRouteFilterRequestRedirect:
+		return source, []string{adctypes.PluginRedirect}
+	case gatewayv1.HTTPRouteFilterRequestMirror:
+		return source, []string{adctypes.PluginProxyMirror}
+	case gatewayv1.HTTPRouteFilterCORS:
+		return source, []string{adctypes.PluginCORS}
+	case gatewayv1.HTTPRouteFilterExternalAuth:
+		return source, []string{adctypes.PluginForwardAuth}
+	case gatewayv1.HTTPRouteFilterExtensionRef:
+		if filter.ExtensionRef == nil || filter.ExtensionRef.Kind != internaltypes.KindPluginConfig {
+			return source, nil
+		}
+		namespacedName := types.NamespacedName{Namespace: namespace, Name: string(filter.ExtensionRef.Name)}
+		return "PluginConfig " + namespacedName.String(), pluginConfigPluginNames(tctx.PluginConfigs[namespacedName])
+	}
+	return source, nil
+}
+
+// httpRoutePolicyPlugins returns the plugins that the policy configures.
+func httpRoutePolicyPlugins(tctx *provider.TranslateContext, policy *v1alpha1.HTTPRoutePolicy) []string {
+	var plugins []string
+	for _, plugin := range policy.Spec.Plugins {
+		plugins = append(plugins, plugin.Name)
+	}
+	if ref := policy.Spec.PluginConfigRef; ref != nil {
+		plugins = append(plugins, pluginConfigPluginNames(tctx.PluginConfigs[types.NamespacedName{Namespace: policy.GetNamespace(), Name: ref.Name}])...)
+	}
+	return plugins
+}
+
+func pluginConfigPluginNames(pluginConfig *v1alpha1.PluginConfig) []string {
+	if pluginConfig == nil {
+		return nil
+	}
+	var plugins []string
+	for _, plugin := range pluginConfig.Spec.Plugins {
+		plugins = append(plugins, plugin.Name)
+	}
+	return plugins
+}
+
+// compareHTTPRoutePolicies orders policies oldest first, then by namespace and name.
+func compareHTTPRoutePolicies(a, b v1alpha1.HTTPRoutePolicy) int {
+	return comparePolicies(&a, &b)
+}
+
 func (r *HTTPRouteReconciler) updateHTTPRoutePolicyStatusOnDeleting(ctx context.Context, nn types.NamespacedName) error {
 	var (
 		list v1alpha1.HTTPRoutePolicyList
@@ -230,18 +525,18 @@ func setAncestorsForHTTPRoutePolicyStatus(parentRefs []gatewayv1.ParentReference
 }
 
 // checkPoliciesConflict determines if there is a conflict among the given HTTPRoutePolicy objects based on their priority values.
-// It returns true if any policy has a different priority than the first policy in the list, otherwise false.
-// An empty or single-element policies slice is considered non-conflicting.
-// The function assumes all policies have a valid Spec.Priority field for comparison.
+// It returns true if two policies set different priorities, otherwise false.
+// Policies without a priority, such as those that only configure plugins, never conflict.
 func checkPoliciesConflict(policies []v1alpha1.HTTPRoutePolicy) bool {
-	if len(policies) == 0 {
-		return false
-	}
-	priority := policies[0].Spec.Priority
+	var priority *int64
 	for _, policy := range policies {
-		if !ptr.Equal(policy.Spec.Priority, priority) {
+		if policy.Spec.Priority == nil {
+			continue
+		}
+		if priority != nil && *priority != *policy.Spec.Priority {
 			return true
 		}
+		priority = policy.Spec.Priority
 	}
 	return false
 }
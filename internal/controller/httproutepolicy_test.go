@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestHTTPRoutePolicyPluginOverrides(t *testing.T) {
+	var (
+		created = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		policy  = func(name string, created metav1.Time, section string, plugins ...string) v1alpha1.HTTPRoutePolicy {
+			policy := v1alpha1.HTTPRoutePolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, CreationTimestamp: created},
+				Spec: v1alpha1.HTTPRoutePolicySpec{
+					TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Kind: internaltypes.KindHTTPRoute, Name: "httpbin"},
+					}},
+				},
+			}
+			if section != "" {
+				policy.Spec.TargetRefs[0].SectionName = ptr.To(gatewayv1.SectionName(section))
+			}
+			for _, plugin := range plugins {
+				policy.Spec.Plugins = append(policy.Spec.Plugins, v1alpha1.Plugin{Name: plugin})
+			}
+			return policy
+		}
+	)
+
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.PluginConfigs[types.NamespacedName{Namespace: "default", Name: "auth"}] = &v1alpha1.PluginConfig{
+		Spec: v1alpha1.PluginConfigSpec{Plugins: []v1alpha1.Plugin{{Name: "key-auth"}}},
+	}
+	tctx.GatewayProxies[internaltypes.NamespacedNameKind{Namespace: "default", Name: "apisix", Kind: internaltypes.KindGatewayProxy}] = v1alpha1.GatewayProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"},
+		Spec: v1alpha1.GatewayProxySpec{Plugins: []v1alpha1.GatewayProxyPlugin{
+			{Name: "prometheus", Enabled: true},
+			{Name: "cors", Enabled: false},
+		}},
+	}
+	tctx.HTTPRoutePolicies = []v1alpha1.HTTPRoutePolicy{
+		policy("newer", metav1.NewTime(created.Add(time.Hour)), "", "limit-count", "cors"),
+		policy("older", created, "", "limit-count"),
+		policy("auth", created, "api", "key-auth", "prometheus", "proxy-rewrite"),
+	}
+	slices.SortFunc(tctx.HTTPRoutePolicies, compareHTTPRoutePolicies)
+	require.Equal(t, []string{"auth", "older", "newer"}, []string{
+		tctx.HTTPRoutePolicies[0].Name, tctx.HTTPRoutePolicies[1].Name, tctx.HTTPRoutePolicies[2].Name,
+	})
+
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Name: ptr.To(gatewayv1.SectionName("api")),
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{
+						Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+						URLRewrite: &gatewayv1.HTTPURLRewriteFilter{},
+					},
+					{
+						Type:         gatewayv1.HTTPRouteFilterExtensionRef,
+						ExtensionRef: &gatewayv1.LocalObjectReference{Kind: internaltypes.KindPluginConfig, Name: "auth"},
+					},
+				},
+			},
+			{Name: ptr.To(gatewayv1.SectionName("web"))},
+		}},
+	}
+
+	require.Equal(t, map[types.NamespacedName][]string{
+		{Namespace: "default", Name: "newer"}: {"limit-count by HTTPRoutePolicy default/older"},
+		{Namespace: "default", Name: "auth"}: {
+			"key-auth by PluginConfig default/auth",
+			"prometheus also runs as a global plugin of GatewayProxy default/apisix",
+			"proxy-rewrite by the URLRewrite filter",
+		},
+	}, httpRoutePolicyPluginOverrides(tctx, httpRoute))
+}
+
+func TestCheckPoliciesConflict(t *testing.T) {
+	policy := func(priority *int64) v1alpha1.HTTPRoutePolicy {
+		return v1alpha1.HTTPRoutePolicy{Spec: v1alpha1.HTTPRoutePolicySpec{Priority: priority}}
+	}
+	for _, tc := range []struct {
+		name     string
+		policies []v1alpha1.HTTPRoutePolicy
+		conflict bool
+	}{
+		{name: "none"},
+		{name: "same priority", policies: []v1alpha1.HTTPRoutePolicy{policy(ptr.To[int64](1)), policy(ptr.To[int64](1))}},
+		{name: "without priority", policies: []v1alpha1.HTTPRoutePolicy{policy(nil), policy(ptr.To[int64](1)), policy(nil)}},
+		{name: "different priorities", policies: []v1alpha1.HTTPRoutePolicy{policy(ptr.To[int64](1)), policy(nil), policy(ptr.To[int64](2))}, conflict: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.conflict, checkPoliciesConflict(tc.policies))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package indexer
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func setupBackendTLSPolicyIndexer(mgr Manager) error {
+	var indexers = map[string]func(client.Object) []string{
+		PolicyTargetRefs:  BackendTLSPolicyIndexFunc,
+		ConfigMapIndexRef: BackendTLSPolicyConfigMapIndexFunc,
+		SecretIndexRef:    BackendTLSPolicySecretIndexFunc,
+	}
+	for key, f := range indexers {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1.BackendTLSPolicy{}, key, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackendTLSPolicyIndexFunc indexes BackendTLSPolicies by the Services they target.
+func BackendTLSPolicyIndexFunc(rawObj client.Object) []string {
+	policy := rawObj.(*gatewayv1.BackendTLSPolicy)
+	keys := make([]string, 0, len(policy.Spec.TargetRefs))
+	m := make(map[string]struct{})
+	for _, ref := range policy.Spec.TargetRefs {
+		key := GenIndexKeyWithGK(string(ref.Group), string(ref.Kind), policy.GetNamespace(), string(ref.Name))
+		if _, ok := m[key]; !ok {
+			m[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// BackendTLSPolicyConfigMapIndexFunc indexes BackendTLSPolicies by the CA ConfigMaps
+// referenced via spec.validation.caCertificateRefs.
+func BackendTLSPolicyConfigMapIndexFunc(rawObj client.Object) []string {
+	return backendTLSPolicyCACertificateKeys(rawObj.(*gatewayv1.BackendTLSPolicy), internaltypes.KindConfigMap)
+}
+
+// BackendTLSPolicySecretIndexFunc indexes BackendTLSPolicies by the CA Secrets
+// referenced via spec.validation.caCertificateRefs.
+func BackendTLSPolicySecretIndexFunc(rawObj client.Object) []string {
+	return backendTLSPolicyCACertificateKeys(rawObj.(*gatewayv1.BackendTLSPolicy), internaltypes.KindSecret)
+}
+
+func backendTLSPolicyCACertificateKeys(policy *gatewayv1.BackendTLSPolicy, kind string) (keys []string) {
+	m := make(map[string]struct{})
+	for _, ref := range policy.Spec.Validation.CACertificateRefs {
+		if ref.Group != "" || string(ref.Kind) != kind {
+			continue
+		}
+		// caCertificateRefs are local references, they always live in the policy namespace.
+		key := GenIndexKey(policy.GetNamespace(), string(ref.Name))
+		if _, ok := m[key]; !ok {
+			m[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
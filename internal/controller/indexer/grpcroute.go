@@ -20,14 +20,13 @@ package indexer
 import (
 	"context"
 
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
-func setupGRPCRouteIndexer(mgr ctrl.Manager) error {
+func setupGRPCRouteIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1.GRPCRoute{},
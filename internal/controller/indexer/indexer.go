@@ -20,6 +20,8 @@ package indexer
 import (
 	"cmp"
 	"context"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -45,36 +47,63 @@ const (
 	ExtensionRef              = "extensionRef"
 	ParametersRef             = "parametersRef"
 	ParentRefs                = "parentRefs"
+	ParentRefKinds            = "parentRefKinds"
 	IngressClass              = "ingressClass"
 	SecretIndexRef            = "secretRefs"
 	ConfigMapIndexRef         = "configMapRefs"
 	IngressClassRef           = "ingressClassRef"
 	IngressClassParametersRef = "ingressClassParametersRef"
 	ConsumerGatewayRef        = "consumerGatewayRef"
+	ConsumerGroupRef          = "consumerGroupRef"
 	PolicyTargetRefs          = "targetRefs"
 	TLSHostIndexRef           = "tlsHostRefs"
 	GatewayClassIndexRef      = "gatewayClassRef"
 	ApisixUpstreamRef         = "apisixUpstreamRef"
 	PluginConfigIndexRef      = "pluginConfigRefs"
 	ControllerName            = "controllerName"
+	PublishServiceIndexRef    = "publishServiceRef"
 )
 
+// Manager is what the indexes are set up with: a ctrl.Manager, or whatever stands in for
+// one where no manager runs.
+type Manager interface {
+	GetFieldIndexer() client.FieldIndexer
+	GetClient() client.Client
+}
+
 func SetupIndexer(mgr ctrl.Manager) error {
+	return setupIndexers(mgr, func(resource client.Object) (bool, error) {
+		return utils.HasAPIResource(mgr, resource)
+	})
+}
+
+// SetupOfflineIndexer sets up the indexes of every API on mgr, as on a cluster that serves
+// all of them.
+func SetupOfflineIndexer(mgr Manager) error {
+	return setupIndexers(mgr, func(client.Object) (bool, error) {
+		return true, nil
+	})
+}
+
+// setupIndexers sets up the indexes of the APIs served reports the cluster serves.
+func setupIndexers(mgr Manager, served func(resource client.Object) (bool, error)) error {
 	setupLog := ctrl.LoggerFrom(context.Background()).WithName("indexer-setup")
 
 	// Gateway API indexers - conditional setup based on API availability
 	if !config.ControllerConfig.DisableGatewayAPI {
-		for resource, setup := range map[client.Object]func(ctrl.Manager) error{
-			&gatewayv1.Gateway{}:        setupGatewayIndexer,
-			&gatewayv1.HTTPRoute{}:      setupHTTPRouteIndexer,
-			&gatewayv1.GRPCRoute{}:      setupGRPCRouteIndexer,
-			&gatewayv1alpha2.TCPRoute{}: setupTCPRouteIndexer,
-			&gatewayv1alpha2.UDPRoute{}: setupUDPRouteIndexer,
-			&gatewayv1alpha2.TLSRoute{}: setupTLSRouteIndexer,
-			&gatewayv1.GatewayClass{}:   setupGatewayClassIndexer,
-			&v1alpha1.Consumer{}:        setupConsumerIndexer,
+		for resource, setup := range map[client.Object]func(Manager) error{
+			&gatewayv1.Gateway{}:          setupGatewayIndexer,
+			&gatewayv1.HTTPRoute{}:        setupHTTPRouteIndexer,
+			&gatewayv1.GRPCRoute{}:        setupGRPCRouteIndexer,
+			&gatewayv1alpha2.TCPRoute{}:   setupTCPRouteIndexer,
+			&gatewayv1alpha2.UDPRoute{}:   setupUDPRouteIndexer,
+			&gatewayv1alpha2.TLSRoute{}:   setupTLSRouteIndexer,
+			&gatewayv1.GatewayClass{}:     setupGatewayClassIndexer,
+			&gatewayv1.BackendTLSPolicy{}: setupBackendTLSPolicyIndexer,
+			&gatewayv1.ListenerSet{}:      setupListenerSetIndexer,
+			&v1alpha1.Consumer{}:          setupConsumerIndexer,
 		} {
-			installed, err := utils.HasAPIResource(mgr, resource)
+			installed, err := served(resource)
 			if err != nil {
 				return err
 			}
@@ -88,7 +117,7 @@ func SetupIndexer(mgr ctrl.Manager) error {
 		}
 	}
 
-	for resource, setup := range map[client.Object]func(ctrl.Manager) error{
+	for resource, setup := range map[client.Object]func(Manager) error{
 		&networkingv1.Ingress{}:           setupIngressIndexer,
 		&networkingv1.IngressClass{}:      setupIngressClassIndexer,
 		&networkingv1beta1.IngressClass{}: setupIngressClassV1beta1Indexer,
@@ -96,7 +125,7 @@ func SetupIndexer(mgr ctrl.Manager) error {
 		&v1alpha1.HTTPRoutePolicy{}:       setHTTPRoutePolicyIndexer,
 		&v1alpha1.L4RoutePolicy{}:         setupL4RoutePolicyIndexer,
 	} {
-		installed, err := utils.HasAPIResource(mgr, resource)
+		installed, err := served(resource)
 		if err != nil {
 			return err
 		}
@@ -110,7 +139,7 @@ func SetupIndexer(mgr ctrl.Manager) error {
 	}
 
 	// Core Kubernetes and APISIX indexers - always setup these
-	for _, setup := range []func(ctrl.Manager) error{
+	for _, setup := range []func(Manager) error{
 		setupGatewayProxyIndexer,
 		setupApisixRouteIndexer,
 		setupApisixPluginConfigIndexer,
@@ -125,7 +154,7 @@ func SetupIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupGatewayIndexer(mgr ctrl.Manager) error {
+func setupGatewayIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1.Gateway{},
@@ -176,7 +205,7 @@ func setupGatewayIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupConsumerIndexer(mgr ctrl.Manager) error {
+func setupConsumerIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.Consumer{},
@@ -193,10 +222,18 @@ func setupConsumerIndexer(mgr ctrl.Manager) error {
 	); err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.Consumer{},
+		ConsumerGroupRef,
+		ConsumerGroupRefIndexFunc,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
-func setupApisixRouteIndexer(mgr ctrl.Manager) error {
+func setupApisixRouteIndexer(mgr Manager) error {
 	var indexers = map[string]func(client.Object) []string{
 		ServiceIndexRef:      ApisixRouteServiceIndexFunc(mgr.GetClient()),
 		SecretIndexRef:       ApisixRouteSecretIndexFunc(mgr.GetClient()),
@@ -212,7 +249,7 @@ func setupApisixRouteIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupApisixPluginConfigIndexer(mgr ctrl.Manager) error {
+func setupApisixPluginConfigIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&apiv2.ApisixPluginConfig{},
@@ -224,7 +261,7 @@ func setupApisixPluginConfigIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupApisixConsumerIndexer(mgr ctrl.Manager) error {
+func setupApisixConsumerIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&apiv2.ApisixConsumer{},
@@ -233,6 +270,14 @@ func setupApisixConsumerIndexer(mgr ctrl.Manager) error {
 	); err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&apiv2.ApisixConsumer{},
+		ConsumerGroupRef,
+		ApisixConsumerGroupRefIndexFunc,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -268,7 +313,15 @@ func ConsumerGatewayRefIndexFunc(rawObj client.Object) []string {
 	return []string{GenIndexKey(ns, consumer.Spec.GatewayRef.Name)}
 }
 
-func setupHTTPRouteIndexer(mgr ctrl.Manager) error {
+func ConsumerGroupRefIndexFunc(rawObj client.Object) []string {
+	consumer := rawObj.(*v1alpha1.Consumer)
+	if consumer.Spec.GroupRef == nil || consumer.Spec.GroupRef.Name == "" {
+		return nil
+	}
+	return []string{GenIndexKey(consumer.GetNamespace(), consumer.Spec.GroupRef.Name)}
+}
+
+func setupHTTPRouteIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1.HTTPRoute{},
@@ -278,6 +331,15 @@ func setupHTTPRouteIndexer(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&gatewayv1.HTTPRoute{},
+		ParentRefKinds,
+		HTTPRouteParentRefKindsIndexFunc,
+	); err != nil {
+		return err
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1.HTTPRoute{},
@@ -298,7 +360,7 @@ func setupHTTPRouteIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setHTTPRoutePolicyIndexer(mgr ctrl.Manager) error {
+func setHTTPRoutePolicyIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.HTTPRoutePolicy{},
@@ -307,10 +369,18 @@ func setHTTPRoutePolicyIndexer(mgr ctrl.Manager) error {
 	); err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.HTTPRoutePolicy{},
+		PluginConfigIndexRef,
+		HTTPRoutePolicyPluginConfigIndexFunc,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
-func setupTCPRouteIndexer(mgr ctrl.Manager) error {
+func setupTCPRouteIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1alpha2.TCPRoute{},
@@ -331,7 +401,7 @@ func setupTCPRouteIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupUDPRouteIndexer(mgr ctrl.Manager) error {
+func setupUDPRouteIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1alpha2.UDPRoute{},
@@ -352,7 +422,7 @@ func setupUDPRouteIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupIngressClassIndexer(mgr ctrl.Manager) error {
+func setupIngressClassIndexer(mgr Manager) error {
 	// create IngressClass index
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -376,7 +446,7 @@ func setupIngressClassIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupIngressClassV1beta1Indexer(mgr ctrl.Manager) error {
+func setupIngressClassV1beta1Indexer(mgr Manager) error {
 	// create IngressClass index
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -400,7 +470,7 @@ func setupIngressClassV1beta1Indexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupGatewayProxyIndexer(mgr ctrl.Manager) error {
+func setupGatewayProxyIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.GatewayProxy{},
@@ -418,10 +488,19 @@ func setupGatewayProxyIndexer(mgr ctrl.Manager) error {
 	); err != nil {
 		return err
 	}
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.GatewayProxy{},
+		PublishServiceIndexRef,
+		GatewayProxyPublishServiceIndexFunc,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
-func setupGatewayClassIndexer(mgr ctrl.Manager) error {
+func setupGatewayClassIndexer(mgr Manager) error {
 	return mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1.GatewayClass{},
@@ -443,6 +522,20 @@ func GatewayProxyServiceIndexFunc(rawObj client.Object) []string {
 	return nil
 }
 
+// GatewayProxyPublishServiceIndexFunc indexes a GatewayProxy by its PublishService,
+// which defaults to the namespace of the GatewayProxy.
+func GatewayProxyPublishServiceIndexFunc(rawObj client.Object) []string {
+	gatewayProxy := rawObj.(*v1alpha1.GatewayProxy)
+	if gatewayProxy.Spec.PublishService == "" {
+		return nil
+	}
+	namespace, name, found := strings.Cut(gatewayProxy.Spec.PublishService, "/")
+	if !found {
+		namespace, name = "", namespace
+	}
+	return []string{GenIndexKey(cmp.Or(namespace, gatewayProxy.GetNamespace()), name)}
+}
+
 func GatewayProxySecretIndexFunc(rawObj client.Object) []string {
 	gatewayProxy := rawObj.(*v1alpha1.GatewayProxy)
 	secretKeys := make([]string, 0)
@@ -464,7 +557,7 @@ func GatewayProxySecretIndexFunc(rawObj client.Object) []string {
 	return secretKeys
 }
 
-func setupIngressIndexer(mgr ctrl.Manager) error {
+func setupIngressIndexer(mgr Manager) error {
 	// create IngressClass index
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -516,7 +609,7 @@ func setupIngressIndexer(mgr ctrl.Manager) error {
 	return nil
 }
 
-func setupBackendTrafficPolicyIndexer(mgr ctrl.Manager) error {
+func setupBackendTrafficPolicyIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.BackendTrafficPolicy{},
@@ -525,6 +618,14 @@ func setupBackendTrafficPolicyIndexer(mgr ctrl.Manager) error {
 	); err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1alpha1.BackendTrafficPolicy{},
+		SecretIndexRef,
+		BackendTrafficPolicySecretIndexFunc,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -537,7 +638,7 @@ func IngressClassV1beta1IndexFunc(rawObj client.Object) []string {
 	return []string{controllerName}
 }
 
-func setupL4RoutePolicyIndexer(mgr ctrl.Manager) error {
+func setupL4RoutePolicyIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&v1alpha1.L4RoutePolicy{},
@@ -703,6 +804,11 @@ func GenIndexKeyWithGK(group, kind, namespace, name string) string {
 	return gvk.String() + "/" + nsName.String()
 }
 
+// GenGroupKindKey returns the key of a group and kind, such as the kind of a parent.
+func GenGroupKindKey(group, kind string) string {
+	return schema.GroupKind{Group: group, Kind: kind}.String()
+}
+
 func GenIndexKey(namespace, name string) string {
 	return client.ObjectKey{
 		Namespace: namespace,
@@ -723,6 +829,27 @@ func HTTPRouteParentRefsIndexFunc(rawObj client.Object) []string {
 	return keys
 }
 
+// HTTPRouteParentRefKindsIndexFunc indexes an HTTPRoute by the group and kind of its
+// parents, to list the routes attached to Services without listing them all.
+func HTTPRouteParentRefKindsIndexFunc(rawObj client.Object) []string {
+	hr := rawObj.(*gatewayv1.HTTPRoute)
+	keys := make([]string, 0, len(hr.Spec.ParentRefs))
+	for _, ref := range hr.Spec.ParentRefs {
+		group := gatewayv1.GroupName
+		if ref.Group != nil {
+			group = string(*ref.Group)
+		}
+		kind := internaltypes.KindGateway
+		if ref.Kind != nil {
+			kind = string(*ref.Kind)
+		}
+		if key := GenGroupKindKey(group, kind); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 func TCPRouteParentRefsIndexFunc(rawObj client.Object) []string {
 	tr := rawObj.(*gatewayv1alpha2.TCPRoute)
 	keys := make([]string, 0, len(tr.Spec.ParentRefs))
@@ -763,6 +890,21 @@ func HTTPRouteServiceIndexFunc(rawObj client.Object) []string {
 			}
 			keys = append(keys, GenIndexKey(namespace, string(backend.Name)))
 		}
+		// the Services of ExternalAuth filters are resolved like backends
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1.HTTPRouteFilterExternalAuth || filter.ExternalAuth == nil {
+				continue
+			}
+			backend := filter.ExternalAuth.BackendRef
+			if backend.Kind != nil && *backend.Kind != internaltypes.KindService {
+				continue
+			}
+			namespace := hr.GetNamespace()
+			if backend.Namespace != nil {
+				namespace = string(*backend.Namespace)
+			}
+			keys = append(keys, GenIndexKey(namespace, string(backend.Name)))
+		}
 	}
 	return keys
 }
@@ -947,6 +1089,14 @@ func HTTPRoutePolicyIndexFunc(rawObj client.Object) []string {
 	return keys
 }
 
+func HTTPRoutePolicyPluginConfigIndexFunc(rawObj client.Object) []string {
+	hrp := rawObj.(*v1alpha1.HTTPRoutePolicy)
+	if hrp.Spec.PluginConfigRef == nil {
+		return nil
+	}
+	return []string{GenIndexKey(hrp.GetNamespace(), hrp.Spec.PluginConfigRef.Name)}
+}
+
 func GatewayParametersRefIndexFunc(rawObj client.Object) []string {
 	gw := rawObj.(*gatewayv1.Gateway)
 	if gw.Spec.Infrastructure != nil && gw.Spec.Infrastructure.ParametersRef != nil {
@@ -975,6 +1125,23 @@ func BackendTrafficPolicyIndexFunc(rawObj client.Object) []string {
 	return keys
 }
 
+// BackendTrafficPolicySecretIndexFunc indexes a BackendTrafficPolicy by the Secrets that its
+// TLS references, so that rotating them reconciles the routes of its backends.
+func BackendTrafficPolicySecretIndexFunc(rawObj client.Object) []string {
+	btp := rawObj.(*v1alpha1.BackendTrafficPolicy)
+	if btp.Spec.TLS == nil {
+		return nil
+	}
+	var keys []string
+	if ref := btp.Spec.TLS.SecretRef; ref != nil {
+		keys = append(keys, GenIndexKey(btp.GetNamespace(), ref.Name))
+	}
+	if ref := btp.Spec.TLS.CACertificateRef; ref != nil {
+		keys = append(keys, GenIndexKey(btp.GetNamespace(), ref.Name))
+	}
+	return keys
+}
+
 func L4RoutePolicyIndexFunc(rawObj client.Object) []string {
 	lrp := rawObj.(*v1alpha1.L4RoutePolicy)
 	keys := make([]string, 0, len(lrp.Spec.TargetRefs))
@@ -1054,7 +1221,15 @@ func ApisixConsumerSecretIndexFunc(rawObj client.Object) (keys []string) {
 	return
 }
 
-func setupApisixTlsIndexer(mgr ctrl.Manager) error {
+func ApisixConsumerGroupRefIndexFunc(rawObj client.Object) []string {
+	ac := rawObj.(*apiv2.ApisixConsumer)
+	if ac.Spec.GroupRef == nil || ac.Spec.GroupRef.Name == "" {
+		return nil
+	}
+	return []string{GenIndexKey(ac.GetNamespace(), ac.Spec.GroupRef.Name)}
+}
+
+func setupApisixTlsIndexer(mgr Manager) error {
 	// Create secret index for ApisixTls
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -1112,7 +1287,7 @@ func ApisixTlsIngressClassIndexFunc(rawObj client.Object) []string {
 	return []string{tls.Spec.IngressClassName}
 }
 
-func setupApisixGlobalRuleIndexer(mgr ctrl.Manager) error {
+func setupApisixGlobalRuleIndexer(mgr Manager) error {
 	// Create secret index for ApisixGlobalRule
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package indexer
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func setupListenerSetIndexer(mgr Manager) error {
+	var indexers = map[string]func(client.Object) []string{
+		ParentRefs:     ListenerSetParentRefIndexFunc,
+		SecretIndexRef: ListenerSetSecretIndexFunc,
+	}
+	for key, f := range indexers {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1.ListenerSet{}, key, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListenerSetParentRefIndexFunc indexes ListenerSets by the Gateway they attach to.
+func ListenerSetParentRefIndexFunc(rawObj client.Object) []string {
+	ls := rawObj.(*gatewayv1.ListenerSet)
+	ref := ls.Spec.ParentRef
+	if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+		return nil
+	}
+	if ref.Kind != nil && *ref.Kind != internaltypes.KindGateway {
+		return nil
+	}
+	namespace := ls.GetNamespace()
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	return []string{GenIndexKey(namespace, string(ref.Name))}
+}
+
+// ListenerSetSecretIndexFunc indexes ListenerSets by the certificate Secrets of their listeners.
+func ListenerSetSecretIndexFunc(rawObj client.Object) (keys []string) {
+	ls := rawObj.(*gatewayv1.ListenerSet)
+	m := make(map[string]struct{})
+	for _, listener := range ls.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			// An unset kind defaults to Secret.
+			if ref.Kind != nil && *ref.Kind != internaltypes.KindSecret {
+				continue
+			}
+			namespace := ls.GetNamespace()
+			if ref.Namespace != nil {
+				namespace = string(*ref.Namespace)
+			}
+			key := GenIndexKey(namespace, string(ref.Name))
+			if _, ok := m[key]; !ok {
+				m[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
@@ -20,14 +20,13 @@ package indexer
 import (
 	"context"
 
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
-func setupTLSRouteIndexer(mgr ctrl.Manager) error {
+func setupTLSRouteIndexer(mgr Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
 		&gatewayv1alpha2.TLSRoute{},
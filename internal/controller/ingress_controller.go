@@ -121,6 +121,9 @@ func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				BackendTrafficPolicyPredicateFunc(r.genericEvent),
 			),
 		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(backendTrafficPolicySecretMapFunc(r.Client, r.Log, r.listIngressForBackendTrafficPolicy)),
+		).
 		Watches(&v1alpha1.HTTPRoutePolicy{},
 			handler.EnqueueRequestsFromMapFunc(r.listIngressesByHTTPRoutePolicy),
 			builder.WithPredicates(httpRoutePolicyPredicateFuncs(r.genericEvent)),
@@ -218,7 +221,7 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		r.Log.Error(err, "failed to process HTTPRoutePolicy", "ingress", ingress.Name)
 	}
 
-	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
+	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx, nil)
 
 	// update the ingress resources
 	if err := r.Provider.Update(ctx, tctx, ingress); err != nil {
@@ -466,6 +469,9 @@ func (r *IngressReconciler) listIngressForBackendTrafficPolicy(ctx context.Conte
 	var namespacedNameMap = make(map[types.NamespacedName]struct{})
 	ingresses := []networkingv1.Ingress{}
 	for _, ref := range v.Spec.TargetRefs {
+		if string(ref.Kind) != internaltypes.KindService {
+			continue
+		}
 		service := &corev1.Service{}
 		if err := r.Get(ctx, client.ObjectKey{
 			Namespace: v.Namespace,
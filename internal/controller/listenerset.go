@@ -0,0 +1,469 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// listenerSetListenerKey identifies a listener of a ListenerSet.
+type listenerSetListenerKey struct {
+	types.NamespacedName
+	Listener gatewayv1.SectionName
+}
+
+// gatewayListenerSets are the ListenerSets referencing a Gateway and the outcome
+// of merging their listeners into it.
+type gatewayListenerSets struct {
+	// Items are the ListenerSets whose parentRef is the Gateway, in precedence
+	// order: oldest first, then by namespace and name.
+	Items []*gatewayv1.ListenerSet
+	// NotAllowed holds the ListenerSets the Gateway allowedListeners rejects.
+	NotAllowed map[types.NamespacedName]bool
+	// Conflicts holds the listeners rejected because they conflict with a
+	// listener of the Gateway or of a ListenerSet with higher precedence.
+	Conflicts map[listenerSetListenerKey]gatewayv1.ListenerEntryConditionReason
+}
+
+// resolveGatewayListenerSets lists the ListenerSets attached to the Gateway,
+// applies its allowedListeners and resolves listener conflicts.
+func resolveGatewayListenerSets(ctx context.Context, c client.Client, gateway *gatewayv1.Gateway) (*gatewayListenerSets, error) {
+	var list gatewayv1.ListenerSetList
+	if err := c.List(ctx, &list, client.MatchingFields{
+		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list listenersets for gateway %s: %w", utils.NamespacedName(gateway), err)
+	}
+	result := &gatewayListenerSets{
+		NotAllowed: make(map[types.NamespacedName]bool),
+	}
+	for i := range list.Items {
+		result.Items = append(result.Items, &list.Items[i])
+	}
+	sortListenerSets(result.Items)
+
+	allowed := make([]*gatewayv1.ListenerSet, 0, len(result.Items))
+	for _, ls := range result.Items {
+		ok, err := gatewayAllowsListenerSet(ctx, c, gateway, ls)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			result.NotAllowed[utils.NamespacedName(ls)] = true
+			continue
+		}
+		allowed = append(allowed, ls)
+	}
+	result.Conflicts = resolveListenerSetConflicts(gateway, allowed)
+	return result, nil
+}
+
+// acceptedListeners returns the listeners of the ListenerSet that the Gateway
+// accepted, none when the Gateway does not allow the ListenerSet.
+func (s *gatewayListenerSets) acceptedListeners(ls *gatewayv1.ListenerSet) []gatewayv1.Listener {
+	nn := utils.NamespacedName(ls)
+	if s.NotAllowed[nn] {
+		return nil
+	}
+	listeners := make([]gatewayv1.Listener, 0, len(ls.Spec.Listeners))
+	for _, listener := range utils.ListenerSetListeners(ls) {
+		if _, conflicted := s.Conflicts[listenerSetListenerKey{NamespacedName: nn, Listener: listener.Name}]; conflicted {
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// sortListenerSets orders ListenerSets by precedence: the oldest first, ties
+// broken by namespace and name.
+func sortListenerSets(sets []*gatewayv1.ListenerSet) {
+	sort.SliceStable(sets, func(i, j int) bool {
+		ti, tj := sets[i].CreationTimestamp, sets[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return utils.NamespacedName(sets[i]).String() < utils.NamespacedName(sets[j]).String()
+	})
+}
+
+// listenerSetParentGateway returns the Gateway the ListenerSet attaches to. It
+// reports false when the parentRef is not a Gateway.
+func listenerSetParentGateway(ls *gatewayv1.ListenerSet) (types.NamespacedName, bool) {
+	ref := ls.Spec.ParentRef
+	if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+		return types.NamespacedName{}, false
+	}
+	if ref.Kind != nil && *ref.Kind != KindGateway {
+		return types.NamespacedName{}, false
+	}
+	namespace := ls.Namespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}, true
+}
+
+// gatewayAllowsListenerSet reports whether the Gateway allowedListeners admits
+// the ListenerSet. A Gateway without allowedListeners admits none.
+func gatewayAllowsListenerSet(ctx context.Context, c client.Client, gateway *gatewayv1.Gateway, ls *gatewayv1.ListenerSet) (bool, error) {
+	if gateway.Spec.AllowedListeners == nil || gateway.Spec.AllowedListeners.Namespaces == nil ||
+		gateway.Spec.AllowedListeners.Namespaces.From == nil {
+		return false, nil
+	}
+	namespaces := gateway.Spec.AllowedListeners.Namespaces
+	switch *namespaces.From {
+	case gatewayv1.NamespacesFromAll:
+		return true, nil
+	case gatewayv1.NamespacesFromSame:
+		return ls.Namespace == gateway.Namespace, nil
+	case gatewayv1.NamespacesFromSelector:
+		if namespaces.Selector == nil {
+			return false, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(namespaces.Selector)
+		if err != nil {
+			return false, nil
+		}
+		var namespace corev1.Namespace
+		if err := c.Get(ctx, client.ObjectKey{Name: ls.Namespace}, &namespace); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return selector.Matches(labels.Set(namespace.Labels)), nil
+	}
+	return false, nil
+}
+
+// listenerProtocolFamily groups the protocols that can share a port: HTTPS and
+// TLS listeners are told apart by SNI, the others need a port of their own.
+func listenerProtocolFamily(protocol gatewayv1.ProtocolType) string {
+	switch protocol {
+	case gatewayv1.HTTPSProtocolType, gatewayv1.TLSProtocolType:
+		return "tls"
+	}
+	return string(protocol)
+}
+
+// resolveListenerSetConflicts returns the ListenerSet listeners that conflict
+// with a listener of higher precedence: the Gateway listeners come first, then
+// the ListenerSets in the given order. A listener on a port taken by another
+// protocol family is a ProtocolConflict, one with the port and hostname of an
+// earlier listener is a HostnameConflict.
+func resolveListenerSetConflicts(gateway *gatewayv1.Gateway, sets []*gatewayv1.ListenerSet) map[listenerSetListenerKey]gatewayv1.ListenerEntryConditionReason {
+	type hostKey struct {
+		port     gatewayv1.PortNumber
+		hostname gatewayv1.Hostname
+	}
+	families := make(map[gatewayv1.PortNumber]string)
+	hosts := make(map[hostKey]struct{})
+	add := func(listener gatewayv1.Listener) {
+		families[listener.Port] = listenerProtocolFamily(listener.Protocol)
+		hosts[hostKey{port: listener.Port, hostname: hostnameOrEmpty(listener.Hostname)}] = struct{}{}
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		if _, ok := families[listener.Port]; !ok {
+			add(listener)
+			continue
+		}
+		hosts[hostKey{port: listener.Port, hostname: hostnameOrEmpty(listener.Hostname)}] = struct{}{}
+	}
+
+	conflicts := make(map[listenerSetListenerKey]gatewayv1.ListenerEntryConditionReason)
+	for _, ls := range sets {
+		nn := utils.NamespacedName(ls)
+		for _, listener := range utils.ListenerSetListeners(ls) {
+			key := listenerSetListenerKey{NamespacedName: nn, Listener: listener.Name}
+			if family, ok := families[listener.Port]; ok && family != listenerProtocolFamily(listener.Protocol) {
+				conflicts[key] = gatewayv1.ListenerEntryReasonProtocolConflict
+				continue
+			}
+			if _, ok := hosts[hostKey{port: listener.Port, hostname: hostnameOrEmpty(listener.Hostname)}]; ok {
+				conflicts[key] = gatewayv1.ListenerEntryReasonHostnameConflict
+				continue
+			}
+			add(listener)
+		}
+	}
+	return conflicts
+}
+
+func hostnameOrEmpty(hostname *gatewayv1.Hostname) gatewayv1.Hostname {
+	if hostname == nil {
+		return ""
+	}
+	return *hostname
+}
+
+// getListenerSetStatus computes the status of a ListenerSet attached to the
+// Gateway. It reports whether the ListenerSet is accepted.
+func getListenerSetStatus(
+	ctx context.Context,
+	c client.Client,
+	gateway *gatewayv1.Gateway,
+	ls *gatewayv1.ListenerSet,
+	attached *gatewayListenerSets,
+	gatewayAccepted bool,
+) (gatewayv1.ListenerSetStatus, bool, error) {
+	nn := utils.NamespacedName(ls)
+	generation := ls.GetGeneration()
+	newCondition := func(conditionType, reason string, ok bool, message string) metav1.Condition {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+			LastTransitionTime: metav1.Now(),
+		}
+		if !ok {
+			condition.Status = metav1.ConditionFalse
+		}
+		return condition
+	}
+
+	status := gatewayv1.ListenerSetStatus{}
+	validListeners := 0
+	for _, listener := range utils.ListenerSetListeners(ls) {
+		entry, valid, err := getListenerSetListenerStatus(ctx, c, ls, listener, attached, newCondition)
+		if err != nil {
+			return status, false, err
+		}
+		if valid {
+			validListeners++
+		}
+		status.Listeners = append(status.Listeners, reuseUnchangedListenerEntryStatus(ls, entry))
+	}
+
+	accepted := newCondition(string(gatewayv1.ListenerSetConditionAccepted), string(gatewayv1.ListenerSetReasonAccepted), true, acceptedMessage("listenerset"))
+	switch {
+	case attached.NotAllowed[nn]:
+		accepted = newCondition(string(gatewayv1.ListenerSetConditionAccepted), string(gatewayv1.ListenerSetReasonNotAllowed), false,
+			fmt.Sprintf("gateway %s does not allow this listenerset", utils.NamespacedName(gateway)))
+	case !gatewayAccepted:
+		accepted = newCondition(string(gatewayv1.ListenerSetConditionAccepted), string(gatewayv1.ListenerSetReasonParentNotAccepted), false,
+			fmt.Sprintf("gateway %s is not accepted", utils.NamespacedName(gateway)))
+	case validListeners == 0:
+		accepted = newCondition(string(gatewayv1.ListenerSetConditionAccepted), string(gatewayv1.ListenerSetReasonListenersNotValid), false,
+			"none of the listeners are valid")
+	}
+	programmed := newCondition(string(gatewayv1.ListenerSetConditionProgrammed), string(gatewayv1.ListenerSetReasonProgrammed), true, "")
+	if accepted.Status != metav1.ConditionTrue {
+		programmed = newCondition(string(gatewayv1.ListenerSetConditionProgrammed), string(gatewayv1.ListenerSetReasonInvalid), false, accepted.Message)
+	}
+	for _, condition := range []metav1.Condition{accepted, programmed} {
+		if IsConditionPresentAndEqual(ls.Status.Conditions, condition) {
+			status.Conditions = append(status.Conditions, *meta.FindStatusCondition(ls.Status.Conditions, condition.Type))
+			continue
+		}
+		status.Conditions = append(status.Conditions, condition)
+	}
+	return status, accepted.Status == metav1.ConditionTrue, nil
+}
+
+// getListenerSetListenerStatus computes the status of one listener of a
+// ListenerSet. It reports whether the listener is valid.
+func getListenerSetListenerStatus(
+	ctx context.Context,
+	c client.Client,
+	ls *gatewayv1.ListenerSet,
+	listener gatewayv1.Listener,
+	attached *gatewayListenerSets,
+	newCondition func(conditionType, reason string, ok bool, message string) metav1.Condition,
+) (gatewayv1.ListenerEntryStatus, bool, error) {
+	nn := utils.NamespacedName(ls)
+	var (
+		accepted = newCondition(string(gatewayv1.ListenerEntryConditionAccepted),
+			string(gatewayv1.ListenerEntryReasonAccepted), true, "")
+		conflicted = newCondition(string(gatewayv1.ListenerEntryConditionConflicted),
+			string(gatewayv1.ListenerReasonNoConflicts), false, "")
+		resolvedRefs = newCondition(string(gatewayv1.ListenerEntryConditionResolvedRefs),
+			string(gatewayv1.ListenerEntryReasonResolvedRefs), true, "")
+		programmed = newCondition(string(gatewayv1.ListenerEntryConditionProgrammed),
+			string(gatewayv1.ListenerEntryReasonProgrammed), true, "")
+	)
+
+	supportedKinds, validKinds := listenerSupportedKinds(listener)
+	if !validKinds {
+		resolvedRefs = newCondition(string(gatewayv1.ListenerEntryConditionResolvedRefs),
+			string(gatewayv1.ListenerEntryReasonInvalidRouteKinds), false, "allowedRoutes contains kinds the listener cannot serve")
+	}
+	if reason, message := validateListenerSetCertificateRefs(ctx, c, ls, listener); reason != "" {
+		resolvedRefs = newCondition(string(gatewayv1.ListenerEntryConditionResolvedRefs), string(reason), false, message)
+		programmed = newCondition(string(gatewayv1.ListenerEntryConditionProgrammed),
+			string(gatewayv1.ListenerEntryReasonInvalid), false, message)
+	}
+
+	switch reason, ok := attached.Conflicts[listenerSetListenerKey{NamespacedName: nn, Listener: listener.Name}]; {
+	case attached.NotAllowed[nn]:
+		accepted = newCondition(string(gatewayv1.ListenerEntryConditionAccepted),
+			string(gatewayv1.ListenerSetReasonNotAllowed), false, "the gateway does not allow this listenerset")
+		programmed = newCondition(string(gatewayv1.ListenerEntryConditionProgrammed),
+			string(gatewayv1.ListenerEntryReasonInvalid), false, accepted.Message)
+	case ok:
+		message := fmt.Sprintf("listener conflicts with another listener on port %d", listener.Port)
+		accepted = newCondition(string(gatewayv1.ListenerEntryConditionAccepted), string(reason), false, message)
+		conflicted = newCondition(string(gatewayv1.ListenerEntryConditionConflicted), string(reason), true, message)
+		programmed = newCondition(string(gatewayv1.ListenerEntryConditionProgrammed),
+			string(gatewayv1.ListenerEntryReasonInvalid), false, message)
+	}
+
+	var attachedRoutes int32
+	if accepted.Status == metav1.ConditionTrue {
+		var err error
+		if attachedRoutes, err = getAttachedRoutesForListenerSetListener(ctx, c, ls, listener); err != nil {
+			return gatewayv1.ListenerEntryStatus{}, false, err
+		}
+	}
+	return gatewayv1.ListenerEntryStatus{
+		Name:           listener.Name,
+		SupportedKinds: supportedKinds,
+		AttachedRoutes: attachedRoutes,
+		Conditions:     []metav1.Condition{programmed, accepted, conflicted, resolvedRefs},
+	}, accepted.Status == metav1.ConditionTrue, nil
+}
+
+// validateListenerSetCertificateRefs checks the certificateRefs of a ListenerSet
+// listener, returning the ResolvedRefs reason and message of the first invalid one.
+func validateListenerSetCertificateRefs(ctx context.Context, c client.Client, ls *gatewayv1.ListenerSet, listener gatewayv1.Listener) (gatewayv1.ListenerEntryConditionReason, string) {
+	if listener.TLS == nil {
+		return "", ""
+	}
+	for _, ref := range listener.TLS.CertificateRefs {
+		if ref.Group != nil && *ref.Group != corev1.GroupName {
+			return gatewayv1.ListenerEntryReasonInvalidCertificateRef, fmt.Sprintf(`Invalid Group, expect "", got "%s"`, *ref.Group)
+		}
+		if ref.Kind != nil && *ref.Kind != KindSecret {
+			return gatewayv1.ListenerEntryReasonInvalidCertificateRef, fmt.Sprintf(`Invalid Kind, expect "Secret", got "%s"`, *ref.Kind)
+		}
+		if !checkReferenceGrant(ctx, c,
+			v1beta1.ReferenceGrantFrom{
+				Group:     gatewayv1.GroupName,
+				Kind:      KindListenerSet,
+				Namespace: v1beta1.Namespace(ls.Namespace),
+			},
+			gatewayv1.ObjectReference{
+				Group:     corev1.GroupName,
+				Kind:      KindSecret,
+				Name:      ref.Name,
+				Namespace: ref.Namespace,
+			},
+		) {
+			return gatewayv1.ListenerEntryReasonRefNotPermitted, "certificateRefs cross namespaces is not permitted"
+		}
+		namespace := ls.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		var secret corev1.Secret
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: string(ref.Name)}, &secret); err != nil {
+			return gatewayv1.ListenerEntryReasonInvalidCertificateRef, err.Error()
+		}
+		if cause, ok := isTLSSecretValid(&secret); !ok {
+			return gatewayv1.ListenerEntryReasonInvalidCertificateRef, fmt.Sprintf("Malformed Secret referenced: %s", cause)
+		}
+	}
+	return "", ""
+}
+
+// getAttachedRoutesForListenerSetListener counts the routes attached to a
+// listener of a ListenerSet.
+func getAttachedRoutesForListenerSetListener(ctx context.Context, c client.Client, ls *gatewayv1.ListenerSet, listener gatewayv1.Listener) (int32, error) {
+	var attachedRoutes int32
+	for _, rl := range routeListsForListener(listener) {
+		if err := c.List(ctx, rl, client.MatchingFields{
+			indexer.ParentRefs: indexer.GenIndexKey(ls.Namespace, ls.Name),
+		}); err != nil {
+			return 0, fmt.Errorf("failed to list %T: %w", rl, err)
+		}
+		for _, route := range internaltypes.NewRouteListAdapter(rl) {
+			if !checkStatusParentOfKind(route.GetParentStatuses(), route.GetNamespace(), KindListenerSet, ls.Namespace, ls.Name) {
+				continue
+			}
+			for _, parentRef := range route.GetParentRefs() {
+				if !parentRefTargets(parentRef, route.GetNamespace(), KindListenerSet, ls.Namespace, ls.Name) {
+					continue
+				}
+				ok, _, err := checkRouteAcceptedByListener(ctx, c, route.GetObject(), ls, listener, parentRef)
+				if err != nil {
+					return 0, err
+				}
+				if ok {
+					attachedRoutes++
+				}
+			}
+		}
+	}
+	return attachedRoutes, nil
+}
+
+// reuseUnchangedListenerEntryStatus keeps the previously published listener
+// status when nothing but the condition timestamps would change.
+func reuseUnchangedListenerEntryStatus(ls *gatewayv1.ListenerSet, status gatewayv1.ListenerEntryStatus) gatewayv1.ListenerEntryStatus {
+	for _, previous := range ls.Status.Listeners {
+		if previous.Name != status.Name {
+			continue
+		}
+		if previous.AttachedRoutes != status.AttachedRoutes || len(previous.Conditions) != len(status.Conditions) {
+			return status
+		}
+		for _, condition := range status.Conditions {
+			if !IsConditionPresentAndEqual(previous.Conditions, condition) {
+				return status
+			}
+		}
+		return previous
+	}
+	return status
+}
+
+// routeParentIndexKeys returns the ParentRefs index keys of the routes affected by a
+// change to a Gateway or a ListenerSet: the object itself and, for a Gateway, the
+// ListenerSets attached to it when the ListenerSet API is installed.
+func routeParentIndexKeys(ctx context.Context, c client.Client, log logr.Logger, obj client.Object, withListenerSets bool) []string {
+	keys := []string{indexer.GenIndexKey(obj.GetNamespace(), obj.GetName())}
+	if _, ok := obj.(*gatewayv1.Gateway); !ok || !withListenerSets {
+		return keys
+	}
+	var list gatewayv1.ListenerSetList
+	if err := c.List(ctx, &list, client.MatchingFields{
+		indexer.ParentRefs: keys[0],
+	}); err != nil {
+		log.Error(err, "failed to list listenersets for gateway", "gateway", utils.NamespacedName(obj))
+		return keys
+	}
+	for _, ls := range list.Items {
+		keys = append(keys, indexer.GenIndexKey(ls.Namespace, ls.Name))
+	}
+	return keys
+}
@@ -0,0 +1,168 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+)
+
+func newListenerSet(namespace, name string, listeners ...gatewayv1.ListenerEntry) *gatewayv1.ListenerSet {
+	return &gatewayv1.ListenerSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: gatewayv1.ListenerSetSpec{
+			ParentRef: gatewayv1.ParentGatewayReference{
+				Name:      "gw",
+				Namespace: ptr.To(gatewayv1.Namespace("default")),
+			},
+			Listeners: listeners,
+		},
+	}
+}
+
+func TestResolveListenerSetConflicts(t *testing.T) {
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("foo.example.com"))},
+			},
+		},
+	}
+	first := newListenerSet("default", "first",
+		gatewayv1.ListenerEntry{Name: "same-host", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("foo.example.com"))},
+		gatewayv1.ListenerEntry{Name: "tcp", Port: 80, Protocol: gatewayv1.TCPProtocolType},
+		gatewayv1.ListenerEntry{Name: "other-host", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("bar.example.com"))},
+		gatewayv1.ListenerEntry{Name: "tls", Port: 443, Protocol: gatewayv1.TLSProtocolType, Hostname: ptr.To(gatewayv1.Hostname("tls.example.com"))},
+	)
+	second := newListenerSet("default", "second",
+		gatewayv1.ListenerEntry{Name: "taken", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("bar.example.com"))},
+		gatewayv1.ListenerEntry{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: ptr.To(gatewayv1.Hostname("web.example.com"))},
+	)
+
+	conflicts := resolveListenerSetConflicts(gw, []*gatewayv1.ListenerSet{first, second})
+
+	key := func(ls *gatewayv1.ListenerSet, name gatewayv1.SectionName) listenerSetListenerKey {
+		return listenerSetListenerKey{NamespacedName: types.NamespacedName{Namespace: ls.Namespace, Name: ls.Name}, Listener: name}
+	}
+	assert.Equal(t, map[listenerSetListenerKey]gatewayv1.ListenerEntryConditionReason{
+		key(first, "same-host"): gatewayv1.ListenerEntryReasonHostnameConflict,
+		key(first, "tcp"):       gatewayv1.ListenerEntryReasonProtocolConflict,
+		key(second, "taken"):    gatewayv1.ListenerEntryReasonHostnameConflict,
+	}, conflicts, "HTTPS and TLS share a port, earlier listeners win hostname conflicts")
+}
+
+func TestGatewayAllowsListenerSet(t *testing.T) {
+	scheme := parentRefTestScheme(t)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"listeners": "allowed"}},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).Build()
+
+	sameNs := newListenerSet("default", "ls")
+	otherNs := newListenerSet("team", "ls")
+	for _, tc := range []struct {
+		name    string
+		allowed *gatewayv1.AllowedListeners
+		ls      *gatewayv1.ListenerSet
+		want    bool
+	}{
+		{name: "unset", ls: sameNs, want: false},
+		{name: "none", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{From: ptr.To(gatewayv1.NamespacesFromNone)}}, ls: sameNs, want: false},
+		{name: "same", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)}}, ls: sameNs, want: true},
+		{name: "same-other-namespace", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)}}, ls: otherNs, want: false},
+		{name: "all", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{From: ptr.To(gatewayv1.NamespacesFromAll)}}, ls: otherNs, want: true},
+		{name: "selector-match", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{
+			From:     ptr.To(gatewayv1.NamespacesFromSelector),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"listeners": "allowed"}},
+		}}, ls: otherNs, want: true},
+		{name: "selector-missing-namespace", allowed: &gatewayv1.AllowedListeners{Namespaces: &gatewayv1.ListenerNamespaces{
+			From:     ptr.To(gatewayv1.NamespacesFromSelector),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"listeners": "allowed"}},
+		}}, ls: newListenerSet("missing", "ls"), want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+				Spec:       gatewayv1.GatewaySpec{AllowedListeners: tc.allowed},
+			}
+			got, err := gatewayAllowsListenerSet(context.Background(), cli, gw, tc.ls)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestParseRouteParentRefs_ListenerSet verifies a route attaches to a listener of
+// a ListenerSet, and that the parent status refers to the ListenerSet.
+func TestParseRouteParentRefs_ListenerSet(t *testing.T) {
+	scheme := parentRefTestScheme(t)
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "apisix",
+			AllowedListeners: &gatewayv1.AllowedListeners{
+				Namespaces: &gatewayv1.ListenerNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)},
+			},
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("gw.example.com"))},
+			},
+		},
+	}
+	ls := newListenerSet("default", "ls",
+		gatewayv1.ListenerEntry{Name: "web", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("ls.example.com"))},
+	)
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "r"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"ls.example.com"},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newParentRefGatewayClass(), gw, ls).
+		WithIndex(&gatewayv1.ListenerSet{}, indexer.ParentRefs, indexer.ListenerSetParentRefIndexFunc).
+		Build()
+
+	got, err := ParseRouteParentRefs(context.Background(), cli, logr.Discard(), route,
+		[]gatewayv1.ParentReference{{Kind: ptr.To(gatewayv1.Kind(KindListenerSet)), Name: "ls"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, metav1.ConditionTrue, got[0].Conditions[0].Status)
+	require.NotNil(t, got[0].ListenerSet)
+	assert.Equal(t, "ls", got[0].ListenerSet.Name)
+	require.Len(t, got[0].Listeners, 1)
+	assert.Equal(t, gatewayv1.SectionName("web"), got[0].Listeners[0].Name)
+
+	var parentStatus gatewayv1.RouteParentStatus
+	SetRouteParentRefFromContext(&parentStatus, got[0])
+	assert.Equal(t, gatewayv1.Kind(KindListenerSet), ptr.Deref(parentStatus.ParentRef.Kind, ""))
+	assert.Equal(t, gatewayv1.ObjectName("ls"), parentStatus.ParentRef.Name)
+}
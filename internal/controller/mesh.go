@@ -0,0 +1,158 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// isServiceParentRef reports whether a parentRef attaches the route to a Service,
+// as a mesh (GAMMA) route.
+func isServiceParentRef(parentRef gatewayv1.ParentReference) bool {
+	return parentRef.Group != nil && *parentRef.Group == corev1.GroupName &&
+		parentRef.Kind != nil && *parentRef.Kind == KindService
+}
+
+// ParseRouteServiceParentRefs returns the Services a route attaches to as a mesh
+// route. Service parentRefs are only handled when mesh.gateway_proxy is
+// configured, and, like Gateways that do not exist, missing Services are skipped.
+func ParseRouteServiceParentRefs(ctx context.Context, mgrc client.Client, route client.Object, parentRefs []gatewayv1.ParentReference) ([]RouteParentRefContext, error) {
+	if _, ok := config.GetMeshGatewayProxy(); !ok {
+		return nil, nil
+	}
+	var parents []RouteParentRefContext
+	for _, parentRef := range parentRefs {
+		if !isServiceParentRef(parentRef) {
+			continue
+		}
+		namespace := route.GetNamespace()
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		var service corev1.Service
+		if err := mgrc.Get(ctx, client.ObjectKey{Namespace: namespace, Name: string(parentRef.Name)}, &service); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, fmt.Errorf("failed to retrieve service for route: %w", err)
+		}
+
+		condition := metav1.Condition{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayv1.RouteReasonAccepted),
+			ObservedGeneration: route.GetGeneration(),
+		}
+		if parentRef.Port != nil && !slices.ContainsFunc(service.Spec.Ports, func(port corev1.ServicePort) bool {
+			return port.Port == int32(*parentRef.Port)
+		}) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(gatewayv1.RouteReasonNoMatchingParent)
+			condition.Message = fmt.Sprintf("port %d is not a port of Service %s/%s", *parentRef.Port, namespace, parentRef.Name)
+		}
+		parents = append(parents, RouteParentRefContext{
+			Service:     &service,
+			ServicePort: parentRef.Port,
+			Conditions:  []metav1.Condition{condition},
+		})
+	}
+	return parents, nil
+}
+
+// rejectMixedMeshParents refuses the Service parents of a route also attached to
+// Gateways: the route is translated once, either for the Gateways or the mesh.
+func rejectMixedMeshParents(parents []RouteParentRefContext, generation int64) {
+	for i := range parents {
+		parents[i].Conditions = []metav1.Condition{{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(gatewayv1.RouteReasonUnsupportedValue),
+			Message:            "a route cannot be attached to both Gateways and Services",
+			ObservedGeneration: generation,
+		}}
+	}
+}
+
+// meshRouteHostnames returns the hostnames a mesh route is matched on: the cluster
+// DNS names and cluster IPs of the Services it accepted.
+func meshRouteHostnames(parents []RouteParentRefContext) []gatewayv1.Hostname {
+	var hostnames []gatewayv1.Hostname
+	for _, parent := range parents {
+		if !isRouteAccepted([]RouteParentRefContext{parent}) {
+			continue
+		}
+		svc := parent.Service
+		for _, hostname := range []string{
+			svc.Name,
+			svc.Name + "." + svc.Namespace,
+			svc.Name + "." + svc.Namespace + ".svc",
+			svc.Name + "." + svc.Namespace + ".svc.cluster.local",
+		} {
+			hostnames = append(hostnames, gatewayv1.Hostname(hostname))
+		}
+		for _, ip := range svc.Spec.ClusterIPs {
+			parsed := net.ParseIP(ip)
+			if parsed == nil {
+				// headless Services have no cluster IP
+				continue
+			}
+			if parsed.To4() == nil {
+				ip = "[" + ip + "]"
+			}
+			hostnames = append(hostnames, gatewayv1.Hostname(ip))
+		}
+	}
+	slices.Sort(hostnames)
+	return slices.Compact(hostnames)
+}
+
+// ProcessMeshGatewayProxy registers the GatewayProxy configured for mesh routes
+// as the data plane the resource is programmed into.
+func ProcessMeshGatewayProxy(r client.Client, log logr.Logger, tctx *provider.TranslateContext, rk types.NamespacedNameKind) error {
+	key, ok := config.GetMeshGatewayProxy()
+	if !ok {
+		return nil
+	}
+	gatewayProxy := &v1alpha1.GatewayProxy{}
+	if err := r.Get(tctx, key, gatewayProxy); err != nil {
+		log.Error(err, "failed to get mesh GatewayProxy", "gatewayproxy", key)
+		return fmt.Errorf("failed to get mesh GatewayProxy %s: %w", key, err)
+	}
+	gatewayProxyKind := types.NamespacedNameKind{
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Kind:      KindGatewayProxy,
+	}
+	tctx.GatewayProxies[gatewayProxyKind] = *gatewayProxy
+	tctx.ResourceParentRefs[rk] = append(tctx.ResourceParentRefs[rk], gatewayProxyKind)
+	return processGatewayProxyProvider(r, log, tctx, gatewayProxy)
+}
@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+)
+
+func serviceParentRef(name string, port *gatewayv1.PortNumber) gatewayv1.ParentReference {
+	return gatewayv1.ParentReference{
+		Group: ptr.To(gatewayv1.Group("")),
+		Kind:  ptr.To(gatewayv1.Kind(KindService)),
+		Name:  gatewayv1.ObjectName(name),
+		Port:  port,
+	}
+}
+
+func TestParseRouteServiceParentRefs(t *testing.T) {
+	scheme := parentRefTestScheme(t)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "echo"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:  "10.96.0.10",
+			ClusterIPs: []string{"10.96.0.10", "fd00::10"},
+			Ports:      []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "r", Generation: 2},
+	}
+	parentRefs := []gatewayv1.ParentReference{
+		{Name: "gw"},
+		serviceParentRef("echo", ptr.To(gatewayv1.PortNumber(8080))),
+		serviceParentRef("echo", ptr.To(gatewayv1.PortNumber(9090))),
+		serviceParentRef("missing", nil),
+	}
+
+	previous := config.ControllerConfig.Mesh
+	t.Cleanup(func() { config.ControllerConfig.Mesh = previous })
+
+	config.ControllerConfig.Mesh = nil
+	got, err := ParseRouteServiceParentRefs(context.Background(), cli, route, parentRefs)
+	require.NoError(t, err)
+	assert.Empty(t, got, "Service parentRefs are ignored until a mesh GatewayProxy is configured")
+
+	config.ControllerConfig.Mesh = &config.MeshConfig{GatewayProxy: "apisix/mesh"}
+	got, err = ParseRouteServiceParentRefs(context.Background(), cli, route, parentRefs)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "the Gateway and the missing Service are skipped")
+
+	assert.Equal(t, metav1.ConditionTrue, got[0].Conditions[0].Status)
+	assert.Equal(t, metav1.ConditionFalse, got[1].Conditions[0].Status)
+	assert.Equal(t, string(gatewayv1.RouteReasonNoMatchingParent), got[1].Conditions[0].Reason)
+
+	assert.Equal(t, []gatewayv1.Hostname{
+		"10.96.0.10",
+		"[fd00::10]",
+		"echo",
+		"echo.default",
+		"echo.default.svc",
+		"echo.default.svc.cluster.local",
+	}, meshRouteHostnames(got))
+
+	var parentStatus gatewayv1.RouteParentStatus
+	SetRouteParentRefFromContext(&parentStatus, got[0])
+	assert.Equal(t, gatewayv1.ParentReference{
+		Group:     ptr.To(gatewayv1.Group("")),
+		Kind:      ptr.To(gatewayv1.Kind(KindService)),
+		Namespace: ptr.To(gatewayv1.Namespace("default")),
+		Name:      "echo",
+		Port:      ptr.To(gatewayv1.PortNumber(8080)),
+	}, parentStatus.ParentRef)
+
+	rejectMixedMeshParents(got, route.Generation)
+	assert.False(t, isRouteAccepted(got))
+	assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedValue), got[0].Conditions[0].Reason)
+	assert.Empty(t, meshRouteHostnames(got))
+}
+
+func TestListHTTPRoutesForMeshGatewayProxy(t *testing.T) {
+	scheme := parentRefTestScheme(t)
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	gatewayProxy := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "apisix", Name: "mesh"}}
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&gatewayv1.Gateway{}, indexer.ParametersRef, indexer.GatewayParametersRefIndexFunc).
+		WithIndex(&gatewayv1.HTTPRoute{}, indexer.ParentRefs, indexer.HTTPRouteParentRefsIndexFunc).
+		WithIndex(&gatewayv1.HTTPRoute{}, indexer.ParentRefKinds, indexer.HTTPRouteParentRefKindsIndexFunc).
+		WithObjects(
+			&gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mesh"},
+				Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{serviceParentRef("echo", nil)},
+				}},
+			},
+			&gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+				Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "gw"}},
+				}},
+			},
+		).Build()
+	r := &HTTPRouteReconciler{Client: cli, Log: logr.Discard()}
+
+	previous := config.ControllerConfig.Mesh
+	t.Cleanup(func() { config.ControllerConfig.Mesh = previous })
+
+	config.ControllerConfig.Mesh = &config.MeshConfig{GatewayProxy: "apisix/mesh"}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "mesh"}}},
+		r.listHTTPRoutesForGatewayProxy(context.Background(), gatewayProxy))
+
+	config.ControllerConfig.Mesh = &config.MeshConfig{GatewayProxy: "apisix/other"}
+	assert.Empty(t, r.listHTTPRoutesForGatewayProxy(context.Background(), gatewayProxy))
+}
@@ -0,0 +1,286 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// OfflineReconciler is a reconciler that runs without a manager, see NewOfflineReconcilers.
+type OfflineReconciler struct {
+	// Object is the kind the reconciler reconciles.
+	Object client.Object
+	// Predicate, when set, filters the objects of that kind the way the watch of the
+	// reconciler does.
+	Predicate predicate.Predicate
+	reconcile.Reconciler
+}
+
+// NewOfflineReconcilers returns the reconcilers of every kind the controller translates, set
+// up the way SetupWithManager sets them up on a cluster that serves every API, in the order
+// they depend on each other: each kind is reconciled after the kinds it is attached to.
+//
+// Nothing watches on their behalf: the caller reconciles each object the predicate of its
+// reconciler accepts, once the objects it refers to are in cli.
+func NewOfflineReconcilers(cli client.Client, scheme *runtime.Scheme, log logr.Logger, pro provider.Provider,
+	updater status.Updater, readier readiness.ReadinessManager) []OfflineReconciler {
+	icgv := networkingv1.SchemeGroupVersion
+	logFor := func(kind string) logr.Logger {
+		return log.WithName("controllers").WithName(kind)
+	}
+
+	gatewayClass := &GatewayClassReconciler{
+		Client:        cli,
+		Scheme:        scheme,
+		EventRecorder: &record.FakeRecorder{},
+		Log:           logFor(types.KindGatewayClass),
+		Updater:       updater,
+	}
+	ingressClass := &IngressClassReconciler{
+		Client:   cli,
+		Scheme:   scheme,
+		Log:      logFor(types.KindIngressClass),
+		Provider: pro,
+	}
+	gateway := &GatewayReconciler{
+		Client:              cli,
+		Scheme:              scheme,
+		Log:                 logFor(types.KindGateway),
+		Provider:            pro,
+		Updater:             updater,
+		supportsListenerSet: true,
+	}
+	consumer := &ConsumerReconciler{
+		Client:   cli,
+		Scheme:   scheme,
+		Log:      logFor(types.KindConsumer),
+		Provider: pro,
+		Updater:  updater,
+		Readier:  readier,
+	}
+	matchesIngressClass := MatchesIngressClassPredicate(cli, log, icgv.String())
+
+	return []OfflineReconciler{
+		{
+			Object:     &gatewayv1.GatewayClass{},
+			Predicate:  predicate.NewPredicateFuncs(gatewayClass.GatewayClassFilter),
+			Reconciler: gatewayClass,
+		},
+		{
+			Object: &v1alpha1.GatewayProxy{},
+			Reconciler: &GatewayProxyController{
+				Client:                cli,
+				Scheme:                scheme,
+				Log:                   logFor(types.KindGatewayProxy),
+				Provider:              pro,
+				ICGV:                  icgv,
+				supportsEndpointSlice: true,
+				supportsGateway:       true,
+			},
+		},
+		{
+			Object:     &networkingv1.IngressClass{},
+			Predicate:  predicate.NewPredicateFuncs(ingressClass.matchesController),
+			Reconciler: ingressClass,
+		},
+		{
+			Object:     &gatewayv1.Gateway{},
+			Predicate:  predicate.NewPredicateFuncs(gateway.checkGatewayClass),
+			Reconciler: gateway,
+		},
+		{
+			Object: &apiv2.ApisixUpstream{},
+			Reconciler: &ApisixUpstreamReconciler{
+				Client:  cli,
+				Scheme:  scheme,
+				Log:     logFor(types.KindApisixUpstream),
+				Updater: updater,
+				ICGV:    icgv,
+			},
+		},
+		{
+			Object: &apiv2.ApisixPluginConfig{},
+			Reconciler: &ApisixPluginConfigReconciler{
+				Client:  cli,
+				Scheme:  scheme,
+				Log:     logFor(types.KindApisixPluginConfig),
+				Updater: updater,
+				ICGV:    icgv,
+			},
+		},
+		{
+			Object: &gatewayv1.HTTPRoute{},
+			Reconciler: &HTTPRouteReconciler{
+				Client:                cli,
+				Scheme:                scheme,
+				Log:                   logFor(types.KindHTTPRoute),
+				Provider:              pro,
+				Updater:               updater,
+				Readier:               readier,
+				supportsEndpointSlice: true,
+				supportsListenerSet:   true,
+			},
+		},
+		{
+			Object: &gatewayv1.GRPCRoute{},
+			Reconciler: &GRPCRouteReconciler{
+				Client:              cli,
+				Scheme:              scheme,
+				Log:                 logFor(types.KindGRPCRoute),
+				Provider:            pro,
+				Updater:             updater,
+				Readier:             readier,
+				supportsListenerSet: true,
+			},
+		},
+		{
+			Object: &gatewayv1alpha2.TCPRoute{},
+			Reconciler: &TCPRouteReconciler{
+				Client:              cli,
+				Scheme:              scheme,
+				Log:                 logFor(types.KindTCPRoute),
+				Provider:            pro,
+				Updater:             updater,
+				Readier:             readier,
+				supportsListenerSet: true,
+			},
+		},
+		{
+			Object: &gatewayv1alpha2.UDPRoute{},
+			Reconciler: &UDPRouteReconciler{
+				Client:              cli,
+				Scheme:              scheme,
+				Log:                 logFor(types.KindUDPRoute),
+				Provider:            pro,
+				Updater:             updater,
+				Readier:             readier,
+				supportsListenerSet: true,
+			},
+		},
+		{
+			Object: &gatewayv1alpha2.TLSRoute{},
+			Reconciler: &TLSRouteReconciler{
+				Client:              cli,
+				Scheme:              scheme,
+				Log:                 logFor(types.KindTLSRoute),
+				Provider:            pro,
+				Updater:             updater,
+				Readier:             readier,
+				supportsListenerSet: true,
+			},
+		},
+		{
+			Object:     &v1alpha1.Consumer{},
+			Predicate:  predicate.NewPredicateFuncs(consumer.checkGatewayRef),
+			Reconciler: consumer,
+		},
+		{
+			Object:    &networkingv1.Ingress{},
+			Predicate: MatchesIngressClassPredicate(cli, log, ""),
+			Reconciler: &IngressReconciler{
+				Client:                cli,
+				Scheme:                scheme,
+				Log:                   logFor(types.KindIngress),
+				Provider:              pro,
+				Updater:               updater,
+				Readier:               readier,
+				supportsEndpointSlice: true,
+			},
+		},
+		{
+			Object:    &apiv2.ApisixRoute{},
+			Predicate: matchesIngressClass,
+			Reconciler: &ApisixRouteReconciler{
+				Client:                cli,
+				Scheme:                scheme,
+				Log:                   logFor(types.KindApisixRoute),
+				Provider:              pro,
+				Updater:               updater,
+				Readier:               readier,
+				ICGV:                  icgv,
+				supportsEndpointSlice: true,
+			},
+		},
+		{
+			Object:    &apiv2.ApisixTls{},
+			Predicate: matchesIngressClass,
+			Reconciler: &ApisixTlsReconciler{
+				Client:   cli,
+				Scheme:   scheme,
+				Log:      logFor(types.KindApisixTls),
+				Provider: pro,
+				Updater:  updater,
+				Readier:  readier,
+				ICGV:     icgv,
+			},
+		},
+		{
+			Object:    &apiv2.ApisixConsumer{},
+			Predicate: matchesIngressClass,
+			Reconciler: &ApisixConsumerReconciler{
+				Client:   cli,
+				Scheme:   scheme,
+				Log:      logFor(types.KindApisixConsumer),
+				Provider: pro,
+				Updater:  updater,
+				Readier:  readier,
+				ICGV:     icgv,
+			},
+		},
+		{
+			// Reconciled after its members, whose status it reports.
+			Object: &v1alpha1.ConsumerGroup{},
+			Reconciler: &ConsumerGroupReconciler{
+				Client:           cli,
+				Scheme:           scheme,
+				Log:              logFor(types.KindConsumerGroup),
+				Updater:          updater,
+				ICGV:             icgv,
+				supportsConsumer: true,
+			},
+		},
+		{
+			Object:    &apiv2.ApisixGlobalRule{},
+			Predicate: matchesIngressClass,
+			Reconciler: &ApisixGlobalRuleReconciler{
+				Client:   cli,
+				Scheme:   scheme,
+				Log:      logFor(types.KindApisixGlobalRule),
+				Provider: pro,
+				Updater:  updater,
+				Readier:  readier,
+				ICGV:     icgv,
+			},
+		},
+	}
+}
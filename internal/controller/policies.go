@@ -24,6 +24,7 @@ import (
 	"sort"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -31,7 +32,9 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
@@ -40,6 +43,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
+	sslutils "github.com/apache/apisix-ingress-controller/internal/ssl"
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
@@ -107,7 +111,10 @@ func ProcessBackendTrafficPolicy(
 	c client.Client,
 	log logr.Logger,
 	tctx *provider.TranslateContext,
+	gateways []RouteParentRefContext,
 ) {
+	sources := processBackendTrafficPolicyDefaults(c, log, tctx, newRouteGateways(gateways))
+
 	conflicts := map[string]*v1alpha1.BackendTrafficPolicy{}
 	servicePortNameMap := map[string]bool{}
 	policyMap := map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy{}
@@ -137,11 +144,33 @@ func ProcessBackendTrafficPolicy(
 		}
 	}
 
-	for _, p := range policyMap {
+	merged := map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy{}
+	for nn, p := range policyMap {
 		policy := p.DeepCopy()
 		targetRefs := policy.Spec.TargetRefs
 		updated := false
+
+		merged[nn] = policy.DeepCopy()
+		var filled []string
+		if defaults := tctx.BackendTrafficPolicyDefaults; defaults != nil {
+			filled = fillBackendTrafficPolicySpec(&merged[nn].Spec, backendTrafficPolicyDefaultsFor(policy.Namespace, defaults))
+		}
+		// The TLS the policy ends up with may come from the defaults.
+		accepted := backendTrafficPolicyAcceptedMessage(merged[nn])
+		if len(filled) > 0 {
+			accepted += ", " + describeDefaults(filled, sources)
+		}
+		tlsErr := resolveBackendTrafficPolicyTLS(c, tctx, merged[nn])
+
 		for _, targetRef := range targetRefs {
+			// A targetRef to a Gateway supplies defaults, processed above.
+			if string(targetRef.Kind) != internaltypes.KindService {
+				continue
+			}
+			if tlsErr != nil {
+				processPolicyStatus(policy, tctx, NewPolicyCondition(policy.Generation, false, tlsErr.Error()), &updated)
+				continue
+			}
 			sectionName := targetRef.SectionName
 			key := PolicyTargetKey{
 				NsName:    types.NamespacedName{Namespace: p.GetNamespace(), Name: string(targetRef.Name)},
@@ -150,7 +179,7 @@ func ProcessBackendTrafficPolicy(
 			if sectionName != nil {
 				key.SectionName = string(*sectionName)
 			}
-			condition := NewPolicyCondition(policy.Generation, true, "Policy has been accepted")
+			condition := NewPolicyCondition(policy.Generation, true, accepted)
 			if sectionName != nil && !servicePortNameMap[fmt.Sprintf("%s/%s/%s", policy.Namespace, string(targetRef.Name), *sectionName)] {
 				condition = NewPolicyCondition(policy.Generation, false, fmt.Sprintf("No section name %s found in Service %s/%s", *sectionName, policy.Namespace, targetRef.Name))
 				processPolicyStatus(policy, tctx, condition, &updated)
@@ -177,10 +206,68 @@ func ProcessBackendTrafficPolicy(
 		}
 	}
 	for _, policy := range conflicts {
-		tctx.BackendTrafficPolicies[types.NamespacedName{
-			Name:      policy.Name,
-			Namespace: policy.Namespace,
-		}] = policy
+		nn := utils.NamespacedName(policy)
+		tctx.BackendTrafficPolicies[nn] = merged[nn]
+	}
+}
+
+// resolveBackendTrafficPolicyTLS loads the Secrets that the TLS of the policy references into
+// tctx.Secrets, and checks that they hold a client certificate and a CA certificate.
+func resolveBackendTrafficPolicyTLS(c client.Client, tctx *provider.TranslateContext, policy *v1alpha1.BackendTrafficPolicy) error {
+	config := policy.Spec.TLS
+	if config == nil {
+		return nil
+	}
+	if config.SecretRef != nil {
+		secretNN := types.NamespacedName{Namespace: policy.Namespace, Name: config.SecretRef.Name}
+		var secret corev1.Secret
+		if err := c.Get(tctx, secretNN, &secret); err != nil {
+			return fmt.Errorf("failed to get client certificate Secret %s: %w", secretNN, err)
+		}
+		if _, _, err := sslutils.ExtractKeyPair(&secret, true); err != nil {
+			return fmt.Errorf("invalid client certificate Secret %s: %w", secretNN, err)
+		}
+		tctx.Secrets[secretNN] = &secret
+	}
+	if config.CACertificateRef != nil {
+		secretNN := types.NamespacedName{Namespace: policy.Namespace, Name: config.CACertificateRef.Name}
+		var secret corev1.Secret
+		if err := c.Get(tctx, secretNN, &secret); err != nil {
+			return fmt.Errorf("failed to get CA certificate Secret %s: %w", secretNN, err)
+		}
+		if _, err := sslutils.ExtractCAFromSecret(&secret); err != nil {
+			return fmt.Errorf("invalid CA certificate Secret %s: %w", secretNN, err)
+		}
+	}
+	return nil
+}
+
+// backendTrafficPolicyAcceptedMessage is the Accepted message of a policy. The data plane
+// has no CA certificate per upstream, so the message says that tls.caCertificateRef is not
+// applied rather than implying the upstream certificate is validated against it.
+func backendTrafficPolicyAcceptedMessage(policy *v1alpha1.BackendTrafficPolicy) string {
+	if policy.Spec.TLS != nil && policy.Spec.TLS.CACertificateRef != nil {
+		return "Policy has been accepted, but tls.caCertificateRef is not applied: the data plane does not support a CA certificate per upstream"
+	}
+	return "Policy has been accepted"
+}
+
+// backendTrafficPolicySecretMapFunc maps a Secret to the routes that listForPolicy lists for
+// each BackendTrafficPolicy whose TLS references the Secret.
+func backendTrafficPolicySecretMapFunc(c client.Client, log logr.Logger, listForPolicy handler.MapFunc) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list v1alpha1.BackendTrafficPolicyList
+		if err := c.List(ctx, &list, client.MatchingFields{
+			indexer.SecretIndexRef: indexer.GenIndexKey(obj.GetNamespace(), obj.GetName()),
+		}); err != nil {
+			log.Error(err, "failed to list BackendTrafficPolicy for Secret", "secret", utils.NamespacedName(obj))
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range list.Items {
+			requests = append(requests, listForPolicy(ctx, &list.Items[i])...)
+		}
+		return distinctRequests(requests)
 	}
 }
 
@@ -263,12 +350,17 @@ func l4RoutePolicyMatchesRoute(policy v1alpha1.L4RoutePolicy, routeKind, routeNa
 // ProcessL4RoutePolicy finds L4RoutePolicy resources that target the given L4 route
 // (identified by namespace, name, and kind), resolves conflicts deterministically,
 // populates tctx.L4RoutePolicies with the winning policy, and queues status updates.
+// The L4RoutePolicies that target the Gateways of the route populate
+// tctx.L4RoutePolicyDefaults.
 func ProcessL4RoutePolicy(
 	c client.Client,
 	log logr.Logger,
 	tctx *provider.TranslateContext,
 	routeNamespace, routeName, routeKind string,
+	gateways []RouteParentRefContext,
 ) {
+	sources := processL4RoutePolicyDefaults(c, log, tctx, newRouteGateways(gateways))
+
 	var list v1alpha1.L4RoutePolicyList
 	key := indexer.GenIndexKeyWithGK(gatewayv1alpha2.GroupName, routeKind, routeNamespace, routeName)
 	if err := c.List(tctx, &list, client.MatchingFields{indexer.PolicyTargetRefs: key}); err != nil {
@@ -307,13 +399,20 @@ func ProcessL4RoutePolicy(
 		policy := list.Items[i]
 		var condition metav1.Condition
 		if i == 0 {
+			message := "Policy has been accepted"
+			if defaults := tctx.L4RoutePolicyDefaults; defaults != nil {
+				plugins := slices.Clone(winner.Spec.Plugins)
+				if filled := fillDefaultPlugins(&plugins, defaults.Spec.Plugins); len(filled) > 0 {
+					message += ", " + describeDefaults(filled, sources)
+				}
+			}
 			condition = metav1.Condition{
 				Type:               string(gatewayv1.PolicyConditionAccepted),
 				Status:             metav1.ConditionTrue,
 				ObservedGeneration: policy.GetGeneration(),
 				LastTransitionTime: metav1.Now(),
 				Reason:             string(gatewayv1.PolicyReasonAccepted),
-				Message:            "Policy has been accepted",
+				Message:            message,
 			}
 		} else {
 			condition = metav1.Condition{
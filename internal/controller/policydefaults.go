@@ -0,0 +1,368 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// A policy that targets a Gateway, or one of its listeners, supplies defaults to the routes
+// attached to it, and the policies of a route or a Service override them field by field, as
+// in the Gateway API policy attachment. When several policies supply the same default, one
+// that targets a listener takes precedence over one that targets a whole Gateway, then the
+// oldest one does.
+
+// routeGateways maps the Gateways that a route is attached to, to the names of the listeners
+// it is attached to.
+type routeGateways map[types.NamespacedName][]string
+
+func newRouteGateways(gateways []RouteParentRefContext) routeGateways {
+	result := make(routeGateways)
+	for _, gateway := range gateways {
+		if gateway.Gateway == nil {
+			continue
+		}
+		namespacedName := utils.NamespacedName(gateway.Gateway)
+		listeners := result[namespacedName]
+		for _, listener := range gateway.Listeners {
+			listeners = append(listeners, string(listener.Name))
+		}
+		if gateway.Listener != nil {
+			listeners = append(listeners, string(gateway.Listener.Name))
+		}
+		result[namespacedName] = listeners
+	}
+	return result
+}
+
+// indexKeys returns the keys that the policies targeting the Gateways are indexed under.
+func (g routeGateways) indexKeys() []string {
+	keys := make([]string, 0, len(g))
+	for namespacedName := range g {
+		keys = append(keys, indexer.GenIndexKeyWithGK(gatewayv1.GroupName, internaltypes.KindGateway, namespacedName.Namespace, namespacedName.Name))
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// scope reports whether a policy in the namespace supplies defaults to the route, and whether
+// it does so through a listener rather than a whole Gateway.
+func (g routeGateways) scope(namespace string, refs []gatewayv1.LocalPolicyTargetReferenceWithSectionName) (applies, listener bool) {
+	for _, ref := range refs {
+		if string(ref.Group) != gatewayv1.GroupName || string(ref.Kind) != internaltypes.KindGateway {
+			continue
+		}
+		listeners, ok := g[types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}]
+		if !ok {
+			continue
+		}
+		if ref.SectionName == nil || *ref.SectionName == "" {
+			applies = true
+			continue
+		}
+		if slices.Contains(listeners, string(*ref.SectionName)) {
+			return true, true
+		}
+	}
+	return applies, false
+}
+
+// ancestorRefs returns the Gateways of the route that a policy in the namespace targets, as
+// the ancestors of its status.
+func (g routeGateways) ancestorRefs(namespace string, refs []gatewayv1.LocalPolicyTargetReferenceWithSectionName) []gatewayv1.ParentReference {
+	var parentRefs []gatewayv1.ParentReference
+	for _, ref := range refs {
+		if string(ref.Group) != gatewayv1.GroupName || string(ref.Kind) != internaltypes.KindGateway {
+			continue
+		}
+		if _, ok := g[types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}]; !ok {
+			continue
+		}
+		parentRef := gatewayv1.ParentReference{
+			Group:     ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+			Kind:      ptr.To(gatewayv1.Kind(internaltypes.KindGateway)),
+			Namespace: ptr.To(gatewayv1.Namespace(namespace)),
+			Name:      ref.Name,
+		}
+		if !slices.ContainsFunc(parentRefs, func(ref gatewayv1.ParentReference) bool {
+			return parentRefValueEqual(ref, parentRef)
+		}) {
+			parentRefs = append(parentRefs, parentRef)
+		}
+	}
+	return parentRefs
+}
+
+// defaultsPolicies keeps the policies that supply defaults to the route, in precedence order.
+func defaultsPolicies[T client.Object](gateways routeGateways, policies []T, targetRefs func(T) []gatewayv1.LocalPolicyTargetReferenceWithSectionName) []T {
+	var (
+		result   []T
+		listener = make(map[types.NamespacedName]bool)
+	)
+	for _, policy := range policies {
+		namespacedName := utils.NamespacedName(policy)
+		if _, ok := listener[namespacedName]; ok {
+			continue
+		}
+		applies, scoped := gateways.scope(policy.GetNamespace(), targetRefs(policy))
+		if !applies {
+			continue
+		}
+		listener[namespacedName] = scoped
+		result = append(result, policy)
+	}
+	slices.SortFunc(result, func(a, b T) int {
+		if la, lb := listener[utils.NamespacedName(a)], listener[utils.NamespacedName(b)]; la != lb {
+			if la {
+				return -1
+			}
+			return 1
+		}
+		return comparePolicies(a, b)
+	})
+	return result
+}
+
+// policyTargetGateways returns the Gateways that the targetRefs of a policy in the namespace
+// refer to, to list the routes attached to them.
+func policyTargetGateways(namespace string, refs []gatewayv1.LocalPolicyTargetReferenceWithSectionName) []client.Object {
+	var gateways []client.Object
+	for _, ref := range refs {
+		if string(ref.Group) == gatewayv1.GroupName && string(ref.Kind) == internaltypes.KindGateway {
+			gateways = append(gateways, &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: string(ref.Name)},
+			})
+		}
+	}
+	return gateways
+}
+
+func backendPolicyTargetRefs(refs []v1alpha1.BackendPolicyTargetReferenceWithSectionName) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	result := make([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, gatewayv1.LocalPolicyTargetReferenceWithSectionName(ref))
+	}
+	return result
+}
+
+// comparePolicies orders policies oldest first, then by namespace and name.
+func comparePolicies(a, b client.Object) int {
+	if c := a.GetCreationTimestamp().Compare(b.GetCreationTimestamp().Time); c != 0 {
+		return c
+	}
+	return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+}
+
+// describeDefaults describes, for the status of a policy, the defaults it inherits. sources
+// maps each default to the policy that supplies it.
+func describeDefaults(defaults []string, sources map[string]string) string {
+	if len(defaults) == 0 {
+		return ""
+	}
+	descriptions := make([]string, 0, len(defaults))
+	for _, field := range defaults {
+		descriptions = append(descriptions, field+" from "+sources[field])
+	}
+	return "with defaults: " + strings.Join(descriptions, ", ")
+}
+
+// fillDefaultPlugins adds the plugins that plugins lacks, and returns the names of those it
+// added, as `plugin <name>`.
+func fillDefaultPlugins(plugins *[]v1alpha1.Plugin, defaults []v1alpha1.Plugin) (filled []string) {
+	for _, plugin := range defaults {
+		if slices.ContainsFunc(*plugins, func(p v1alpha1.Plugin) bool { return p.Name == plugin.Name }) {
+			continue
+		}
+		*plugins = append(*plugins, *plugin.DeepCopy())
+		filled = append(filled, "plugin "+plugin.Name)
+	}
+	return filled
+}
+
+// fillBackendTrafficPolicySpec sets the fields of spec that are unset from defaults, and
+// returns the names of those it set.
+func fillBackendTrafficPolicySpec(spec, defaults *v1alpha1.BackendTrafficPolicySpec) (filled []string) {
+	if spec.LoadBalancer == nil && defaults.LoadBalancer != nil {
+		spec.LoadBalancer = defaults.LoadBalancer.DeepCopy()
+		filled = append(filled, "loadbalancer")
+	}
+	if spec.Scheme == "" && defaults.Scheme != "" {
+		spec.Scheme = defaults.Scheme
+		filled = append(filled, "scheme")
+	}
+	if spec.Retries == nil && defaults.Retries != nil {
+		spec.Retries = ptr.To(*defaults.Retries)
+		filled = append(filled, "retries")
+	}
+	if spec.Timeout == nil && defaults.Timeout != nil {
+		spec.Timeout = defaults.Timeout.DeepCopy()
+		filled = append(filled, "timeout")
+	}
+	if spec.PassHost == "" && defaults.PassHost != "" {
+		spec.PassHost = defaults.PassHost
+		filled = append(filled, "passHost")
+	}
+	if spec.Host == "" && defaults.Host != "" {
+		spec.Host = defaults.Host
+		filled = append(filled, "upstreamHost")
+	}
+	if spec.HealthCheck == nil && defaults.HealthCheck != nil {
+		spec.HealthCheck = defaults.HealthCheck.DeepCopy()
+		filled = append(filled, "healthCheck")
+	}
+	if spec.SessionPersistence == nil && defaults.SessionPersistence != nil {
+		spec.SessionPersistence = defaults.SessionPersistence.DeepCopy()
+		filled = append(filled, "sessionPersistence")
+	}
+	if spec.TLS == nil && defaults.TLS != nil {
+		spec.TLS = defaults.TLS.DeepCopy()
+		filled = append(filled, "tls")
+	}
+	return filled
+}
+
+// backendTrafficPolicyDefaultsFor returns the defaults that a policy in the namespace
+// inherits. The Secrets of `tls` are local to the policy that configures it, so `tls` is
+// not inherited across namespaces.
+func backendTrafficPolicyDefaultsFor(namespace string, defaults *v1alpha1.BackendTrafficPolicy) *v1alpha1.BackendTrafficPolicySpec {
+	spec := defaults.Spec
+	if namespace != defaults.Namespace {
+		spec.TLS = nil
+	}
+	return &spec
+}
+
+// processBackendTrafficPolicyDefaults merges the BackendTrafficPolicies that target the
+// Gateways of the route into tctx.BackendTrafficPolicyDefaults, and returns the policy that
+// supplies each default.
+func processBackendTrafficPolicyDefaults(c client.Client, log logr.Logger, tctx *provider.TranslateContext, gateways routeGateways) map[string]string {
+	var policies []*v1alpha1.BackendTrafficPolicy
+	for _, key := range gateways.indexKeys() {
+		var list v1alpha1.BackendTrafficPolicyList
+		if err := c.List(tctx, &list, client.MatchingFields{indexer.PolicyTargetRefs: key}); err != nil {
+			log.Error(err, "failed to list BackendTrafficPolicy for Gateway", "gateway", key)
+			continue
+		}
+		for i := range list.Items {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	policies = defaultsPolicies(gateways, policies, func(policy *v1alpha1.BackendTrafficPolicy) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+		return backendPolicyTargetRefs(policy.Spec.TargetRefs)
+	})
+	if len(policies) == 0 {
+		return nil
+	}
+
+	var (
+		defaults = &v1alpha1.BackendTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: policies[0].Namespace, Name: policies[0].Name},
+		}
+		sources = make(map[string]string)
+	)
+	for _, policy := range policies {
+		condition := NewPolicyCondition(policy.Generation, true, backendTrafficPolicyAcceptedMessage(policy))
+		if err := resolveBackendTrafficPolicyTLS(c, tctx, policy); err != nil {
+			condition = NewPolicyCondition(policy.Generation, false, err.Error())
+		} else {
+			for _, field := range fillBackendTrafficPolicySpec(&defaults.Spec, backendTrafficPolicyDefaultsFor(defaults.Namespace, policy)) {
+				sources[field] = "BackendTrafficPolicy " + utils.NamespacedName(policy).String()
+			}
+		}
+
+		refs := gateways.ancestorRefs(policy.Namespace, backendPolicyTargetRefs(policy.Spec.TargetRefs))
+		if SetAncestors(&policy.Status, refs, condition) {
+			tctx.StatusUpdaters = append(tctx.StatusUpdaters, status.Update{
+				NamespacedName: utils.NamespacedName(policy),
+				Resource:       policy.DeepCopy(),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					cp := obj.(*v1alpha1.BackendTrafficPolicy).DeepCopy()
+					cp.Status = policy.Status
+					return cp
+				}),
+			})
+		}
+	}
+	tctx.BackendTrafficPolicyDefaults = defaults
+	return sources
+}
+
+// processL4RoutePolicyDefaults merges the L4RoutePolicies that target the Gateways of the
+// route into tctx.L4RoutePolicyDefaults, and returns the policy that supplies each default.
+func processL4RoutePolicyDefaults(c client.Client, log logr.Logger, tctx *provider.TranslateContext, gateways routeGateways) map[string]string {
+	var policies []*v1alpha1.L4RoutePolicy
+	for _, key := range gateways.indexKeys() {
+		var list v1alpha1.L4RoutePolicyList
+		if err := c.List(tctx, &list, client.MatchingFields{indexer.PolicyTargetRefs: key}); err != nil {
+			log.Error(err, "failed to list L4RoutePolicy for Gateway", "gateway", key)
+			continue
+		}
+		for i := range list.Items {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	policies = defaultsPolicies(gateways, policies, func(policy *v1alpha1.L4RoutePolicy) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+		return policy.Spec.TargetRefs
+	})
+	if len(policies) == 0 {
+		return nil
+	}
+
+	var (
+		defaults = &v1alpha1.L4RoutePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: policies[0].Namespace, Name: policies[0].Name},
+		}
+		sources = make(map[string]string)
+	)
+	for _, policy := range policies {
+		for _, plugin := range fillDefaultPlugins(&defaults.Spec.Plugins, policy.Spec.Plugins) {
+			sources[plugin] = "L4RoutePolicy " + utils.NamespacedName(policy).String()
+		}
+
+		refs := gateways.ancestorRefs(policy.Namespace, policy.Spec.TargetRefs)
+		if SetAncestors(&policy.Status, refs, NewPolicyCondition(policy.Generation, true, "Policy has been accepted")) {
+			tctx.StatusUpdaters = append(tctx.StatusUpdaters, status.Update{
+				NamespacedName: utils.NamespacedName(policy),
+				Resource:       policy.DeepCopy(),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					cp := obj.(*v1alpha1.L4RoutePolicy).DeepCopy()
+					cp.Status = policy.Status
+					return cp
+				}),
+			})
+		}
+	}
+	tctx.L4RoutePolicyDefaults = defaults
+	return sources
+}
@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestDefaultsPolicies(t *testing.T) {
+	var (
+		older  = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		newer  = metav1.NewTime(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+		policy = func(name string, created metav1.Time, gateway, section string) *v1alpha1.BackendTrafficPolicy {
+			ref := v1alpha1.BackendPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Group: gatewayv1.GroupName,
+					Kind:  internaltypes.KindGateway,
+					Name:  gatewayv1.ObjectName(gateway),
+				},
+			}
+			if section != "" {
+				ref.SectionName = ptr.To(gatewayv1.SectionName(section))
+			}
+			return &v1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, CreationTimestamp: created},
+				Spec: v1alpha1.BackendTrafficPolicySpec{
+					TargetRefs: []v1alpha1.BackendPolicyTargetReferenceWithSectionName{ref},
+				},
+			}
+		}
+		targetRefs = func(policy *v1alpha1.BackendTrafficPolicy) []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+			return backendPolicyTargetRefs(policy.Spec.TargetRefs)
+		}
+	)
+
+	gateways := newRouteGateways([]RouteParentRefContext{{
+		Gateway:  &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}},
+		Listener: &gatewayv1.Listener{Name: "http"},
+	}})
+	policies := defaultsPolicies(gateways, []*v1alpha1.BackendTrafficPolicy{
+		policy("gateway-newer", newer, "apisix", ""),
+		policy("gateway-older", older, "apisix", ""),
+		policy("listener", newer, "apisix", "http"),
+		policy("other-listener", older, "apisix", "https"),
+		policy("other-gateway", older, "other", ""),
+		policy("gateway-older", older, "apisix", ""),
+	}, targetRefs)
+
+	var names []string
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+	require.Equal(t, []string{"listener", "gateway-older", "gateway-newer"}, names,
+		"a listener policy should take precedence, then the oldest, and policies of other listeners or Gateways should not apply")
+
+	require.Equal(t, []gatewayv1.ParentReference{{
+		Group:     ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+		Kind:      ptr.To(gatewayv1.Kind(internaltypes.KindGateway)),
+		Namespace: ptr.To(gatewayv1.Namespace("default")),
+		Name:      "apisix",
+	}}, gateways.ancestorRefs("default", targetRefs(policies[0])))
+}
+
+func TestFillBackendTrafficPolicySpec(t *testing.T) {
+	spec := v1alpha1.BackendTrafficPolicySpec{
+		Retries: ptr.To(1),
+	}
+	defaults := v1alpha1.BackendTrafficPolicySpec{
+		Retries:  ptr.To(3),
+		Scheme:   "https",
+		PassHost: "node",
+		Timeout:  &v1alpha1.Timeout{Connect: metav1.Duration{Duration: time.Second}},
+	}
+
+	filled := fillBackendTrafficPolicySpec(&spec, &defaults)
+	require.Equal(t, []string{"scheme", "timeout", "passHost"}, filled)
+	require.Equal(t, 1, *spec.Retries, "a field set by the policy should override the default")
+	require.Equal(t, "https", spec.Scheme)
+	require.Equal(t, "node", spec.PassHost)
+
+	spec.Timeout.Connect.Duration = time.Minute
+	require.Equal(t, time.Second, defaults.Timeout.Connect.Duration, "the defaults should not share state with the policy")
+
+	require.Equal(t, "with defaults: scheme from BackendTrafficPolicy default/a, timeout from BackendTrafficPolicy default/b",
+		describeDefaults([]string{"scheme", "timeout"}, map[string]string{
+			"scheme":  "BackendTrafficPolicy default/a",
+			"timeout": "BackendTrafficPolicy default/b",
+		}))
+}
+
+func TestBackendTrafficPolicyDefaultsFor(t *testing.T) {
+	defaults := &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "gateway-defaults"},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			Retries: ptr.To(3),
+			TLS:     &v1alpha1.BackendTLS{SecretRef: &corev1.LocalObjectReference{Name: "client"}},
+		},
+	}
+
+	var spec v1alpha1.BackendTrafficPolicySpec
+	require.Equal(t, []string{"retries", "tls"}, fillBackendTrafficPolicySpec(&spec, backendTrafficPolicyDefaultsFor("infra", defaults)))
+
+	spec = v1alpha1.BackendTrafficPolicySpec{}
+	require.Equal(t, []string{"retries"}, fillBackendTrafficPolicySpec(&spec, backendTrafficPolicyDefaultsFor("apps", defaults)),
+		"tls should not be inherited by a policy in another namespace, its Secrets are local to the Gateway policy")
+	require.NotNil(t, defaults.Spec.TLS)
+}
+
+func TestProcessBackendTrafficPolicyResolvesDefaultTLS(t *testing.T) {
+	scheme := parentRefTestScheme(t)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	policy := &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TargetRefs: []v1alpha1.BackendPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Kind: internaltypes.KindService,
+					Name: "httpbin",
+				},
+			}},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&v1alpha1.BackendTrafficPolicy{}, indexer.PolicyTargetRefs, indexer.BackendTrafficPolicyIndexFunc).
+		WithObjects(policy).
+		Build()
+
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	tctx.Services[k8stypes.NamespacedName{Namespace: "default", Name: "httpbin"}] = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"},
+	}
+	tctx.BackendTrafficPolicyDefaults = &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-defaults"},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TLS: &v1alpha1.BackendTLS{SecretRef: &corev1.LocalObjectReference{Name: "missing"}},
+		},
+	}
+	tctx.RouteParentRefs = []gatewayv1.ParentReference{{Name: "apisix"}}
+	ProcessBackendTrafficPolicy(cli, logr.Discard(), tctx, nil)
+
+	require.Empty(t, tctx.BackendTrafficPolicies, "a policy whose inherited TLS cannot be resolved should not apply")
+	require.Len(t, tctx.StatusUpdaters, 1)
+	updated := tctx.StatusUpdaters[0].Resource.(*v1alpha1.BackendTrafficPolicy)
+	require.Len(t, updated.Status.Ancestors, 1)
+	condition := apimeta.FindStatusCondition(updated.Status.Ancestors[0].Conditions, string(gatewayv1.PolicyConditionAccepted))
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Contains(t, condition.Message, "default/missing")
+}
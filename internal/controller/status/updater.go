@@ -161,10 +161,24 @@ func (u *UpdateHandler) Writer() Updater {
 	}
 }
 
+// SyncWriter returns an Updater that applies each update before returning, for the callers
+// that do not start the handler, such as the render command.
+func (u *UpdateHandler) SyncWriter(ctx context.Context) Updater {
+	return updaterFunc(func(update Update) {
+		u.apply(ctx, update)
+	})
+}
+
 type Updater interface {
 	Update(u Update)
 }
 
+type updaterFunc func(Update)
+
+func (f updaterFunc) Update(update Update) {
+	f(update)
+}
+
 type UpdateWriter struct {
 	updateChannel chan<- Update
 	wg            *sync.WaitGroup
@@ -249,12 +263,36 @@ func statusEqual(a, b any, opts ...cmp.Option) bool {
 			return false
 		}
 		statusA, statusB = a.Status, b.Status
+	case *gatewayv1.BackendTLSPolicy:
+		b, ok := b.(*gatewayv1.BackendTLSPolicy)
+		if !ok {
+			return false
+		}
+		statusA, statusB = a.Status, b.Status
+	case *gatewayv1.ListenerSet:
+		b, ok := b.(*gatewayv1.ListenerSet)
+		if !ok {
+			return false
+		}
+		statusA, statusB = a.Status, b.Status
 	case *v1alpha1.Consumer:
 		b, ok := b.(*v1alpha1.Consumer)
 		if !ok {
 			return false
 		}
 		statusA, statusB = a.Status, b.Status
+	case *v1alpha1.ConsumerGroup:
+		b, ok := b.(*v1alpha1.ConsumerGroup)
+		if !ok {
+			return false
+		}
+		statusA, statusB = a.Status, b.Status
+	case *v1alpha1.GatewayProxy:
+		b, ok := b.(*v1alpha1.GatewayProxy)
+		if !ok {
+			return false
+		}
+		statusA, statusB = a.Status, b.Status
 	default:
 		return false
 	}
@@ -62,6 +62,9 @@ type TCPRouteReconciler struct { //nolint:revive
 
 	// supportsL4RoutePolicy indicates whether the L4RoutePolicy CRD is installed.
 	supportsL4RoutePolicy bool
+
+	// supportsListenerSet indicates whether the ListenerSet API is installed.
+	supportsListenerSet bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -69,7 +72,10 @@ func (r *TCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	bdr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1alpha2.TCPRoute{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.NewPredicateFuncs(TypePredicate[*corev1.Secret]()),
+		)).
 		Watches(&discoveryv1.EndpointSlice{},
 			handler.EnqueueRequestsFromMapFunc(r.listTCPRoutesByServiceRef),
 		).
@@ -95,6 +101,9 @@ func (r *TCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&v1alpha1.BackendTrafficPolicy{},
 			handler.EnqueueRequestsFromMapFunc(r.listTCPRoutesForBackendTrafficPolicy),
 		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(backendTrafficPolicySecretMapFunc(r.Client, r.Log, r.listTCPRoutesForBackendTrafficPolicy)),
+		).
 		Watches(&v1alpha1.GatewayProxy{},
 			handler.EnqueueRequestsFromMapFunc(r.listTCPRoutesForGatewayProxy),
 		)
@@ -112,6 +121,17 @@ func (r *TCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		)
 	}
 
+	supportsListenerSet, err := pkgutils.HasAPIResource(mgr, &gatewayv1.ListenerSet{})
+	if err != nil {
+		return err
+	}
+	r.supportsListenerSet = supportsListenerSet
+	if r.supportsListenerSet {
+		bdr.Watches(&gatewayv1.ListenerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.listTCPRoutesForGateway),
+		)
+	}
+
 	if GetEnableReferenceGrant() {
 		bdr.Watches(&v1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.listTCPRoutesForReferenceGrant),
@@ -131,6 +151,9 @@ func (r *TCPRouteReconciler) listTCPRoutesForBackendTrafficPolicy(ctx context.Co
 
 	tcprouteList := []gatewayv1alpha2.TCPRoute{}
 	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) != types.KindService {
+			continue
+		}
 		service := &corev1.Service{}
 		if err := r.Get(ctx, client.ObjectKey{
 			Namespace: policy.Namespace,
@@ -167,30 +190,33 @@ func (r *TCPRouteReconciler) listTCPRoutesForBackendTrafficPolicy(ctx context.Co
 			})
 		}
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, backendPolicyTargetRefs(policy.Spec.TargetRefs)) {
+		requests = append(requests, r.listTCPRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
 
+// listTCPRoutesForGateway lists the TCPRoutes attached to a Gateway or a ListenerSet.
+// For a Gateway, routes attached through its ListenerSets are listed as well.
 func (r *TCPRouteReconciler) listTCPRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
-	gateway, ok := obj.(*gatewayv1.Gateway)
-	if !ok {
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to Gateway")
-	}
-	tcprList := &gatewayv1alpha2.TCPRouteList{}
-	if err := r.List(ctx, tcprList, client.MatchingFields{
-		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
-	}); err != nil {
-		r.Log.Error(err, "failed to list tcproutes by gateway", "gateway", gateway.Name)
-		return nil
-	}
-
-	requests := make([]reconcile.Request, 0, len(tcprList.Items))
-	for _, tcr := range tcprList.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Namespace: tcr.Namespace,
-				Name:      tcr.Name,
-			},
-		})
+	var requests []reconcile.Request
+	for _, key := range routeParentIndexKeys(ctx, r.Client, r.Log, obj, r.supportsListenerSet) {
+		tcprList := &gatewayv1alpha2.TCPRouteList{}
+		if err := r.List(ctx, tcprList, client.MatchingFields{
+			indexer.ParentRefs: key,
+		}); err != nil {
+			r.Log.Error(err, "failed to list tcproutes by parent", "parent", key)
+			return nil
+		}
+		for _, tcr := range tcprList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: tcr.Namespace,
+					Name:      tcr.Name,
+				},
+			})
+		}
 	}
 	return requests
 }
@@ -320,14 +346,14 @@ func (r *TCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		backendRefErr = err
 	}
 
-	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
+	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx, gateways)
 	if r.supportsL4RoutePolicy {
-		ProcessL4RoutePolicy(r.Client, r.Log, tctx, tr.Namespace, tr.Name, KindTCPRoute)
+		ProcessL4RoutePolicy(r.Client, r.Log, tctx, tr.Namespace, tr.Name, KindTCPRoute, gateways)
 	}
 	tr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
 	for _, gateway := range gateways {
 		parentStatus := gatewayv1.RouteParentStatus{}
-		SetRouteParentRef(&parentStatus, gateway.Gateway.Name, gateway.Gateway.Namespace)
+		SetRouteParentRefFromContext(&parentStatus, gateway)
 		for _, condition := range gateway.Conditions {
 			parentStatus.Conditions = MergeCondition(parentStatus.Conditions, condition)
 		}
@@ -557,5 +583,9 @@ func (r *TCPRouteReconciler) listTCPRoutesForL4RoutePolicy(ctx context.Context,
 		seen[nn] = struct{}{}
 		requests = append(requests, reconcile.Request{NamespacedName: nn})
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, policy.Spec.TargetRefs) {
+		requests = append(requests, r.listTCPRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
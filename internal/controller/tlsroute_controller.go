@@ -62,6 +62,9 @@ type TLSRouteReconciler struct { //nolint:revive
 
 	// supportsL4RoutePolicy indicates whether the L4RoutePolicy CRD is installed.
 	supportsL4RoutePolicy bool
+
+	// supportsListenerSet indicates whether the ListenerSet API is installed.
+	supportsListenerSet bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -69,7 +72,10 @@ func (r *TLSRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	bdr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1alpha2.TLSRoute{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.NewPredicateFuncs(TypePredicate[*corev1.Secret]()),
+		)).
 		Watches(&discoveryv1.EndpointSlice{},
 			handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesByServiceRef),
 		).
@@ -95,6 +101,9 @@ func (r *TLSRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&v1alpha1.BackendTrafficPolicy{},
 			handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesForBackendTrafficPolicy),
 		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(backendTrafficPolicySecretMapFunc(r.Client, r.Log, r.listTLSRoutesForBackendTrafficPolicy)),
+		).
 		Watches(&v1alpha1.GatewayProxy{},
 			handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesForGatewayProxy),
 		)
@@ -112,6 +121,17 @@ func (r *TLSRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		)
 	}
 
+	supportsListenerSet, err := pkgutils.HasAPIResource(mgr, &gatewayv1.ListenerSet{})
+	if err != nil {
+		return err
+	}
+	r.supportsListenerSet = supportsListenerSet
+	if r.supportsListenerSet {
+		bdr.Watches(&gatewayv1.ListenerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesForGateway),
+		)
+	}
+
 	if GetEnableReferenceGrant() {
 		bdr.Watches(&v1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.listTLSRoutesForReferenceGrant),
@@ -131,6 +151,9 @@ func (r *TLSRouteReconciler) listTLSRoutesForBackendTrafficPolicy(ctx context.Co
 
 	tlsrouteList := []gatewayv1alpha2.TLSRoute{}
 	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) != types.KindService {
+			continue
+		}
 		service := &corev1.Service{}
 		if err := r.Get(ctx, client.ObjectKey{
 			Namespace: policy.Namespace,
@@ -167,30 +190,33 @@ func (r *TLSRouteReconciler) listTLSRoutesForBackendTrafficPolicy(ctx context.Co
 			})
 		}
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, backendPolicyTargetRefs(policy.Spec.TargetRefs)) {
+		requests = append(requests, r.listTLSRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
 
+// listTLSRoutesForGateway lists the TLSRoutes attached to a Gateway or a ListenerSet.
+// For a Gateway, routes attached through its ListenerSets are listed as well.
 func (r *TLSRouteReconciler) listTLSRoutesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
-	gateway, ok := obj.(*gatewayv1.Gateway)
-	if !ok {
-		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to Gateway")
-	}
-	trList := &gatewayv1alpha2.TLSRouteList{}
-	if err := r.List(ctx, trList, client.MatchingFields{
-		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
-	}); err != nil {
-		r.Log.Error(err, "failed to list tlsroutes by gateway", "gateway", gateway.Name)
-		return nil
-	}
-
-	requests := make([]reconcile.Request, 0, len(trList.Items))
-	for _, tcr := range trList.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: client.ObjectKey{
-				Namespace: tcr.Namespace,
-				Name:      tcr.Name,
-			},
-		})
+	var requests []reconcile.Request
+	for _, key := range routeParentIndexKeys(ctx, r.Client, r.Log, obj, r.supportsListenerSet) {
+		trList := &gatewayv1alpha2.TLSRouteList{}
+		if err := r.List(ctx, trList, client.MatchingFields{
+			indexer.ParentRefs: key,
+		}); err != nil {
+			r.Log.Error(err, "failed to list tlsroutes by parent", "parent", key)
+			return nil
+		}
+		for _, tcr := range trList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: tcr.Namespace,
+					Name:      tcr.Name,
+				},
+			})
+		}
 	}
 	return requests
 }
@@ -312,14 +338,14 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		backendRefErr = err
 	}
 
-	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
+	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx, gateways)
 	if r.supportsL4RoutePolicy {
-		ProcessL4RoutePolicy(r.Client, r.Log, tctx, tr.Namespace, tr.Name, types.KindTLSRoute)
+		ProcessL4RoutePolicy(r.Client, r.Log, tctx, tr.Namespace, tr.Name, types.KindTLSRoute, gateways)
 	}
 	tr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
 	for _, gateway := range gateways {
 		parentStatus := gatewayv1.RouteParentStatus{}
-		SetRouteParentRef(&parentStatus, gateway.Gateway.Name, gateway.Gateway.Namespace)
+		SetRouteParentRefFromContext(&parentStatus, gateway)
 		for _, condition := range gateway.Conditions {
 			parentStatus.Conditions = MergeCondition(parentStatus.Conditions, condition)
 		}
@@ -549,5 +575,9 @@ func (r *TLSRouteReconciler) listTLSRoutesForL4RoutePolicy(ctx context.Context,
 		seen[nn] = struct{}{}
 		requests = append(requests, reconcile.Request{NamespacedName: nn})
 	}
+	// a policy that targets a Gateway supplies defaults to the routes attached to it
+	for _, gateway := range policyTargetGateways(policy.Namespace, policy.Spec.TargetRefs) {
+		requests = append(requests, r.listTLSRoutesForGateway(ctx, gateway)...)
+	}
 	return requests
 }
@@ -62,6 +62,7 @@ import (
 
 const (
 	KindGateway            = "Gateway"
+	KindListenerSet        = "ListenerSet"
 	KindHTTPRoute          = "HTTPRoute"
 	KindTCPRoute           = "TCPRoute"
 	KindUDPRoute           = "UDPRoute"
@@ -171,10 +172,14 @@ func IsConditionPresentAndEqual(conditions []metav1.Condition, condition metav1.
 }
 
 func SetGatewayConditionAccepted(gw *gatewayv1.Gateway, status bool, message string) (ok bool) {
+	return SetGatewayConditionAcceptedWithReason(gw, status, string(gatewayv1.GatewayReasonAccepted), message)
+}
+
+func SetGatewayConditionAcceptedWithReason(gw *gatewayv1.Gateway, status bool, reason, message string) (ok bool) {
 	condition := metav1.Condition{
 		Type:               string(gatewayv1.GatewayConditionAccepted),
 		Status:             ConditionStatus(status),
-		Reason:             string(gatewayv1.GatewayReasonAccepted),
+		Reason:             reason,
 		ObservedGeneration: gw.GetGeneration(),
 		Message:            message,
 		LastTransitionTime: metav1.Now(),
@@ -244,10 +249,14 @@ func SetGatewayListenerConditionResolvedRefs(gw *gatewayv1.Gateway, listenerName
 }
 
 func SetGatewayConditionProgrammed(gw *gatewayv1.Gateway, status bool, message string) (ok bool) {
+	return SetGatewayConditionProgrammedWithReason(gw, status, string(gatewayv1.GatewayReasonProgrammed), message)
+}
+
+func SetGatewayConditionProgrammedWithReason(gw *gatewayv1.Gateway, status bool, reason, message string) (ok bool) {
 	condition := metav1.Condition{
 		Type:               string(gatewayv1.GatewayConditionProgrammed),
 		Status:             ConditionStatus(status),
-		Reason:             string(gatewayv1.GatewayReasonProgrammed),
+		Reason:             reason,
 		ObservedGeneration: gw.GetGeneration(),
 		Message:            message,
 		LastTransitionTime: metav1.Now(),
@@ -268,17 +277,23 @@ func ConditionStatus(status bool) metav1.ConditionStatus {
 }
 
 func SetRouteConditionAccepted(routeParentStatus *gatewayv1.RouteParentStatus, generation int64, status bool, message string) {
+	reason := string(gatewayv1.RouteReasonAccepted)
+	if message == ErrNoMatchingListenerHostname.Error() {
+		reason = string(gatewayv1.RouteReasonNoMatchingListenerHostname)
+	}
+	SetRouteConditionAcceptedWithReason(routeParentStatus, generation, status, reason, message)
+}
+
+// SetRouteConditionAcceptedWithReason sets the Accepted condition with the given reason.
+func SetRouteConditionAcceptedWithReason(routeParentStatus *gatewayv1.RouteParentStatus, generation int64, status bool, reason, message string) {
 	condition := metav1.Condition{
 		Type:               string(gatewayv1.RouteConditionAccepted),
 		Status:             ConditionStatus(status),
-		Reason:             string(gatewayv1.RouteReasonAccepted),
+		Reason:             reason,
 		ObservedGeneration: generation,
 		Message:            message,
 		LastTransitionTime: metav1.Now(),
 	}
-	if message == ErrNoMatchingListenerHostname.Error() {
-		condition.Reason = string(gatewayv1.RouteReasonNoMatchingListenerHostname)
-	}
 
 	if !IsConditionPresentAndEqual(routeParentStatus.Conditions, condition) && !slices.ContainsFunc(routeParentStatus.Conditions, func(item metav1.Condition) bool {
 		return item.Type == condition.Type && item.Status == metav1.ConditionFalse && condition.Status == metav1.ConditionTrue
@@ -326,6 +341,27 @@ func SetRouteParentRef(routeParentStatus *gatewayv1.RouteParentStatus, gatewayNa
 	routeParentStatus.ControllerName = gatewayv1.GatewayController(config.ControllerConfig.ControllerName)
 }
 
+// SetRouteParentRefFromContext records the parent a route attached to: the
+// Service of a mesh route, the ListenerSet when the parentRef referenced one,
+// the Gateway otherwise.
+func SetRouteParentRefFromContext(routeParentStatus *gatewayv1.RouteParentStatus, parent RouteParentRefContext) {
+	if parent.Service != nil {
+		SetRouteParentRef(routeParentStatus, parent.Service.Name, parent.Service.Namespace)
+		group, kind := gatewayv1.Group(corev1.GroupName), gatewayv1.Kind(KindService)
+		routeParentStatus.ParentRef.Group = &group
+		routeParentStatus.ParentRef.Kind = &kind
+		routeParentStatus.ParentRef.Port = parent.ServicePort
+		return
+	}
+	if parent.ListenerSet == nil {
+		SetRouteParentRef(routeParentStatus, parent.Gateway.Name, parent.Gateway.Namespace)
+		return
+	}
+	SetRouteParentRef(routeParentStatus, parent.ListenerSet.Name, parent.ListenerSet.Namespace)
+	kind := gatewayv1.Kind(KindListenerSet)
+	routeParentStatus.ParentRef.Kind = &kind
+}
+
 // parentRefTargetsListenerExplicitly reports whether a parentRef names a
 // specific listener, via a non-empty sectionName or an explicit port. It is only
 // meaningful for a parentRef that already matched a listener on its Gateway.
@@ -351,19 +387,50 @@ func ParseRouteParentRefs(
 		}
 		name := string(parentRef.Name)
 
-		if parentRef.Kind != nil && *parentRef.Kind != KindGateway {
+		if parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName {
 			continue
 		}
 
-		gateway := gatewayv1.Gateway{}
-		if err := mgrc.Get(ctx, client.ObjectKey{
-			Namespace: namespace,
-			Name:      name,
-		}, &gateway); err != nil {
-			if client.IgnoreNotFound(err) == nil {
+		var (
+			gateway gatewayv1.Gateway
+			// listenerSet is set when the parentRef attaches through a ListenerSet,
+			// whose listeners are then matched instead of the Gateway's.
+			listenerSet *gatewayv1.ListenerSet
+		)
+		switch {
+		case parentRef.Kind == nil || *parentRef.Kind == KindGateway:
+			if err := mgrc.Get(ctx, client.ObjectKey{
+				Namespace: namespace,
+				Name:      name,
+			}, &gateway); err != nil {
+				if client.IgnoreNotFound(err) == nil {
+					continue
+				}
+				return nil, fmt.Errorf("failed to retrieve gateway for route: %w", err)
+			}
+		case *parentRef.Kind == KindListenerSet:
+			listenerSet = new(gatewayv1.ListenerSet)
+			if err := mgrc.Get(ctx, client.ObjectKey{
+				Namespace: namespace,
+				Name:      name,
+			}, listenerSet); err != nil {
+				if client.IgnoreNotFound(err) == nil || meta.IsNoMatchError(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to retrieve listenerset for route: %w", err)
+			}
+			gatewayKey, ok := listenerSetParentGateway(listenerSet)
+			if !ok {
 				continue
 			}
-			return nil, fmt.Errorf("failed to retrieve gateway for route: %w", err)
+			if err := mgrc.Get(ctx, gatewayKey, &gateway); err != nil {
+				if client.IgnoreNotFound(err) == nil {
+					continue
+				}
+				return nil, fmt.Errorf("failed to retrieve gateway for listenerset: %w", err)
+			}
+		default:
+			continue
 		}
 
 		gatewayClass := gatewayv1.GatewayClass{}
@@ -398,7 +465,20 @@ func ParseRouteParentRefs(
 		// served even though the listener reports Accepted=False/Programmed=False.
 		tlsConflictPorts := portsWithConflictingTLSMode(&gateway)
 
-		for _, listener := range gateway.Spec.Listeners {
+		// allowedRoutes namespaces are relative to the resource declaring the listener.
+		listeners, listenersNamespace := gateway.Spec.Listeners, gateway.Namespace
+		if listenerSet != nil {
+			// Only the listeners the Gateway accepted from the ListenerSet can carry
+			// routes: a ListenerSet the Gateway does not allow has none, and
+			// conflicting listeners are left out.
+			attached, err := resolveGatewayListenerSets(ctx, mgrc, &gateway)
+			if err != nil {
+				return nil, err
+			}
+			listeners, listenersNamespace = attached.acceptedListeners(listenerSet), listenerSet.Namespace
+		}
+
+		for _, listener := range listeners {
 			if parentRef.SectionName != nil {
 				if *parentRef.SectionName != "" && *parentRef.SectionName != listener.Name {
 					continue
@@ -432,7 +512,7 @@ func ParseRouteParentRefs(
 				continue
 			}
 
-			ok, err := routeMatchesListenerAllowedRoutes(ctx, mgrc, route, listener.AllowedRoutes, gateway.Namespace, parentRef.Namespace)
+			ok, err := routeMatchesListenerAllowedRoutes(ctx, mgrc, route, listener.AllowedRoutes, listenersNamespace, parentRef.Namespace)
 			if err != nil {
 				log.Error(err, "failed matching listener to a route for gateway",
 					"listener", string(listener.Name),
@@ -481,6 +561,7 @@ func ParseRouteParentRefs(
 		if matched {
 			gateways = append(gateways, RouteParentRefContext{
 				Gateway:      &gateway,
+				ListenerSet:  listenerSet,
 				ListenerName: listenerName,
 				Listener:     &matchedListener,
 				Listeners:    matchedListeners,
@@ -498,6 +579,7 @@ func ParseRouteParentRefs(
 		} else {
 			gateways = append(gateways, RouteParentRefContext{
 				Gateway:      &gateway,
+				ListenerSet:  listenerSet,
 				ListenerName: listenerName,
 				Listener:     nil,
 				Listeners:    matchedListeners,
@@ -627,7 +709,7 @@ func checkRouteAcceptedByListener(
 	ctx context.Context,
 	mgrc client.Client,
 	route client.Object,
-	gateway gatewayv1.Gateway,
+	parent client.Object,
 	listener gatewayv1.Listener,
 	parentRef gatewayv1.ParentReference,
 ) (bool, gatewayv1.RouteConditionReason, error) {
@@ -647,9 +729,9 @@ func checkRouteAcceptedByListener(
 	if !routeHostnamesIntersectsWithListenerHostname(route, listener) {
 		return false, gatewayv1.RouteReasonNoMatchingListenerHostname, nil
 	}
-	if ok, err := routeMatchesListenerAllowedRoutes(ctx, mgrc, route, listener.AllowedRoutes, gateway.Namespace, parentRef.Namespace); err != nil {
-		return false, gatewayv1.RouteReasonNotAllowedByListeners, fmt.Errorf("failed matching listener %s to a route %s for gateway %s: %w",
-			listener.Name, route.GetName(), gateway.Name, err,
+	if ok, err := routeMatchesListenerAllowedRoutes(ctx, mgrc, route, listener.AllowedRoutes, parent.GetNamespace(), parentRef.Namespace); err != nil {
+		return false, gatewayv1.RouteReasonNotAllowedByListeners, fmt.Errorf("failed matching listener %s to a route %s for parent %s: %w",
+			listener.Name, route.GetName(), client.ObjectKeyFromObject(parent), err,
 		)
 	} else if !ok {
 		return false, gatewayv1.RouteReasonNotAllowedByListeners, nil
@@ -857,41 +939,11 @@ func getAttachedRoutesForListener(ctx context.Context, mgrc client.Client, gatew
 	}
 
 	routes := []types.RouteAdapter{}
-	routeList := []client.ObjectList{}
+	routeList := routeListsForListener(listener)
 
 	listOption := client.MatchingFields{
 		indexer.ParentRefs: indexer.GenIndexKey(gateway.Namespace, gateway.Name),
 	}
-	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Kinds != nil {
-		for _, rgk := range listener.AllowedRoutes.Kinds {
-			if rgk.Group != nil && *rgk.Group != gatewayv1.GroupName {
-				continue
-			}
-			switch rgk.Kind {
-			case types.KindHTTPRoute:
-				routeList = append(routeList, &gatewayv1.HTTPRouteList{})
-			case types.KindGRPCRoute:
-				routeList = append(routeList, &gatewayv1.GRPCRouteList{})
-			case types.KindTCPRoute:
-				routeList = append(routeList, &gatewayv1alpha2.TCPRouteList{})
-			case types.KindUDPRoute:
-				routeList = append(routeList, &gatewayv1alpha2.UDPRouteList{})
-			case types.KindTLSRoute:
-				routeList = append(routeList, &gatewayv1alpha2.TLSRouteList{})
-			}
-		}
-	} else {
-		switch listener.Protocol {
-		case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
-			routeList = append(routeList, &gatewayv1.HTTPRouteList{}, &gatewayv1.GRPCRouteList{})
-		case gatewayv1.TCPProtocolType:
-			routeList = append(routeList, &gatewayv1alpha2.TCPRouteList{})
-		case gatewayv1.UDPProtocolType:
-			routeList = append(routeList, &gatewayv1alpha2.UDPRouteList{})
-		case gatewayv1.TLSProtocolType:
-			routeList = append(routeList, &gatewayv1alpha2.TLSRouteList{})
-		}
-	}
 
 	for _, rl := range routeList {
 		if err := mgrc.List(ctx, rl, listOption); err != nil {
@@ -910,7 +962,7 @@ func getAttachedRoutesForListener(ctx context.Context, mgrc client.Client, gatew
 				ctx,
 				mgrc,
 				route.GetObject(),
-				gateway,
+				&gateway,
 				listener,
 				parentRef,
 			)
@@ -925,23 +977,75 @@ func getAttachedRoutesForListener(ctx context.Context, mgrc client.Client, gatew
 	return attachedRoutes, nil
 }
 
-func checkStatusParent(parents []gatewayv1.RouteParentStatus, routeNamespace string, gateway gatewayv1.Gateway) bool {
-	return lo.ContainsBy(parents, func(parentStatus gatewayv1.RouteParentStatus) bool {
-		parentRef := parentStatus.ParentRef
-		if parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName {
-			return false
-		}
-		if parentRef.Kind != nil && *parentRef.Kind != KindGateway {
-			return false
+// routeListsForListener returns empty lists of the route kinds a listener can
+// have attached, from its allowedRoutes.kinds or else its protocol.
+func routeListsForListener(listener gatewayv1.Listener) []client.ObjectList {
+	routeList := []client.ObjectList{}
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Kinds != nil {
+		for _, rgk := range listener.AllowedRoutes.Kinds {
+			if rgk.Group != nil && *rgk.Group != gatewayv1.GroupName {
+				continue
+			}
+			switch rgk.Kind {
+			case types.KindHTTPRoute:
+				routeList = append(routeList, &gatewayv1.HTTPRouteList{})
+			case types.KindGRPCRoute:
+				routeList = append(routeList, &gatewayv1.GRPCRouteList{})
+			case types.KindTCPRoute:
+				routeList = append(routeList, &gatewayv1alpha2.TCPRouteList{})
+			case types.KindUDPRoute:
+				routeList = append(routeList, &gatewayv1alpha2.UDPRouteList{})
+			case types.KindTLSRoute:
+				routeList = append(routeList, &gatewayv1alpha2.TLSRouteList{})
+			}
 		}
-		gatewayNamespace := routeNamespace
-		if parentRef.Namespace != nil {
-			gatewayNamespace = string(*parentRef.Namespace)
+	} else {
+		switch listener.Protocol {
+		case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+			routeList = append(routeList, &gatewayv1.HTTPRouteList{}, &gatewayv1.GRPCRouteList{})
+		case gatewayv1.TCPProtocolType:
+			routeList = append(routeList, &gatewayv1alpha2.TCPRouteList{})
+		case gatewayv1.UDPProtocolType:
+			routeList = append(routeList, &gatewayv1alpha2.UDPRouteList{})
+		case gatewayv1.TLSProtocolType:
+			routeList = append(routeList, &gatewayv1alpha2.TLSRouteList{})
 		}
-		return gateway.Namespace == gatewayNamespace && gateway.Name == string(parentRef.Name)
+	}
+	return routeList
+}
+
+func checkStatusParent(parents []gatewayv1.RouteParentStatus, routeNamespace string, gateway gatewayv1.Gateway) bool {
+	return checkStatusParentOfKind(parents, routeNamespace, KindGateway, gateway.Namespace, gateway.Name)
+}
+
+// checkStatusParentOfKind reports whether the route status lists the parent of
+// the given kind, namespace and name.
+func checkStatusParentOfKind(parents []gatewayv1.RouteParentStatus, routeNamespace, kind, namespace, name string) bool {
+	return lo.ContainsBy(parents, func(parentStatus gatewayv1.RouteParentStatus) bool {
+		return parentRefTargets(parentStatus.ParentRef, routeNamespace, kind, namespace, name)
 	})
 }
 
+// parentRefTargets reports whether a route parentRef references the parent of
+// the given kind, namespace and name. An unset kind means Gateway.
+func parentRefTargets(parentRef gatewayv1.ParentReference, routeNamespace, kind, namespace, name string) bool {
+	if parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName {
+		return false
+	}
+	parentKind := gatewayv1.Kind(KindGateway)
+	if parentRef.Kind != nil {
+		parentKind = *parentRef.Kind
+	}
+	if parentKind != gatewayv1.Kind(kind) {
+		return false
+	}
+	parentNamespace := routeNamespace
+	if parentRef.Namespace != nil {
+		parentNamespace = string(*parentRef.Namespace)
+	}
+	return parentNamespace == namespace && string(parentRef.Name) == name
+}
+
 func getListenerStatus(
 	ctx context.Context,
 	mrgc client.Client,
@@ -1015,29 +1119,10 @@ func getListenerStatus(
 			continue
 		}
 
-		// Route kinds this listener's protocol is able to serve.
-		protocolKinds := routeKindsForProtocol(listener.Protocol)
-
-		if listener.AllowedRoutes == nil || listener.AllowedRoutes.Kinds == nil {
-			supportedKinds = protocolKinds
-		} else {
-			for _, kind := range listener.AllowedRoutes.Kinds {
-				if kind.Group != nil && *kind.Group != gatewayv1.GroupName {
-					conditionResolvedRefs.Status = metav1.ConditionFalse
-					conditionResolvedRefs.Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
-					continue
-				}
-				// A kind the listener's protocol cannot serve is invalid; the listener
-				// still advertises the kinds it does support.
-				if !slices.ContainsFunc(protocolKinds, func(k gatewayv1.RouteGroupKind) bool {
-					return k.Kind == kind.Kind
-				}) {
-					conditionResolvedRefs.Status = metav1.ConditionFalse
-					conditionResolvedRefs.Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
-					continue
-				}
-				supportedKinds = append(supportedKinds, kind)
-			}
+		var validKinds bool
+		if supportedKinds, validKinds = listenerSupportedKinds(listener); !validKinds {
+			conditionResolvedRefs.Status = metav1.ConditionFalse
+			conditionResolvedRefs.Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
 		}
 
 		if listener.TLS != nil {
@@ -1132,6 +1217,33 @@ func getListenerStatus(
 	return statusArray, nil
 }
 
+// listenerSupportedKinds returns the route kinds a listener accepts: the kinds its
+// protocol is able to serve, narrowed by allowedRoutes.kinds. It reports false when
+// allowedRoutes names a kind the listener cannot serve; the listener still
+// advertises the kinds it does support.
+func listenerSupportedKinds(listener gatewayv1.Listener) ([]gatewayv1.RouteGroupKind, bool) {
+	protocolKinds := routeKindsForProtocol(listener.Protocol)
+	if listener.AllowedRoutes == nil || listener.AllowedRoutes.Kinds == nil {
+		return protocolKinds, true
+	}
+	valid := true
+	supportedKinds := []gatewayv1.RouteGroupKind{}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if kind.Group != nil && *kind.Group != gatewayv1.GroupName {
+			valid = false
+			continue
+		}
+		if !slices.ContainsFunc(protocolKinds, func(k gatewayv1.RouteGroupKind) bool {
+			return k.Kind == kind.Kind
+		}) {
+			valid = false
+			continue
+		}
+		supportedKinds = append(supportedKinds, kind)
+	}
+	return supportedKinds, valid
+}
+
 // validateListenerFrontendValidation validates a listener's TLS frontendValidation
 // (downstream mTLS CA references) and records the outcome on the listener conditions.
 func validateListenerFrontendValidation(
@@ -1281,44 +1393,8 @@ func ProcessGatewayProxy(r client.Client, log logr.Logger, tctx *provider.Transl
 			log.Info("found GatewayProxy for Gateway", "namespace", gateway.Namespace, "name", gateway.Name)
 			tctx.GatewayProxies[gatewayKind] = *gatewayProxy
 			tctx.ResourceParentRefs[rk] = append(tctx.ResourceParentRefs[rk], gatewayKind)
-
-			// Process provider secrets if provider exists
-			if prov := gatewayProxy.Spec.Provider; prov != nil && prov.Type == v1alpha1.ProviderTypeControlPlane {
-				if cp := prov.ControlPlane; cp != nil {
-					if cp.Auth.Type == v1alpha1.AuthTypeAdminKey &&
-						cp.Auth.AdminKey != nil &&
-						cp.Auth.AdminKey.ValueFrom != nil &&
-						cp.Auth.AdminKey.ValueFrom.SecretKeyRef != nil {
-
-						secretRef := cp.Auth.AdminKey.ValueFrom.SecretKeyRef
-						secret := &corev1.Secret{}
-						if err := r.Get(context.Background(), client.ObjectKey{
-							Namespace: ns,
-							Name:      secretRef.Name,
-						}, secret); err != nil {
-							log.Error(err, "failed to get secret for GatewayProxy provider",
-								"namespace", ns,
-								"name", secretRef.Name)
-							return err
-						}
-
-						log.Info("found secret for GatewayProxy provider", "gateway", gateway.Name, "gatewayproxy", gatewayProxy.Name, "secret", secretRef.Name)
-
-						tctx.Secrets[k8stypes.NamespacedName{
-							Namespace: ns,
-							Name:      secretRef.Name,
-						}] = secret
-					}
-
-					if cp.Service != nil {
-						if err := addProviderEndpointsToTranslateContext(tctx, r, log, k8stypes.NamespacedName{
-							Namespace: gatewayProxy.GetNamespace(),
-							Name:      cp.Service.Name,
-						}); err != nil {
-							return err
-						}
-					}
-				}
+			if err := processGatewayProxyProvider(r, log, tctx, gatewayProxy); err != nil {
+				return err
 			}
 		}
 	}
@@ -1331,6 +1407,52 @@ func ProcessGatewayProxy(r client.Client, log logr.Logger, tctx *provider.Transl
 	return nil
 }
 
+// processGatewayProxyProvider loads what the control plane provider of a
+// GatewayProxy needs to be reached: its admin key Secret and the endpoints of
+// its Service.
+func processGatewayProxyProvider(r client.Client, log logr.Logger, tctx *provider.TranslateContext, gatewayProxy *v1alpha1.GatewayProxy) error {
+	prov := gatewayProxy.Spec.Provider
+	if prov == nil || prov.Type != v1alpha1.ProviderTypeControlPlane || prov.ControlPlane == nil {
+		return nil
+	}
+	cp := prov.ControlPlane
+	ns := gatewayProxy.GetNamespace()
+	if cp.Auth.Type == v1alpha1.AuthTypeAdminKey &&
+		cp.Auth.AdminKey != nil &&
+		cp.Auth.AdminKey.ValueFrom != nil &&
+		cp.Auth.AdminKey.ValueFrom.SecretKeyRef != nil {
+
+		secretRef := cp.Auth.AdminKey.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := r.Get(context.Background(), client.ObjectKey{
+			Namespace: ns,
+			Name:      secretRef.Name,
+		}, secret); err != nil {
+			log.Error(err, "failed to get secret for GatewayProxy provider",
+				"namespace", ns,
+				"name", secretRef.Name)
+			return err
+		}
+
+		log.Info("found secret for GatewayProxy provider", "gatewayproxy", gatewayProxy.Name, "secret", secretRef.Name)
+
+		tctx.Secrets[k8stypes.NamespacedName{
+			Namespace: ns,
+			Name:      secretRef.Name,
+		}] = secret
+	}
+
+	if cp.Service != nil {
+		if err := addProviderEndpointsToTranslateContext(tctx, r, log, k8stypes.NamespacedName{
+			Namespace: ns,
+			Name:      cp.Service.Name,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // FullTypeName returns the fully qualified name of the type of the given value.
 func FullTypeName(a any) string {
 	typeOf := reflect.TypeOf(a)
@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterScopedKinds are the kinds the controller reads that have no namespace. Any other
+// object a manifest leaves without one is in the default namespace, as kubectl applies it.
+var clusterScopedKinds = map[string]bool{
+	"GatewayClass": true,
+	"IngressClass": true,
+	"Namespace":    true,
+}
+
+// loadManifests reads the objects of the YAML or JSON manifests in paths: files, the
+// .yaml, .yml and .json files below directories, and the standard input for "-". A manifest
+// may hold several documents, and v1 Lists.
+func loadManifests(paths []string) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	var objects []client.Object
+	load := func(name string, r io.Reader) error {
+		loaded, err := decodeManifest(decoder, r)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		objects = append(objects, loaded...)
+		return nil
+	}
+
+	for _, path := range paths {
+		if path == "-" {
+			if err := load("standard input", os.Stdin); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			// A file named on its own is read whatever its extension.
+			if file != path {
+				switch filepath.Ext(file) {
+				case ".yaml", ".yml", ".json":
+				default:
+					return nil
+				}
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = f.Close() }()
+			return load(file, f)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+func decodeManifest(decoder runtime.Decoder, r io.Reader) ([]client.Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objects []client.Object
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		loaded, err := decodeObject(decoder, doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		objects = append(objects, loaded...)
+	}
+}
+
+func decodeObject(decoder runtime.Decoder, data []byte) ([]client.Object, error) {
+	// A document of comments only decodes to nothing.
+	var meta metav1.TypeMeta
+	if err := utilyaml.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Kind == "" && meta.APIVersion == "" {
+		return nil, nil
+	}
+
+	decoded, _, err := decoder.Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := decoded.(*corev1.List); ok {
+		var objects []client.Object
+		for _, item := range list.Items {
+			loaded, err := decodeObject(decoder, item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, loaded...)
+		}
+		return objects, nil
+	}
+	obj, ok := decoded.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", meta.Kind)
+	}
+	if obj.GetNamespace() == "" && !clusterScopedKinds[meta.Kind] {
+		obj.SetNamespace(metav1.NamespaceDefault)
+	}
+	return []client.Object{obj}, nil
+}
@@ -0,0 +1,473 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+const (
+	// RenderOutputADC renders each config as the ADC configuration the controller syncs.
+	RenderOutputADC = "adc"
+	// RenderOutputAPISIXStandalone renders each config as the apisix.yaml of an APISIX in
+	// standalone mode.
+	RenderOutputAPISIXStandalone = "apisix-standalone"
+)
+
+// RenderOptions tells Render what to render and where.
+type RenderOptions struct {
+	// Paths are the manifest files, and directories of them, to render. "-" reads the
+	// standard input.
+	Paths []string
+	// Output is the format configs are rendered in, RenderOutputADC or
+	// RenderOutputAPISIXStandalone.
+	Output string
+	// OutputDir, when set, receives a file per config instead of Out.
+	OutputDir string
+	// Out receives the rendered configs, Report what became of each object.
+	Out    io.Writer
+	Report io.Writer
+}
+
+// Render translates the objects of the manifests in opts.Paths the way the controller
+// would translate them on a cluster holding just them, without one: the reconcilers and the
+// provider of the controller run against an in-memory client, and nothing is synced. It
+// writes the configuration the provider holds for each config afterwards, and, for each
+// object, the configs it was rendered into, the error it failed with and the conditions it
+// was left with.
+//
+// Only a GatewayProxy with a control plane provider yields a config, as on a cluster: the
+// manifests should include the GatewayProxy, and the Secrets, Services and EndpointSlices it
+// and the routes refer to.
+func Render(ctx context.Context, logger logr.Logger, opts RenderOptions) error {
+	if opts.Output != RenderOutputADC && opts.Output != RenderOutputAPISIXStandalone {
+		return fmt.Errorf("unknown output %q, must be %q or %q", opts.Output, RenderOutputADC, RenderOutputAPISIXStandalone)
+	}
+	objects, err := loadManifests(opts.Paths)
+	if err != nil {
+		return err
+	}
+
+	cli, err := newRenderClient(objects)
+	if err != nil {
+		return err
+	}
+	// Everything the manifests hold is served, ReferenceGrants included.
+	controller.SetEnableReferenceGrant(true)
+
+	readier := readiness.NewReadinessManager(cli, logger)
+	updater := status.NewStatusUpdateHandler(logger.WithName("status").WithName("updater"), cli).SyncWriter(ctx)
+	pro, err := provider.New(string(config.ControllerConfig.ProviderConfig.Type), logger, updater, readier,
+		&provider.Options{ListenerPortMatchMode: config.ControllerConfig.ListenerPortMatchMode})
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	lister, ok := pro.(provider.ResourceLister)
+	if !ok {
+		return fmt.Errorf("provider %q cannot render", config.ControllerConfig.ProviderConfig.Type)
+	}
+	recorder := newRenderProvider(pro)
+
+	results := make(map[types.NamespacedNameKind]*renderResult)
+	var reported []client.Object
+	for _, r := range controller.NewOfflineReconcilers(cli, scheme, logger, recorder, updater, readier) {
+		for _, obj := range objects {
+			if gvkOf(obj) != gvkOf(r.Object) {
+				continue
+			}
+			key := renderKey(obj)
+			result := &renderResult{}
+			results[key] = result
+			reported = append(reported, obj)
+			if r.Predicate != nil && !r.Predicate.Create(event.CreateEvent{Object: obj}) {
+				result.ignored = true
+				continue
+			}
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: utils.NamespacedName(obj)}); err != nil {
+				result.err = err
+			}
+		}
+	}
+
+	resources, err := lister.ListResources()
+	if err != nil {
+		return err
+	}
+	if err := writeRendered(opts, resources); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, obj := range reported {
+		key := renderKey(obj)
+		result := results[key]
+		recorder.fill(key, result)
+		if _, ok := resources[key.String()]; ok && key.Kind == types.KindGatewayProxy {
+			// A GatewayProxy is the config it configures.
+			result.configs = []string{key.String()}
+		}
+		if result.err != nil {
+			failed++
+		}
+		current := obj.DeepCopyObject().(client.Object)
+		if err := cli.Get(ctx, utils.NamespacedName(obj), current); err == nil {
+			result.conditions = conditionsOf(current)
+		}
+		writeReport(opts.Report, key, result)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to render", failed, len(reported))
+	}
+	return nil
+}
+
+// newRenderClient returns an in-memory client holding objects, with the indexes the
+// reconcilers look objects up by.
+func newRenderClient(objects []client.Object) (client.Client, error) {
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	idx := &renderIndexer{builder: builder, client: &lateClient{}}
+	if err := indexer.SetupOfflineIndexer(idx); err != nil {
+		return nil, err
+	}
+	// Every kind keeps its status apart, so that status updates land as they do on a
+	// cluster.
+	kinds := make(map[schema.GroupVersionKind]client.Object)
+	for _, obj := range objects {
+		kinds[gvkOf(obj)] = obj
+	}
+	cli := builder.WithStatusSubresource(slices.Collect(maps.Values(kinds))...).
+		// The reconcilers read through the cache of a manager, which fills in the kind of
+		// what it returns, and some of them rely on it.
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				return setKind(obj)
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				return apimeta.EachListItem(list, setKind)
+			},
+		}).
+		Build()
+	idx.client.Client = cli
+
+	for _, obj := range objects {
+		if err := cli.Create(context.Background(), obj.DeepCopyObject().(client.Object)); err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", renderKey(obj), err)
+		}
+	}
+	return cli, nil
+}
+
+func setKind(obj runtime.Object) error {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
+
+// renderIndexer stands in for the manager the indexes are set up with, registering them
+// with the builder of the client they index.
+type renderIndexer struct {
+	builder *fake.ClientBuilder
+	client  *lateClient
+}
+
+func (i *renderIndexer) GetFieldIndexer() client.FieldIndexer {
+	return i
+}
+
+func (i *renderIndexer) GetClient() client.Client {
+	return i.client
+}
+
+func (i *renderIndexer) IndexField(_ context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	i.builder.WithIndex(obj, field, extractValue)
+	return nil
+}
+
+// lateClient is the client handed to the index functions that look objects up, which are
+// set up before the client is built.
+type lateClient struct {
+	client.Client
+}
+
+// renderResult is what became of one object.
+type renderResult struct {
+	// ignored is set when the object belongs to another controller.
+	ignored bool
+	// configs are the configs the object was rendered into.
+	configs []string
+	// removed is set when the provider was told to remove the object rather than render
+	// it, such as a route attached to no Gateway of the controller.
+	removed    bool
+	err        error
+	conditions []string
+}
+
+// renderProvider records what the reconcilers hand the provider.
+type renderProvider struct {
+	provider.Provider
+
+	mu      sync.Mutex
+	configs map[types.NamespacedNameKind][]string
+	errs    map[types.NamespacedNameKind]error
+	removed map[types.NamespacedNameKind]bool
+}
+
+func newRenderProvider(pro provider.Provider) *renderProvider {
+	return &renderProvider{
+		Provider: pro,
+		configs:  make(map[types.NamespacedNameKind][]string),
+		errs:     make(map[types.NamespacedNameKind]error),
+		removed:  make(map[types.NamespacedNameKind]bool),
+	}
+}
+
+func (p *renderProvider) Update(ctx context.Context, tctx *provider.TranslateContext, obj client.Object) error {
+	err := p.Provider.Update(ctx, tctx, obj)
+
+	key := renderKey(obj)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.removed, key)
+	if err != nil {
+		p.errs[key] = err
+		return err
+	}
+	delete(p.errs, key)
+	var configs []string
+	for _, gp := range tctx.GatewayProxies {
+		configs = append(configs, renderKey(&gp).String())
+	}
+	slices.Sort(configs)
+	p.configs[key] = configs
+	return nil
+}
+
+func (p *renderProvider) Delete(ctx context.Context, obj client.Object) error {
+	key := renderKey(obj)
+	p.mu.Lock()
+	delete(p.configs, key)
+	p.removed[key] = true
+	p.mu.Unlock()
+	return p.Provider.Delete(ctx, obj)
+}
+
+// fill completes result with what the provider was handed for the object key.
+func (p *renderProvider) fill(key types.NamespacedNameKind, result *renderResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result.configs = p.configs[key]
+	result.removed = p.removed[key]
+	if err, ok := p.errs[key]; ok && result.err == nil {
+		result.err = err
+	}
+}
+
+func writeReport(w io.Writer, key types.NamespacedNameKind, result *renderResult) {
+	var state string
+	switch {
+	case result.err != nil:
+		state = "failed: " + result.err.Error()
+	case result.ignored:
+		state = "ignored, it belongs to another controller"
+	case len(result.configs) > 0:
+		state = "rendered into " + strings.Join(result.configs, ", ")
+	case result.removed:
+		state = "rendered into no config, the controller removes it"
+	default:
+		state = "rendered into no config"
+	}
+	name := key.Name
+	if key.Namespace != "" {
+		name = key.Namespace + "/" + key.Name
+	}
+	_, _ = fmt.Fprintf(w, "%s %s: %s\n", key.Kind, name, state)
+	for _, condition := range result.conditions {
+		_, _ = fmt.Fprintf(w, "  %s\n", condition)
+	}
+}
+
+// writeRendered writes resources, by config name, in the output format of opts.
+func writeRendered(opts RenderOptions, resources map[string]*adctypes.Resources) error {
+	if opts.OutputDir != "" {
+		if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+			return err
+		}
+	}
+	for i, name := range slices.Sorted(maps.Keys(resources)) {
+		data, err := renderConfig(opts.Output, resources[name])
+		if err != nil {
+			return fmt.Errorf("failed to render config %s: %w", name, err)
+		}
+		if opts.OutputDir != "" {
+			// Config names are Kind/namespace/name.
+			path := filepath.Join(opts.OutputDir, strings.ReplaceAll(name, "/", "_")+".yaml")
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				return err
+			}
+			continue
+		}
+		if i > 0 {
+			_, _ = fmt.Fprintln(opts.Out, "---")
+		}
+		if _, err := fmt.Fprintf(opts.Out, "# %s\n%s", name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderConfig(output string, resources *adctypes.Resources) ([]byte, error) {
+	if output == RenderOutputADC {
+		return yaml.Marshal(resources)
+	}
+	standalone, err := adcclient.StandaloneConfig(resources)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(standalone)
+	if err != nil {
+		return nil, err
+	}
+	// APISIX only loads an apisix.yaml that ends with the #END marker.
+	return append(data, "#END\n"...), nil
+}
+
+// conditionsOf returns the conditions anywhere in the status of obj, each preceded by where
+// it is found, such as parents[gateway].
+func conditionsOf(obj client.Object) []string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	var conditions []string
+	collectConditions(content["status"], "", &conditions)
+	return conditions
+}
+
+func collectConditions(value any, path string, conditions *[]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if key != "conditions" {
+				collectConditions(v[key], joinPath(path, key), conditions)
+				continue
+			}
+			items, _ := v[key].([]any)
+			for _, item := range items {
+				c, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				condition := fmt.Sprintf("%v=%v", c["type"], c["status"])
+				if reason, ok := c["reason"].(string); ok && reason != "" {
+					condition += " (" + reason + ")"
+				}
+				if message, ok := c["message"].(string); ok && message != "" {
+					condition += ": " + message
+				}
+				if path != "" {
+					condition = path + ": " + condition
+				}
+				*conditions = append(*conditions, condition)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			collectConditions(item, fmt.Sprintf("%s[%s]", path, itemName(item, i)), conditions)
+		}
+	}
+}
+
+// itemName is what an item of a status list is known by: the name of its parent or its
+// own, failing that its index.
+func itemName(item any, i int) string {
+	if m, ok := item.(map[string]any); ok {
+		if parentRef, ok := m["parentRef"].(map[string]any); ok {
+			if name, ok := parentRef["name"].(string); ok {
+				return name
+			}
+		}
+		if name, ok := m["name"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprint(i)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func gvkOf(obj client.Object) schema.GroupVersionKind {
+	gvk, _ := apiutil.GVKForObject(obj, scheme)
+	return gvk
+}
+
+// renderKey is what an object is reported by. The kind comes from the scheme: an object
+// read through a client may leave its TypeMeta empty.
+func renderKey(obj client.Object) types.NamespacedNameKind {
+	key := utils.NamespacedNameKind(obj)
+	key.Kind = gvkOf(obj).Kind
+	return key
+}
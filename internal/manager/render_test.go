@@ -0,0 +1,262 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const renderGatewayManifest = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: apisix
+spec:
+  controllerName: apisix.apache.org/apisix-ingress-controller
+---
+apiVersion: apisix.apache.org/v1alpha1
+kind: GatewayProxy
+metadata:
+  name: apisix-config
+spec:
+  provider:
+    type: ControlPlane
+    controlPlane:
+      endpoints: ["http://apisix-admin:9180"]
+      auth:
+        type: AdminKey
+        adminKey:
+          value: admin-key
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: apisix
+spec:
+  gatewayClassName: apisix
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+  infrastructure:
+    parametersRef:
+      group: apisix.apache.org
+      kind: GatewayProxy
+      name: apisix-config
+`
+
+const renderRouteManifest = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: httpbin
+  spec:
+    ports:
+    - port: 80
+- apiVersion: discovery.k8s.io/v1
+  kind: EndpointSlice
+  metadata:
+    name: httpbin-1
+    labels:
+      kubernetes.io/service-name: httpbin
+  addressType: IPv4
+  ports:
+  - name: ""
+    port: 80
+  endpoints:
+  - addresses: ["10.0.0.1"]
+- apiVersion: gateway.networking.k8s.io/v1
+  kind: HTTPRoute
+  metadata:
+    name: httpbin
+  spec:
+    parentRefs:
+    - name: apisix
+    rules:
+    - matches:
+      - path: {type: Exact, value: /get}
+      backendRefs:
+      - name: httpbin
+        port: 80
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: nginx
+  spec:
+    ingressClassName: nginx
+    defaultBackend:
+      service:
+        name: httpbin
+        port: {number: 80}
+`
+
+func writeRenderManifests(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, manifest := range map[string]string{
+		"gateway.yaml": renderGatewayManifest,
+		"route.yml":    renderRouteManifest,
+		"README.md":    "not a manifest",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(manifest), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRender(t *testing.T) {
+	dir := writeRenderManifests(t)
+
+	var out, report bytes.Buffer
+	err := Render(context.Background(), logr.Discard(), RenderOptions{
+		Paths:  []string{dir},
+		Output: RenderOutputADC,
+		Out:    &out,
+		Report: &report,
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v\n%s", err, report.String())
+	}
+
+	for _, want := range []string{"# GatewayProxy/default/apisix-config", "- /get", "host: 10.0.0.1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected the rendered configuration to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	for _, want := range []string{
+		"HTTPRoute default/httpbin: rendered into GatewayProxy/default/apisix-config",
+		"parents[apisix]: Accepted=True (Accepted)",
+		"Ingress default/nginx: ignored, it belongs to another controller",
+	} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("expected the report to contain %q, got:\n%s", want, report.String())
+		}
+	}
+}
+
+func TestRenderAPISIXStandalone(t *testing.T) {
+	dir := writeRenderManifests(t)
+	outputDir := filepath.Join(t.TempDir(), "out")
+
+	var report bytes.Buffer
+	if err := Render(context.Background(), logr.Discard(), RenderOptions{
+		Paths:     []string{dir},
+		Output:    RenderOutputAPISIXStandalone,
+		OutputDir: outputDir,
+		Report:    &report,
+	}); err != nil {
+		t.Fatalf("render failed: %v\n%s", err, report.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "GatewayProxy_default_apisix-config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := string(data)
+	if !strings.HasSuffix(config, "#END\n") {
+		t.Errorf("expected apisix.yaml to end with #END, got:\n%s", config)
+	}
+	for _, want := range []string{"routes:", "services:", "service_id:"} {
+		if !strings.Contains(config, want) {
+			t.Errorf("expected apisix.yaml to contain %q, got:\n%s", want, config)
+		}
+	}
+}
+
+const renderConsumerGroupManifest = `
+apiVersion: apisix.apache.org/v1alpha1
+kind: ConsumerGroup
+metadata:
+  name: gold
+spec:
+  plugins:
+  - name: limit-count
+    config:
+      count: 100
+      time_window: 60
+---
+apiVersion: apisix.apache.org/v1alpha1
+kind: Consumer
+metadata:
+  name: alice
+spec:
+  gatewayRef:
+    name: apisix
+  groupRef:
+    name: gold
+---
+apiVersion: apisix.apache.org/v1alpha1
+kind: Consumer
+metadata:
+  name: bob
+spec:
+  gatewayRef:
+    name: apisix
+  groupRef:
+    name: silver
+`
+
+func TestRenderConsumerGroup(t *testing.T) {
+	dir := t.TempDir()
+	for name, manifest := range map[string]string{
+		"gateway.yaml":  renderGatewayManifest,
+		"consumer.yaml": renderConsumerGroupManifest,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(manifest), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out, report bytes.Buffer
+	err := Render(context.Background(), logr.Discard(), RenderOptions{
+		Paths:  []string{dir},
+		Output: RenderOutputADC,
+		Out:    &out,
+		Report: &report,
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v\n%s", err, report.String())
+	}
+
+	for _, want := range []string{"consumer_groups:", "name: default_gold", "limit-count:", "username: default_alice", "username: default_bob"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected the rendered configuration to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	for _, want := range []string{
+		"Consumer default/alice: rendered into GatewayProxy/default/apisix-config",
+		"Consumer default/bob: rendered into GatewayProxy/default/apisix-config",
+		"Available=False (ResourceSyncAbort): ConsumerGroup default/silver not found",
+		"ConsumerGroup default/gold: rendered into no config",
+	} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("expected the report to contain %q, got:\n%s", want, report.String())
+		}
+	}
+}
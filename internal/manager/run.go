@@ -45,6 +45,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
 	"github.com/apache/apisix-ingress-controller/internal/manager/server"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 	_ "github.com/apache/apisix-ingress-controller/internal/provider/init"
 	_ "github.com/apache/apisix-ingress-controller/pkg/metrics"
 	"github.com/apache/apisix-ingress-controller/pkg/utils"
@@ -218,10 +219,25 @@ func Run(ctx context.Context, logger logr.Logger) error {
 	providerType := string(config.ControllerConfig.ProviderConfig.Type)
 
 	providerOptions := &provider.Options{
-		SyncTimeout:           config.ControllerConfig.ExecADCTimeout.Duration,
-		SyncPeriod:            config.ControllerConfig.ProviderConfig.SyncPeriod.Duration,
-		InitSyncDelay:         config.ControllerConfig.ProviderConfig.InitSyncDelay.Duration,
-		ListenerPortMatchMode: config.ControllerConfig.ListenerPortMatchMode,
+		SyncTimeout:             config.ControllerConfig.ExecADCTimeout.Duration,
+		SyncPeriod:              config.ControllerConfig.ProviderConfig.SyncPeriod.Duration,
+		InitSyncDelay:           config.ControllerConfig.ProviderConfig.InitSyncDelay.Duration,
+		DefaultExecutor:         string(config.ControllerConfig.ProviderConfig.Executor),
+		SyncConcurrency:         config.ControllerConfig.ProviderConfig.SyncConcurrency,
+		ListenerPortMatchMode:   config.ControllerConfig.ListenerPortMatchMode,
+		DryRun:                  config.ControllerConfig.ProviderConfig.DryRun,
+		DriftCheckPeriod:        config.ControllerConfig.ProviderConfig.DriftDetection.Period.Duration,
+		DriftPolicy:             config.ControllerConfig.ProviderConfig.DriftDetection.Policy,
+		BatchWindow:             config.ControllerConfig.ProviderConfig.Batch.Window.Duration,
+		BatchMaxSize:            config.ControllerConfig.ProviderConfig.Batch.MaxSize,
+		CircuitBreakerThreshold: config.ControllerConfig.ProviderConfig.CircuitBreaker.FailureThreshold,
+		CircuitBreakerCooldown:  config.ControllerConfig.ProviderConfig.CircuitBreaker.Cooldown.Duration,
+		DataPlaneEvents: common.NewDataPlaneEventRecorder(logger, mgr.GetClient(),
+			mgr.GetEventRecorderFor("apisix-ingress-controller")), //nolint:staticcheck
+	}
+	if persistence := config.ControllerConfig.ProviderConfig.Persistence; persistence.Enable {
+		providerOptions.StorePersister = common.NewStorePersister(logger, mgr.GetClient(), mgr.GetAPIReader(),
+			namespace, config.ControllerConfig.ControllerName, persistence.Interval.Duration)
 	}
 	provider, err := provider.New(providerType, logger, updater.Writer(), readier, providerOptions)
 	if err != nil {
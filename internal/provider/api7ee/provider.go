@@ -19,7 +19,10 @@ package api7ee
 
 import (
 	"context"
+	"errors"
+	"maps"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -55,8 +58,13 @@ const (
 type api7eeProvider struct {
 	translator *translator.Translator
 
-	updater         status.Updater
+	updater status.Updater
+	// statusMu guards statusUpdateMap and syncFailures, which full syncs and batches both
+	// update.
+	statusMu        sync.Mutex
 	statusUpdateMap map[types.NamespacedNameKind][]string
+	// syncFailures holds, per config, what its latest sync failed with, full or batched.
+	syncFailures map[string]types.ADCExecutionErrors
 
 	readier readiness.ReadinessManager
 
@@ -77,12 +85,13 @@ func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessMan
 		o.DefaultBackendMode = ProviderTypeAPI7EE
 	}
 
-	cli, err := adcclient.New(log, o.DefaultBackendMode, o.SyncTimeout)
+	// Dry runs read the data plane through the APISIX Admin API, which api7ee does not serve.
+	cli, err := adcclient.New(log, o.DefaultBackendMode, o.DefaultExecutor, o.SyncTimeout, o.SyncConcurrency, false)
 	if err != nil {
 		return nil, err
 	}
 
-	return &api7eeProvider{
+	d := &api7eeProvider{
 		client:     cli,
 		Options:    o,
 		translator: translator.NewTranslator(log, o.ListenerPortMatchMode),
@@ -90,13 +99,26 @@ func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessMan
 		readier:    readier,
 		syncCh:     make(chan struct{}, 1),
 		log:        log.WithName("provider"),
-	}, nil
+	}
+	if o.BatchWindow > 0 {
+		cli.EnableBatching(o.BatchWindow, o.BatchMaxSize)
+		cli.OnBatchSynced = d.handleBatchResult
+	}
+	if o.CircuitBreakerThreshold > 0 {
+		cli.EnableCircuitBreaker(o.CircuitBreakerThreshold, o.CircuitBreakerCooldown)
+		cli.OnCircuitChange = d.handleCircuitChange
+	}
+	return d, nil
 }
 
 func (d *api7eeProvider) Register(pathPrefix string, mux *http.ServeMux) {
 	d.client.ADCDebugProvider.SetupHandler(pathPrefix, mux)
 }
 
+func (d *api7eeProvider) ListResources() (map[string]*adctypes.Resources, error) {
+	return d.client.ListResources()
+}
+
 func (d *api7eeProvider) Update(ctx context.Context, tctx *provider.TranslateContext, obj client.Object) error {
 	d.log.V(1).Info("updating object", "object", obj)
 	var (
@@ -131,7 +153,7 @@ func (d *api7eeProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 		resourceTypes = append(resourceTypes, "service", "ssl")
 	case *v1alpha1.Consumer:
 		result, err = d.translator.TranslateConsumerV1alpha1(tctx, t.DeepCopy())
-		resourceTypes = append(resourceTypes, "consumer")
+		resourceTypes = append(resourceTypes, "consumer_group", "consumer")
 	case *networkingv1.IngressClass:
 		result, err = d.translator.TranslateIngressClass(tctx, t.DeepCopy())
 		resourceTypes = append(resourceTypes, "global_rule", "plugin_metadata")
@@ -150,7 +172,7 @@ func (d *api7eeProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 		resourceTypes = append(resourceTypes, "ssl")
 	case *apiv2.ApisixConsumer:
 		result, err = d.translator.TranslateApisixConsumer(tctx, t.DeepCopy())
-		resourceTypes = append(resourceTypes, "consumer")
+		resourceTypes = append(resourceTypes, "consumer_group", "consumer")
 	case *v1alpha1.GatewayProxy:
 		return d.updateConfigForGatewayProxy(tctx, t)
 	}
@@ -184,6 +206,7 @@ func (d *api7eeProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 			Services:       result.Services,
 			SSLs:           result.SSL,
 			Consumers:      result.Consumers,
+			ConsumerGroups: result.ConsumerGroups,
 		},
 	}
 
@@ -210,7 +233,7 @@ func (d *api7eeProvider) Delete(ctx context.Context, obj client.Object) error {
 		resourceTypes = append(resourceTypes, "service", "ssl")
 		labels = label.GenLabel(obj)
 	case *v1alpha1.Consumer:
-		resourceTypes = append(resourceTypes, "consumer")
+		resourceTypes = append(resourceTypes, "consumer_group", "consumer")
 		labels = label.GenLabel(obj)
 	case *networkingv1.IngressClass, *networkingv1beta1.IngressClass:
 		// delete all resources
@@ -221,7 +244,7 @@ func (d *api7eeProvider) Delete(ctx context.Context, obj client.Object) error {
 		resourceTypes = append(resourceTypes, "ssl")
 		labels = label.GenLabel(obj)
 	case *apiv2.ApisixConsumer:
-		resourceTypes = append(resourceTypes, "consumer")
+		resourceTypes = append(resourceTypes, "consumer_group", "consumer")
 		labels = label.GenLabel(obj)
 	}
 
@@ -235,7 +258,18 @@ func (d *api7eeProvider) Delete(ctx context.Context, obj client.Object) error {
 }
 
 func (d *api7eeProvider) Start(ctx context.Context) error {
-	d.readier.WaitReady(ctx, 5*time.Minute)
+	go d.client.RunBatches(ctx)
+
+	restored := false
+	if d.StorePersister != nil {
+		restored = d.StorePersister.Restore(ctx, d.client.Restore, d.Delete)
+		go d.StorePersister.Run(ctx, d.client.Snapshots)
+	}
+	// A restored store already holds what the data plane last received, so the first sync
+	// leaves it as it is rather than pushing whatever has been reconciled so far.
+	if !restored {
+		d.readier.WaitReady(ctx, 5*time.Minute)
+	}
 
 	d.startUpSync.Store(true)
 	d.log.Info("Performing startup synchronization")
@@ -253,11 +287,17 @@ func (d *api7eeProvider) Start(ctx context.Context) error {
 		})
 	}
 
-	if d.SyncPeriod < 1 {
+	// A failed batch, and an open circuit, are retried by a sync, see handleBatchResult and
+	// handleCircuitChange.
+	if d.SyncPeriod < 1 && d.BatchWindow <= 0 && d.CircuitBreakerThreshold <= 0 {
 		return nil
 	}
-	ticker := time.NewTicker(d.SyncPeriod)
-	defer ticker.Stop()
+	var tick <-chan time.Time
+	if d.SyncPeriod > 0 {
+		ticker := time.NewTicker(d.SyncPeriod)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 
 	retrier := common.NewRetrier(common.NewExponentialBackoff(RetryBaseDelay, RetryMaxDelay))
 
@@ -265,7 +305,7 @@ func (d *api7eeProvider) Start(ctx context.Context) error {
 		select {
 		case <-d.syncCh:
 		case <-retrier.C():
-		case <-ticker.C:
+		case <-tick:
 		case <-ctx.Done():
 			return nil
 		}
@@ -279,6 +319,17 @@ func (d *api7eeProvider) Start(ctx context.Context) error {
 	}
 }
 
+// handleCircuitChange reports the state of the circuit breaker of a config on its
+// GatewayProxy, and probes an open circuit by a sync once its cooldown passed.
+func (d *api7eeProvider) handleCircuitChange(_ context.Context, name string, state types.CircuitState, message string) {
+	if update, ok := common.NewCircuitStatusUpdate(name, state, message); ok {
+		d.updater.Update(update)
+	}
+	if state == types.CircuitOpen {
+		time.AfterFunc(d.CircuitBreakerCooldown, d.syncNotify)
+	}
+}
+
 func (d *api7eeProvider) syncNotify() {
 	select {
 	case d.syncCh <- struct{}{}:
@@ -293,9 +344,44 @@ func (d *api7eeProvider) sync(ctx context.Context) error {
 }
 
 func (d *api7eeProvider) handleADCExecutionErrors(statusesMap map[string]types.ADCExecutionErrors) {
-	statusUpdateMap := d.resolveADCExecutionErrors(statusesMap)
+	d.statusMu.Lock()
+	defer d.statusMu.Unlock()
+
+	d.syncFailures = maps.Clone(statusesMap)
+	d.resolveSyncFailures()
+}
+
+// handleBatchResult takes the outcome of a batch in place of what the config last failed
+// with, and syncs everything again soon when the batch failed.
+func (d *api7eeProvider) handleBatchResult(_ context.Context, result adcclient.BatchResult) {
+	d.statusMu.Lock()
+	defer d.statusMu.Unlock()
+
+	var execErrs types.ADCExecutionErrors
+	switch {
+	case result.Err == nil:
+		delete(d.syncFailures, result.ConfigName)
+	case errors.As(result.Err, &execErrs):
+		if d.syncFailures == nil {
+			d.syncFailures = make(map[string]types.ADCExecutionErrors)
+		}
+		d.syncFailures[result.ConfigName] = execErrs
+	}
+	d.resolveSyncFailures()
+
+	// Update returned before the batch was synced, so nothing requeues the objects it
+	// coalesced.
+	if result.Err != nil {
+		d.syncNotify()
+	}
+}
+
+// resolveSyncFailures marks the objects the latest syncs of all configs failed as failed,
+// and the ones that failed before but no longer do as accepted.
+func (d *api7eeProvider) resolveSyncFailures() {
+	statusUpdateMap := d.resolveADCExecutionErrors(d.syncFailures)
 	d.handleStatusUpdate(statusUpdateMap)
-	d.log.V(1).Info("handled ADC execution errors", "status_record", statusesMap, "status_update", statusUpdateMap)
+	d.log.V(1).Info("handled ADC execution errors", "status_record", d.syncFailures, "status_update", statusUpdateMap)
 }
 
 func (d *api7eeProvider) NeedLeaderElection() bool {
@@ -325,7 +325,7 @@ func (d *api7eeProvider) handleEmptyFailedStatuses(
 		d.addResourceToStatusUpdateMap(obj.GetLabels(), failedStatus.Error(), statusUpdateMap)
 	}
 
-	for _, obj := range resource.Consumers {
+	for _, obj := range resource.AllConsumers() {
 		d.addResourceToStatusUpdateMap(obj.GetLabels(), failedStatus.Error(), statusUpdateMap)
 	}
 
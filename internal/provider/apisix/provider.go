@@ -35,6 +35,7 @@ import (
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -78,12 +79,16 @@ func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessMan
 		o.DefaultBackendMode = ProviderTypeAPISIX
 	}
 
-	cli, err := adcclient.New(log, o.DefaultBackendMode, o.SyncTimeout)
+	cli, err := adcclient.New(log, o.DefaultBackendMode, o.DefaultExecutor, o.SyncTimeout, o.SyncConcurrency, o.DryRun)
 	if err != nil {
 		return nil, err
 	}
+	if o.DataPlaneEvents != nil {
+		cli.OnDryRun = o.DataPlaneEvents.RecordDryRun
+		cli.OnDrift = o.DataPlaneEvents.RecordDrift
+	}
 
-	return &apisixProvider{
+	d := &apisixProvider{
 		client:     cli,
 		Options:    o,
 		translator: translator.NewTranslator(log, o.ListenerPortMatchMode),
@@ -91,13 +96,22 @@ func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessMan
 		readier:    readier,
 		syncCh:     make(chan struct{}, 1),
 		log:        log.WithName("provider"),
-	}, nil
+	}
+	if o.CircuitBreakerThreshold > 0 {
+		cli.EnableCircuitBreaker(o.CircuitBreakerThreshold, o.CircuitBreakerCooldown)
+		cli.OnCircuitChange = d.handleCircuitChange
+	}
+	return d, nil
 }
 
 func (d *apisixProvider) Register(pathPrefix string, mux *http.ServeMux) {
 	d.client.ADCDebugProvider.SetupHandler(pathPrefix, mux)
 }
 
+func (d *apisixProvider) ListResources() (map[string]*adctypes.Resources, error) {
+	return d.client.ListResources()
+}
+
 func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateContext, obj client.Object) error {
 	d.log.V(1).Info("updating object", "object", utils.NamespacedNameKind(obj))
 	var (
@@ -132,7 +146,7 @@ func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 		resourceTypes = append(resourceTypes, adctypes.TypeService, adctypes.TypeSSL)
 	case *v1alpha1.Consumer:
 		result, err = d.translator.TranslateConsumerV1alpha1(tctx, t.DeepCopy())
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 	case *networkingv1.IngressClass:
 		result, err = d.translator.TranslateIngressClass(tctx, t.DeepCopy())
 		resourceTypes = append(resourceTypes, adctypes.TypeGlobalRule, adctypes.TypePluginMetadata)
@@ -151,7 +165,7 @@ func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 		resourceTypes = append(resourceTypes, adctypes.TypeSSL)
 	case *apiv2.ApisixConsumer:
 		result, err = d.translator.TranslateApisixConsumer(tctx, t.DeepCopy())
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 	case *v1alpha1.GatewayProxy:
 		return d.updateConfigForGatewayProxy(tctx, t)
 	}
@@ -185,6 +199,7 @@ func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 			Services:       result.Services,
 			SSLs:           result.SSL,
 			Consumers:      result.Consumers,
+			ConsumerGroups: result.ConsumerGroups,
 		},
 	}
 	d.log.V(1).Info("updating config", "task", task)
@@ -207,7 +222,7 @@ func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
 		resourceTypes = append(resourceTypes, adctypes.TypeService, adctypes.TypeSSL)
 		labels = label.GenLabel(obj)
 	case *v1alpha1.Consumer:
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 		labels = label.GenLabel(obj)
 	case *networkingv1.IngressClass, *networkingv1beta1.IngressClass:
 		// delete all resources
@@ -218,7 +233,7 @@ func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
 		resourceTypes = append(resourceTypes, adctypes.TypeSSL)
 		labels = label.GenLabel(obj)
 	case *apiv2.ApisixConsumer:
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 		labels = label.GenLabel(obj)
 	}
 	nnk := utils.NamespacedNameKind(obj)
@@ -261,7 +276,16 @@ func (d *apisixProvider) Start(ctx context.Context) error {
 	// Rebuild every baseline from the data plane before syncing from it.
 	d.client.InvalidateADCCache()
 
-	d.readier.WaitReady(ctx, 5*time.Minute)
+	restored := false
+	if d.StorePersister != nil {
+		restored = d.StorePersister.Restore(ctx, d.client.Restore, d.Delete)
+		go d.StorePersister.Run(ctx, d.client.Snapshots)
+	}
+	// A restored store already holds what the data plane last received, so the first sync
+	// leaves it as it is rather than pushing whatever has been reconciled so far.
+	if !restored {
+		d.readier.WaitReady(ctx, 5*time.Minute)
+	}
 
 	initalSyncDelay := d.InitSyncDelay
 	if initalSyncDelay > 0 {
@@ -275,6 +299,10 @@ func (d *apisixProvider) Start(ctx context.Context) error {
 	ticker := time.NewTicker(syncPeriod)
 	defer ticker.Stop()
 
+	if d.DriftCheckPeriod > 0 {
+		go d.detectDrift(ctx)
+	}
+
 	retrier := common.NewRetrier(common.NewExponentialBackoff(RetryBaseDelay, RetryMaxDelay))
 
 	for {
@@ -301,6 +329,39 @@ func (d *apisixProvider) sync(ctx context.Context) error {
 	return err
 }
 
+// detectDrift checks the data planes for drift every DriftCheckPeriod until ctx is done.
+// Under the correct policy, a drift is synced away right away.
+func (d *apisixProvider) detectDrift(ctx context.Context) {
+	ticker := time.NewTicker(d.DriftCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		drifted, err := d.client.DetectDrift(ctx)
+		if err != nil {
+			d.log.Error(err, "failed to check for drift")
+		}
+		if len(drifted) > 0 && d.DriftPolicy == config.DriftPolicyCorrect {
+			d.log.Info("correcting drifted data planes", "configs", drifted)
+			d.syncNotify()
+		}
+	}
+}
+
+// handleCircuitChange reports the state of the circuit breaker of a config on its
+// GatewayProxy, and probes an open circuit by a sync once its cooldown passed.
+func (d *apisixProvider) handleCircuitChange(_ context.Context, name string, state types.CircuitState, message string) {
+	if update, ok := common.NewCircuitStatusUpdate(name, state, message); ok {
+		d.updater.Update(update)
+	}
+	if state == types.CircuitOpen {
+		time.AfterFunc(d.CircuitBreakerCooldown, d.syncNotify)
+	}
+}
+
 func (d *apisixProvider) syncNotify() {
 	select {
 	case d.syncCh <- struct{}{}:
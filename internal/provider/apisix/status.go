@@ -326,7 +326,7 @@ func (d *apisixProvider) handleEmptyFailedStatuses(
 		d.addResourceToStatusUpdateMap(obj.GetLabels(), failedStatus.Error(), statusUpdateMap)
 	}
 
-	for _, obj := range resource.Consumers {
+	for _, obj := range resource.AllConsumers() {
 		d.addResourceToStatusUpdateMap(obj.GetLabels(), failedStatus.Error(), statusUpdateMap)
 	}
 
@@ -17,14 +17,19 @@
 package common
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -35,9 +40,16 @@ type ResourceInfo struct {
 	Link string
 }
 
+// RevisionPinner keeps the data plane of a config on one of its revisions, see cache.Store.Pin.
+type RevisionPinner interface {
+	PinRevision(ctx context.Context, name string, version int64) error
+	UnpinRevision(ctx context.Context, name string) error
+}
+
 type ADCDebugProvider struct {
 	store         *cache.Store
 	configManager *ConfigManager[types.NamespacedNameKind, adctypes.Config]
+	pinner        RevisionPinner
 	pathPrefix    string
 }
 
@@ -50,11 +62,16 @@ func newTemplate(name, body string) *template.Template {
 func (asrv *ADCDebugProvider) SetupHandler(pathPrefix string, mux *http.ServeMux) {
 	asrv.pathPrefix = pathPrefix
 	mux.HandleFunc("/config", asrv.handleConfig)
+	mux.HandleFunc("/history", asrv.handleHistory)
+	mux.HandleFunc("/history/rollback", asrv.handleRollback)
+	mux.HandleFunc("/history/unpin", asrv.handleUnpin)
+	mux.HandleFunc("/dryrun", asrv.handleDryRun)
+	mux.HandleFunc("/drift", asrv.handleDrift)
 	mux.HandleFunc("/", asrv.handleIndex)
 }
 
-func NewADCDebugProvider(store *cache.Store, configManager *ConfigManager[types.NamespacedNameKind, adctypes.Config]) *ADCDebugProvider {
-	return &ADCDebugProvider{store: store, configManager: configManager}
+func NewADCDebugProvider(store *cache.Store, configManager *ConfigManager[types.NamespacedNameKind, adctypes.Config], pinner RevisionPinner) *ADCDebugProvider {
+	return &ADCDebugProvider{store: store, configManager: configManager, pinner: pinner}
 }
 
 func (asrv *ADCDebugProvider) handleIndex(w http.ResponseWriter, r *http.Request) {
@@ -123,7 +140,7 @@ func (asrv *ADCDebugProvider) handleConfig(w http.ResponseWriter, r *http.Reques
 }
 
 func (asrv *ADCDebugProvider) showResourceTypes(w http.ResponseWriter, configName, configNameEncoded string) {
-	resourceTypes := []string{adctypes.TypeService, adctypes.TypeRoute, adctypes.TypeConsumer, adctypes.TypeSSL, adctypes.TypeGlobalRule, adctypes.TypePluginMetadata}
+	resourceTypes := []string{adctypes.TypeService, adctypes.TypeRoute, adctypes.TypeConsumerGroup, adctypes.TypeConsumer, adctypes.TypeSSL, adctypes.TypeGlobalRule, adctypes.TypePluginMetadata}
 
 	tmpl := newTemplate("resources", `
         <html>
@@ -135,6 +152,9 @@ func (asrv *ADCDebugProvider) showResourceTypes(w http.ResponseWriter, configNam
                 <li><a href="{{$.Prefix}}/config?name={{$.ConfigNameEncoded}}&type={{. | urlencode}}">{{.}}</a></li>
                 {{end}}
             </ul>
+            <a href="{{$.Prefix}}/history?name={{$.ConfigNameEncoded}}">Revision history</a>
+            <a href="{{$.Prefix}}/dryrun?name={{$.ConfigNameEncoded}}">Dry run</a>
+            <a href="{{$.Prefix}}/drift?name={{$.ConfigNameEncoded}}">Drift</a>
         </body>
         </html>
     `)
@@ -171,8 +191,18 @@ func (asrv *ADCDebugProvider) showResources(w http.ResponseWriter, r *http.Reque
 					asrv.pathPrefix, configNameEncoded, url.QueryEscape(resourceType), url.QueryEscape(svc.ID)),
 			})
 		}
+	case adctypes.TypeConsumerGroup:
+		for _, group := range resources.ConsumerGroups {
+			resourceInfos = append(resourceInfos, ResourceInfo{
+				ID:   group.ID,
+				Name: group.Name,
+				Type: resourceType,
+				Link: fmt.Sprintf("%s/config?name=%s&type=%s&id=%s",
+					asrv.pathPrefix, configNameEncoded, url.QueryEscape(resourceType), url.QueryEscape(group.ID)),
+			})
+		}
 	case adctypes.TypeConsumer:
-		for _, consumer := range resources.Consumers {
+		for _, consumer := range resources.AllConsumers() {
 			resourceInfos = append(resourceInfos, ResourceInfo{
 				ID:   consumer.Username,
 				Name: consumer.Username,
@@ -271,8 +301,15 @@ func (asrv *ADCDebugProvider) showResourceDetail(w http.ResponseWriter, r *http.
 				break
 			}
 		}
+	case adctypes.TypeConsumerGroup:
+		for _, group := range resources.ConsumerGroups {
+			if group.ID == resourceID {
+				resource = group
+				break
+			}
+		}
 	case adctypes.TypeConsumer:
-		for _, consumer := range resources.Consumers {
+		for _, consumer := range resources.AllConsumers() {
 			if consumer.Username == resourceID {
 				resource = consumer
 				break
@@ -339,3 +376,265 @@ func (asrv *ADCDebugProvider) showResourceDetail(w http.ResponseWriter, r *http.
 		Prefix:       asrv.pathPrefix,
 	})
 }
+
+// handleHistory lists the revisions of a config, or, given from and to, the objects that
+// differ between two of them. Either of from and to may be "desired", which stands for what
+// the config desires now.
+func (asrv *ADCDebugProvider) handleHistory(w http.ResponseWriter, r *http.Request) {
+	configName := r.URL.Query().Get("name")
+	if configName == "" {
+		http.Error(w, "Config name is required", http.StatusBadRequest)
+		return
+	}
+
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from != "" || to != "" {
+		asrv.showRevisionDiff(w, configName, from, to)
+		return
+	}
+
+	type revisionInfo struct {
+		Version   int64
+		Timestamp string
+		Hash      string
+		Objects   []string
+	}
+	var revisions []revisionInfo
+	for _, revision := range asrv.store.Revisions(configName) {
+		revisions = append(revisions, revisionInfo{
+			Version:   revision.Version,
+			Timestamp: revision.Timestamp.UTC().Format(time.RFC3339),
+			Hash:      revision.Hash[:12],
+			Objects:   revision.Objects,
+		})
+	}
+	var pinned int64
+	if revision, ok := asrv.store.Pinned(configName); ok {
+		pinned = revision.Version
+	}
+
+	tmpl := newTemplate("history", `
+		<html>
+		<head><title>Revision history for {{.ConfigName}}</title></head>
+		<body>
+			<h1>Revision history for {{.ConfigName}}</h1>
+			{{if .Pinned}}
+			<form method="post" action="{{.Prefix}}/history/unpin?name={{.ConfigName | urlencode}}">
+				Pinned to revision {{.Pinned}}. <button type="submit">Unpin</button>
+			</form>
+			{{end}}
+			<table border="1">
+				<tr><th>Revision</th><th>Time</th><th>Hash</th><th>Changed objects</th><th></th></tr>
+				{{range .Revisions}}
+				<tr>
+					<td>{{.Version}}</td>
+					<td>{{.Timestamp}}</td>
+					<td>{{.Hash}}</td>
+					<td>{{range .Objects}}{{.}}<br>{{end}}</td>
+					<td>
+						<a href="{{$.Prefix}}/history?name={{$.ConfigName | urlencode}}&from={{.Version}}&to=desired">Diff with desired</a>
+						<form method="post" action="{{$.Prefix}}/history/rollback?name={{$.ConfigName | urlencode}}&version={{.Version}}">
+							<button type="submit">Roll back and pin</button>
+						</form>
+					</td>
+				</tr>
+				{{end}}
+			</table>
+			<a href="{{.Prefix}}/config?name={{.ConfigName | urlencode}}">Back</a>
+		</body>
+		</html>
+	`)
+
+	_ = tmpl.Execute(w, struct {
+		ConfigName string
+		Revisions  []revisionInfo
+		Pinned     int64
+		Prefix     string
+	}{
+		ConfigName: configName,
+		Revisions:  revisions,
+		Pinned:     pinned,
+		Prefix:     asrv.pathPrefix,
+	})
+}
+
+func (asrv *ADCDebugProvider) showRevisionDiff(w http.ResponseWriter, configName, from, to string) {
+	resolve := func(ref string) (*adctypes.Resources, error) {
+		if ref == "" || ref == "desired" {
+			return asrv.store.GetResources(configName)
+		}
+		version, err := strconv.ParseInt(ref, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid revision %q", ref)
+		}
+		revision, ok := asrv.store.GetRevision(configName, version)
+		if !ok {
+			return nil, fmt.Errorf("revision %d not found", version)
+		}
+		return revision.Resources, nil
+	}
+	before, err := resolve(from)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	after, err := resolve(to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tmpl := newTemplate("revisionDiff", `
+		<html>
+		<head><title>Diff for {{.ConfigName}}</title></head>
+		<body>
+			<h1>{{.ConfigName}}: {{.From}} to {{.To}}</h1>
+			{{range .Changes}}
+			<h2>{{.Change}} {{.Type}}/{{.ID}}{{if .Object}} ({{.Object}}){{end}}</h2>
+			<table border="1"><tr>
+				<td valign="top"><pre>{{.Before}}</pre></td>
+				<td valign="top"><pre>{{.After}}</pre></td>
+			</tr></table>
+			{{else}}
+			<p>No differences.</p>
+			{{end}}
+			<a href="{{.Prefix}}/history?name={{.ConfigName | urlencode}}">Back</a>
+		</body>
+		</html>
+	`)
+
+	_ = tmpl.Execute(w, struct {
+		ConfigName string
+		From       string
+		To         string
+		Changes    []cache.RevisionChange
+		Prefix     string
+	}{
+		ConfigName: configName,
+		From:       cmp.Or(from, "desired"),
+		To:         cmp.Or(to, "desired"),
+		Changes:    cache.DiffResources(before, after),
+		Prefix:     asrv.pathPrefix,
+	})
+}
+
+// handleRollback pushes a revision of a config to its data plane and pins it there.
+func (asrv *ADCDebugProvider) handleRollback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	configName := r.URL.Query().Get("name")
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if configName == "" || err != nil {
+		http.Error(w, "Config name and revision version are required", http.StatusBadRequest)
+		return
+	}
+	if err := asrv.pinner.PinRevision(r.Context(), configName, version); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, asrv.pathPrefix+"/history?name="+url.QueryEscape(configName), http.StatusSeeOther)
+}
+
+// handleUnpin lets the data plane of a config follow the config again.
+func (asrv *ADCDebugProvider) handleUnpin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	configName := r.URL.Query().Get("name")
+	if configName == "" {
+		http.Error(w, "Config name is required", http.StatusBadRequest)
+		return
+	}
+	if err := asrv.pinner.UnpinRevision(r.Context(), configName); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, asrv.pathPrefix+"/history?name="+url.QueryEscape(configName), http.StatusSeeOther)
+}
+
+// handleDryRun shows what the latest dry run of a config found it would change on the
+// data plane.
+func (asrv *ADCDebugProvider) handleDryRun(w http.ResponseWriter, r *http.Request) {
+	asrv.showDataPlaneDiff(w, r, "Dry run", "The config has not been dry run.", asrv.store.GetDryRun, nil)
+}
+
+// handleDrift shows the drift the latest check found between what a config desires and
+// what its data plane holds.
+func (asrv *ADCDebugProvider) handleDrift(w http.ResponseWriter, r *http.Request) {
+	asrv.showDataPlaneDiff(w, r, "Drift", "The config has not been checked for drift.", asrv.store.GetDrift, driftDescriptions)
+}
+
+// showDataPlaneDiff shows the diff get returns for the config named in the request. describe,
+// if set, renames the changes a sync would make.
+func (asrv *ADCDebugProvider) showDataPlaneDiff(w http.ResponseWriter, r *http.Request, title, missing string,
+	get func(name string) (cache.DataPlaneDiff, bool), describe map[string]string) {
+	configName := r.URL.Query().Get("name")
+	if configName == "" {
+		http.Error(w, "Config name is required", http.StatusBadRequest)
+		return
+	}
+	diff, ok := get(configName)
+
+	type changeInfo struct {
+		cache.DataPlaneChange
+		Object string
+	}
+	var changes []changeInfo
+	for _, change := range diff.Changes {
+		if description, ok := describe[change.Change]; ok {
+			change.Change = description
+		}
+		changes = append(changes, changeInfo{DataPlaneChange: change, Object: change.Labels[label.LabelResourceKey]})
+	}
+
+	tmpl := newTemplate("dataPlaneDiff", `
+		<html>
+		<head><title>{{.Title}} for {{.ConfigName}}</title></head>
+		<body>
+			<h1>{{.Title}} for {{.ConfigName}}</h1>
+			{{if not .Found}}
+			<p>{{.Missing}}</p>
+			{{else}}
+			<p>At {{.Timestamp}}</p>
+			{{if .Error}}<p>Failed: {{.Error}}</p>{{end}}
+			<table border="1">
+				<tr><th>Endpoint</th><th>Change</th><th>Collection</th><th>ID</th><th>Object</th></tr>
+				{{range .Changes}}
+				<tr>
+					<td>{{.ServerAddr}}</td>
+					<td>{{.Change}}</td>
+					<td>{{.Collection}}</td>
+					<td>{{.ID}}</td>
+					<td>{{.Object}}</td>
+				</tr>
+				{{end}}
+			</table>
+			{{end}}
+			<a href="{{.Prefix}}/config?name={{.ConfigName | urlencode}}">Back</a>
+		</body>
+		</html>
+	`)
+
+	_ = tmpl.Execute(w, struct {
+		Title      string
+		Missing    string
+		ConfigName string
+		Found      bool
+		Timestamp  string
+		Error      string
+		Changes    []changeInfo
+		Prefix     string
+	}{
+		Title:      title,
+		Missing:    missing,
+		ConfigName: configName,
+		Found:      ok,
+		Timestamp:  diff.Timestamp.UTC().Format(time.RFC3339),
+		Error:      diff.Error,
+		Changes:    changes,
+		Prefix:     asrv.pathPrefix,
+	})
+}
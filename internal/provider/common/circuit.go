@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// circuitReasons is the reason of the DataPlaneAvailable condition in each circuit state.
+var circuitReasons = map[types.CircuitState]string{
+	types.CircuitClosed:   v1alpha1.ConditionReasonCircuitClosed,
+	types.CircuitOpen:     v1alpha1.ConditionReasonCircuitOpen,
+	types.CircuitHalfOpen: v1alpha1.ConditionReasonCircuitHalfOpen,
+}
+
+// NewCircuitStatusUpdate returns the update that sets the DataPlaneAvailable condition of
+// the GatewayProxy whose config is named name to the state of its circuit breaker. It
+// reports false for a config that is not named after a GatewayProxy.
+func NewCircuitStatusUpdate(name string, state types.CircuitState, message string) (status.Update, bool) {
+	var nnk types.NamespacedNameKind
+	if err := nnk.FromString(name); err != nil || nnk.Kind != types.KindGatewayProxy {
+		return status.Update{}, false
+	}
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeDataPlaneAvailable,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             circuitReasons[state],
+		Message:            cutils.TruncateConditionMessage(message),
+	}
+	if state == types.CircuitClosed {
+		condition.Status = metav1.ConditionTrue
+	}
+	return status.Update{
+		NamespacedName: nnk.NamespacedName(),
+		Resource:       &v1alpha1.GatewayProxy{},
+		Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+			gp, ok := obj.(*v1alpha1.GatewayProxy)
+			if !ok {
+				return nil
+			}
+			cp := gp.DeepCopy()
+			condition.ObservedGeneration = cp.Generation
+			cp.Status.Conditions = cutils.MergeCondition(cp.Status.Conditions, condition)
+			return cp
+		}),
+	}, true
+}
@@ -144,3 +144,42 @@ func (s *ConfigManager[K, T]) DeleteConfig(key K) {
 	delete(s.configs, key)
 	delete(s.configRefs, key)
 }
+
+// Resources returns the config keys of every resource key.
+func (s *ConfigManager[K, T]) Resources() map[K][]K {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := make(map[K][]K, len(s.resourceConfigKeys))
+	for k, v := range s.resourceConfigKeys {
+		resources[k] = append([]K(nil), v...)
+	}
+	return resources
+}
+
+// Restore adds the resource keys, configs and config refs it is given that are not known
+// yet, and returns the resource and config keys it added.
+func (s *ConfigManager[K, T]) Restore(resourceConfigKeys map[K][]K, configs map[K]T, configRefs map[K][]K) []K {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var restored []K
+	for k, v := range configs {
+		if _, ok := s.configs[k]; ok {
+			continue
+		}
+		s.configs[k] = v
+		if refs, ok := configRefs[k]; ok {
+			s.configRefs[k] = refs
+		}
+		restored = append(restored, k)
+	}
+	for k, v := range resourceConfigKeys {
+		if _, ok := s.resourceConfigKeys[k]; ok {
+			continue
+		}
+		s.resourceConfigKeys[k] = v
+		restored = append(restored, k)
+	}
+	return restored
+}
@@ -0,0 +1,153 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	v2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// The reasons of the Events that report what a dry run and a drift check found.
+const (
+	ReasonDryRun = "DryRun"
+	ReasonDrift  = "Drift"
+)
+
+// maxDataPlaneEventMessage keeps an Event message below the limit the API server puts on it.
+const maxDataPlaneEventMessage = 1000
+
+// dataPlaneSources are the kinds of Kubernetes object a data plane object is translated from.
+var dataPlaneSources = []client.Object{
+	&gatewayv1.Gateway{},
+	&gatewayv1.HTTPRoute{},
+	&gatewayv1.GRPCRoute{},
+	&gatewayv1alpha2.TCPRoute{},
+	&gatewayv1alpha2.UDPRoute{},
+	&gatewayv1alpha2.TLSRoute{},
+	&netv1.Ingress{},
+	&netv1.IngressClass{},
+	&v1alpha1.Consumer{},
+	&v1alpha1.GatewayProxy{},
+	&v2.ApisixRoute{},
+	&v2.ApisixTls{},
+	&v2.ApisixConsumer{},
+	&v2.ApisixGlobalRule{},
+}
+
+// driftDescriptions says what each change a sync would make means for the data plane.
+var driftDescriptions = map[string]string{
+	"added":   "missing",
+	"removed": "unexpected",
+	"changed": "modified",
+}
+
+// DataPlaneEventRecorder reports what a comparison of a config with its data plane found
+// as an Event on each Kubernetes object whose translation differs. The object is named by
+// the label.LabelResourceKey of the data plane objects; a difference in one without it,
+// such as a global rule or a credential, is only shown by the debug server.
+type DataPlaneEventRecorder struct {
+	reader   client.Reader
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+func NewDataPlaneEventRecorder(log logr.Logger, reader client.Reader, recorder record.EventRecorder) *DataPlaneEventRecorder {
+	return &DataPlaneEventRecorder{
+		reader:   reader,
+		recorder: recorder,
+		log:      log.WithName("data-plane-events"),
+	}
+}
+
+// RecordDryRun emits the Events for what the dry run of the config named name found it
+// would change.
+func (r *DataPlaneEventRecorder) RecordDryRun(ctx context.Context, name string, diff cache.DataPlaneDiff) {
+	r.record(ctx, diff, corev1.EventTypeNormal, ReasonDryRun, "dry run against "+name+" would apply: ",
+		func(change string) string { return change })
+}
+
+// RecordDrift emits the Events for the drift found between what the config named name
+// desires and what its data plane holds.
+func (r *DataPlaneEventRecorder) RecordDrift(ctx context.Context, name string, diff cache.DataPlaneDiff) {
+	r.record(ctx, diff, corev1.EventTypeWarning, ReasonDrift, "data plane of "+name+" drifted: ",
+		func(change string) string { return driftDescriptions[change] })
+}
+
+func (r *DataPlaneEventRecorder) record(ctx context.Context, diff cache.DataPlaneDiff, eventType, reason, prefix string,
+	describe func(change string) string) {
+	type source struct {
+		kind string
+		key  k8stypes.NamespacedName
+	}
+	changes := make(map[source][]string)
+	for _, change := range diff.Changes {
+		// The resource key is kind/namespace/name, with no namespace for a cluster scoped object.
+		parts := strings.SplitN(change.Labels[label.LabelResourceKey], "/", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			continue
+		}
+		src := source{kind: parts[0], key: k8stypes.NamespacedName{Namespace: parts[1], Name: parts[2]}}
+		// The same change on every endpoint is said once.
+		description := fmt.Sprintf("%s %s/%s", describe(change.Change), change.Collection, change.ID)
+		if !slices.Contains(changes[src], description) {
+			changes[src] = append(changes[src], description)
+		}
+	}
+
+	for src, descriptions := range changes {
+		obj := newDataPlaneSource(src.kind)
+		if obj == nil {
+			continue
+		}
+		if err := r.reader.Get(ctx, src.key, obj); err != nil {
+			r.log.V(1).Info("failed to get the source of a data plane object", "kind", src.kind, "object", src.key, "error", err.Error())
+			continue
+		}
+		message := prefix + strings.Join(descriptions, ", ")
+		if len(message) > maxDataPlaneEventMessage {
+			message = message[:maxDataPlaneEventMessage-3] + "..."
+		}
+		r.recorder.Event(obj, eventType, reason, message)
+	}
+}
+
+func newDataPlaneSource(kind string) client.Object {
+	for _, obj := range dataPlaneSources {
+		if types.KindOf(obj) == kind {
+			return obj.DeepCopyObject().(client.Object)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const (
+	// LabelStoreSnapshot marks the Secrets a StorePersister keeps the store in.
+	LabelStoreSnapshot = "apisix.apache.org/store-snapshot"
+	// AnnotationStoreController and AnnotationStoreConfig name the controller and the
+	// config a store snapshot Secret belongs to.
+	AnnotationStoreController = "apisix.apache.org/controller-name"
+	AnnotationStoreConfig     = "apisix.apache.org/config-name"
+
+	storeSnapshotKey    = "snapshot.json.gz"
+	storeSnapshotPrefix = "apisix-ingress-store-"
+	// maxStoreSnapshotSize keeps a snapshot below the size the API server allows a Secret,
+	// with room to spare for its metadata.
+	maxStoreSnapshotSize = 1000 * 1024
+)
+
+// StorePersister keeps a copy of the translated store in Secrets, one per config, so that
+// a restarted controller starts from the configuration the data plane last received
+// instead of an empty one. Snapshots are gzip compressed and hold the secrets of the
+// configuration, which is why they are kept in Secrets.
+type StorePersister struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	// controllerName tells apart the snapshots of controllers sharing the namespace.
+	controllerName string
+	interval       time.Duration
+
+	// saved holds, per config, the checksum of the snapshot its Secret holds.
+	saved map[string][sha256.Size]byte
+	log   logr.Logger
+}
+
+// NewStorePersister creates a StorePersister that saves snapshots in namespace through cli
+// every interval, and reads them back through reader.
+func NewStorePersister(log logr.Logger, cli client.Client, reader client.Reader, namespace, controllerName string, interval time.Duration) *StorePersister {
+	return &StorePersister{
+		client:         cli,
+		reader:         reader,
+		namespace:      namespace,
+		controllerName: controllerName,
+		interval:       interval,
+		saved:          make(map[string][sha256.Size]byte),
+		log:            log.WithName("store-persister"),
+	}
+}
+
+// Load returns the snapshots the Secrets hold, by config name.
+func (p *StorePersister) Load(ctx context.Context) (map[string][]byte, error) {
+	var secrets corev1.SecretList
+	if err := p.reader.List(ctx, &secrets, client.InNamespace(p.namespace), client.HasLabels{LabelStoreSnapshot}); err != nil {
+		return nil, fmt.Errorf("failed to list store snapshots: %w", err)
+	}
+	snapshots := make(map[string][]byte)
+	for _, secret := range secrets.Items {
+		if secret.Annotations[AnnotationStoreController] != p.controllerName {
+			continue
+		}
+		name := secret.Annotations[AnnotationStoreConfig]
+		data, err := decompress(secret.Data[storeSnapshotKey])
+		if err != nil {
+			// A snapshot that cannot be read is as good as none: the config is translated
+			// again anyway.
+			p.log.Error(err, "ignoring unreadable store snapshot", "secret", secret.Name, "config", name)
+			continue
+		}
+		snapshots[name] = data
+		p.saved[name] = sha256.Sum256(data)
+	}
+	p.log.Info("loaded store snapshots", "configs", len(snapshots))
+	return snapshots, nil
+}
+
+// Restore hands the snapshots the Secrets hold to restore, and the objects it restored
+// that no longer exist to remove, which should reconcile their deletion. It reports whether
+// anything was restored.
+func (p *StorePersister) Restore(ctx context.Context,
+	restore func(snapshots map[string][]byte) ([]types.NamespacedNameKind, error),
+	remove func(ctx context.Context, obj client.Object) error) bool {
+	snapshots, err := p.Load(ctx)
+	if err != nil {
+		p.log.Error(err, "failed to load store snapshots")
+		return false
+	}
+	if len(snapshots) == 0 {
+		return false
+	}
+	keys, err := restore(snapshots)
+	if err != nil {
+		p.log.Error(err, "failed to restore store snapshots")
+		return false
+	}
+	for _, obj := range p.Stale(ctx, keys) {
+		p.log.Info("removing restored object that no longer exists", "kind", obj.GetObjectKind().GroupVersionKind().Kind,
+			"namespace", obj.GetNamespace(), "name", obj.GetName())
+		if err := remove(ctx, obj); err != nil {
+			p.log.Error(err, "failed to remove restored object that no longer exists", "name", obj.GetName())
+		}
+	}
+	return len(keys) > 0
+}
+
+// Run saves what snapshot returns every interval until ctx is done: the snapshot of each
+// config that changed since it was last saved, and the removal of each config that is gone.
+func (p *StorePersister) Run(ctx context.Context, snapshot func() (map[string][]byte, error)) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		snapshots, err := snapshot()
+		if err != nil {
+			p.log.Error(err, "failed to take store snapshot")
+			continue
+		}
+		p.save(ctx, snapshots)
+	}
+}
+
+func (p *StorePersister) save(ctx context.Context, snapshots map[string][]byte) {
+	for name, data := range snapshots {
+		checksum := sha256.Sum256(data)
+		if saved, ok := p.saved[name]; ok && saved == checksum {
+			continue
+		}
+		if err := p.write(ctx, name, data); err != nil {
+			p.log.Error(err, "failed to save store snapshot", "config", name)
+			continue
+		}
+		p.saved[name] = checksum
+	}
+	for name := range p.saved {
+		if _, ok := snapshots[name]; ok {
+			continue
+		}
+		if err := p.remove(ctx, name); err != nil {
+			p.log.Error(err, "failed to remove store snapshot", "config", name)
+			continue
+		}
+		delete(p.saved, name)
+	}
+}
+
+func (p *StorePersister) write(ctx context.Context, name string, data []byte) error {
+	compressed, err := compress(data)
+	if err != nil {
+		return err
+	}
+	if len(compressed) > maxStoreSnapshotSize {
+		// A snapshot left behind would restore a configuration older than the data plane's.
+		if err := p.remove(ctx, name); err != nil {
+			return err
+		}
+		return fmt.Errorf("snapshot takes %d bytes compressed, more than the %d a Secret holds", len(compressed), maxStoreSnapshotSize)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.namespace,
+			Name:      p.secretName(name),
+			Labels:    map[string]string{LabelStoreSnapshot: "true"},
+			Annotations: map[string]string{
+				AnnotationStoreController: p.controllerName,
+				AnnotationStoreConfig:     name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{storeSnapshotKey: compressed},
+	}
+	existing, err := p.get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return p.client.Create(ctx, secret)
+	}
+	secret.ResourceVersion = existing.ResourceVersion
+	return p.client.Update(ctx, secret)
+}
+
+func (p *StorePersister) remove(ctx context.Context, name string) error {
+	existing, err := p.get(ctx, name)
+	if err != nil || existing == nil {
+		return err
+	}
+	// The UID precondition keeps a Secret recreated in the meantime.
+	return client.IgnoreNotFound(p.client.Delete(ctx, existing, client.Preconditions{UID: &existing.UID}))
+}
+
+// get returns the Secret the snapshot of config name is kept in, or nil when there is none.
+// RBAC cannot limit the Secrets the controller writes to by label, so a Secret that is not
+// labeled as a snapshot is never overwritten or deleted.
+func (p *StorePersister) get(ctx context.Context, name string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	err := p.reader.Get(ctx, client.ObjectKey{Namespace: p.namespace, Name: p.secretName(name)}, &secret)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := secret.Labels[LabelStoreSnapshot]; !ok {
+		return nil, fmt.Errorf("secret %s/%s is not a store snapshot", secret.Namespace, secret.Name)
+	}
+	return &secret, nil
+}
+
+// secretName derives a valid Secret name from the controller and config name, which holds
+// slashes.
+func (p *StorePersister) secretName(name string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(p.controllerName + "/" + name))
+	return fmt.Sprintf("%s%016x", storeSnapshotPrefix, h.Sum64())
+}
+
+// Stale returns the objects among keys that no longer exist in Kubernetes, ready to be
+// handed to the provider's Delete. A restored object that was deleted while no controller
+// ran is reconciled by nothing else: no event is left to tell of its deletion.
+func (p *StorePersister) Stale(ctx context.Context, keys []types.NamespacedNameKind) []client.Object {
+	var stale []client.Object
+	for _, key := range keys {
+		obj := newDataPlaneSource(key.Kind)
+		if obj == nil {
+			p.log.V(1).Info("cannot tell whether a restored object still exists", "object", key)
+			continue
+		}
+		err := p.client.Get(ctx, key.NamespacedName(), obj)
+		if err == nil {
+			continue
+		}
+		// A kind whose API is gone takes its objects with it.
+		if !k8serrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+			p.log.Error(err, "failed to check whether a restored object still exists", "object", key)
+			continue
+		}
+		gvk, err := p.client.GroupVersionKindFor(obj)
+		if err != nil {
+			p.log.Error(err, "failed to resolve the kind of a restored object", "object", key)
+			continue
+		}
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		stale = append(stale, obj)
+	}
+	return stale
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+	return io.ReadAll(zr)
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 )
 
 type Option interface {
@@ -32,8 +33,31 @@ type Options struct {
 	SyncPeriod              time.Duration
 	InitSyncDelay           time.Duration
 	DefaultBackendMode      string
+	DefaultExecutor         string
+	SyncConcurrency         int
 	DefaultResolveEndpoints bool
 	ListenerPortMatchMode   config.ListenerPortMatchMode
+	// DryRun diffs every GatewayProxy that does not say otherwise instead of syncing it.
+	DryRun bool
+	// DriftCheckPeriod is the time between two drift checks, zero disables them.
+	DriftCheckPeriod time.Duration
+	DriftPolicy      config.DriftPolicy
+	// BatchWindow is how long changes are coalesced before they are synced, zero syncs
+	// every change on its own. Only the api7ee provider batches.
+	BatchWindow time.Duration
+	// BatchMaxSize is how many objects a batch coalesces at most, zero bounds batches by
+	// BatchWindow alone.
+	BatchMaxSize int
+	// CircuitBreakerThreshold is how many pushes in a row may find the data plane of a
+	// config unreachable before its circuit opens, zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown is how long an open circuit skips pushes before it probes.
+	CircuitBreakerCooldown time.Duration
+	// DataPlaneEvents, when set, reports what dry runs and drift checks found as Events on
+	// the source objects.
+	DataPlaneEvents *common.DataPlaneEventRecorder
+	// StorePersister, when set, saves the store so that a restart restores it.
+	StorePersister *common.StorePersister
 }
 
 func (o *Options) ApplyToList(lo *Options) {
@@ -49,12 +73,45 @@ func (o *Options) ApplyToList(lo *Options) {
 	if o.DefaultBackendMode != "" {
 		lo.DefaultBackendMode = o.DefaultBackendMode
 	}
+	if o.DefaultExecutor != "" {
+		lo.DefaultExecutor = o.DefaultExecutor
+	}
+	if o.SyncConcurrency > 0 {
+		lo.SyncConcurrency = o.SyncConcurrency
+	}
 	if o.DefaultResolveEndpoints {
 		lo.DefaultResolveEndpoints = o.DefaultResolveEndpoints
 	}
 	if o.ListenerPortMatchMode != "" {
 		lo.ListenerPortMatchMode = o.ListenerPortMatchMode
 	}
+	if o.DryRun {
+		lo.DryRun = o.DryRun
+	}
+	if o.DriftCheckPeriod > 0 {
+		lo.DriftCheckPeriod = o.DriftCheckPeriod
+	}
+	if o.DriftPolicy != "" {
+		lo.DriftPolicy = o.DriftPolicy
+	}
+	if o.BatchWindow > 0 {
+		lo.BatchWindow = o.BatchWindow
+	}
+	if o.BatchMaxSize > 0 {
+		lo.BatchMaxSize = o.BatchMaxSize
+	}
+	if o.CircuitBreakerThreshold > 0 {
+		lo.CircuitBreakerThreshold = o.CircuitBreakerThreshold
+	}
+	if o.CircuitBreakerCooldown > 0 {
+		lo.CircuitBreakerCooldown = o.CircuitBreakerCooldown
+	}
+	if o.DataPlaneEvents != nil {
+		lo.DataPlaneEvents = o.DataPlaneEvents
+	}
+	if o.StorePersister != nil {
+		lo.StorePersister = o.StorePersister
+	}
 }
 
 func (o *Options) ApplyOptions(opts []Option) *Options {
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
@@ -40,6 +41,12 @@ type Provider interface {
 	NeedLeaderElection() bool
 }
 
+// ResourceLister is implemented by the providers that can tell what they hold for each
+// config, by config name, without a data plane.
+type ResourceLister interface {
+	ListResources() (map[string]*adctypes.Resources, error)
+}
+
 type TranslateContext struct {
 	context.Context
 	RouteParentRefs  []gatewayv1.ParentReference
@@ -52,6 +59,9 @@ type TranslateContext struct {
 	// injection (see Translator.shouldInjectServerPortVars) and is computed from
 	// the matched RouteParentRefContext, preserving each parentRef's Gateway.
 	HasExplicitListenerMatch bool
+	// ListenerSets are the ListenerSets merged into the Gateway being translated,
+	// holding only the listeners the Gateway accepted.
+	ListenerSets []*gatewayv1.ListenerSet
 
 	EndpointSlices         map[k8stypes.NamespacedName][]discoveryv1.EndpointSlice
 	Secrets                map[k8stypes.NamespacedName]*corev1.Secret
@@ -60,13 +70,22 @@ type TranslateContext struct {
 	ApisixPluginConfigs    map[k8stypes.NamespacedName]*apiv2.ApisixPluginConfig
 	Services               map[k8stypes.NamespacedName]*corev1.Service
 	BackendTrafficPolicies map[k8stypes.NamespacedName]*v1alpha1.BackendTrafficPolicy
+	BackendTLSPolicies     map[k8stypes.NamespacedName]*gatewayv1.BackendTLSPolicy
 	L4RoutePolicies        map[k8stypes.NamespacedName]*v1alpha1.L4RoutePolicy
 	Upstreams              map[k8stypes.NamespacedName]*apiv2.ApisixUpstream
+	ConsumerGroups         map[k8stypes.NamespacedName]*v1alpha1.ConsumerGroup
 	GatewayProxies         map[types.NamespacedNameKind]v1alpha1.GatewayProxy
 	ResourceParentRefs     map[types.NamespacedNameKind][]types.NamespacedNameKind
 	// GatewayProxyReferrers key is GatewayProxy, value is a list of resources that reference this GatewayProxy
 	GatewayProxyReferrers map[k8stypes.NamespacedName][]types.NamespacedNameKind
-	HTTPRoutePolicies     []v1alpha1.HTTPRoutePolicy
+	// HTTPRoutePolicies are ordered oldest first, the order in which their plugins take precedence.
+	HTTPRoutePolicies []v1alpha1.HTTPRoutePolicy
+	// BackendTrafficPolicyDefaults, HTTPRoutePolicyDefaults and L4RoutePolicyDefaults merge the
+	// policies that target the Gateways, or the listeners, of the route. They apply where the
+	// policies of the route or its Services leave a field unset.
+	BackendTrafficPolicyDefaults *v1alpha1.BackendTrafficPolicy
+	HTTPRoutePolicyDefaults      *v1alpha1.HTTPRoutePolicy
+	L4RoutePolicyDefaults        *v1alpha1.L4RoutePolicy
 
 	StatusUpdaters []status.Update
 }
@@ -81,8 +100,10 @@ func NewDefaultTranslateContext(ctx context.Context) *TranslateContext {
 		ApisixPluginConfigs:    make(map[k8stypes.NamespacedName]*apiv2.ApisixPluginConfig),
 		Services:               make(map[k8stypes.NamespacedName]*corev1.Service),
 		BackendTrafficPolicies: make(map[k8stypes.NamespacedName]*v1alpha1.BackendTrafficPolicy),
+		BackendTLSPolicies:     make(map[k8stypes.NamespacedName]*gatewayv1.BackendTLSPolicy),
 		L4RoutePolicies:        make(map[k8stypes.NamespacedName]*v1alpha1.L4RoutePolicy),
 		Upstreams:              make(map[k8stypes.NamespacedName]*apiv2.ApisixUpstream),
+		ConsumerGroups:         make(map[k8stypes.NamespacedName]*v1alpha1.ConsumerGroup),
 		GatewayProxies:         make(map[types.NamespacedNameKind]v1alpha1.GatewayProxy),
 		ResourceParentRefs:     make(map[types.NamespacedNameKind][]types.NamespacedNameKind),
 		GatewayProxyReferrers:  make(map[k8stypes.NamespacedName][]types.NamespacedNameKind),
@@ -20,9 +20,35 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// MaxProxyTimeout is the longest proxy timeout the data plane accepts, timeouts are
+// handed to the balancer as a signed 32-bit number of milliseconds.
+const MaxProxyTimeout = time.Duration(math.MaxInt32) * time.Millisecond
+
+// ParseProxyTimeout parses a Gateway API Duration (GEP-2257) used as a proxy timeout.
+//
+// The data plane cannot disable a timeout, so following the Gateway API a zero
+// duration is interpreted as MaxProxyTimeout. Durations above MaxProxyTimeout
+// cannot be expressed and are rejected.
+func ParseProxyTimeout(d gatewayv1.Duration) (time.Duration, error) {
+	dur, err := time.ParseDuration(string(d))
+	if err != nil {
+		return 0, err
+	}
+	if dur < 0 || dur > MaxProxyTimeout {
+		return 0, fmt.Errorf("duration %s is out of range, it must be between 0s and %s", d, MaxProxyTimeout)
+	}
+	if dur == 0 {
+		return MaxProxyTimeout, nil
+	}
+	return dur, nil
+}
+
 // TimeDuration is yet another time.Duration but implements json.Unmarshaler
 // and json.Marshaler, yaml.Unmarshaler and yaml.Marshaler interfaces so one
 // can use "1h", "5s" and etc in their json/yaml configurations.
@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+// supportedGatewayFeatures are the Gateway API features the controller
+// implements. They are published on the status of the GatewayClasses it
+// manages and claimed by the conformance tests, so that every advertised
+// feature is exercised by the conformance tests that cover it. Features only
+// partially translated, such as BackendTLSPolicy without certificate
+// validation and retries without codes or backoff, are not advertised.
+var supportedGatewayFeatures = sets.New(
+	// resources
+	features.SupportGateway,
+	features.SupportHTTPRoute,
+	features.SupportGRPCRoute,
+	features.SupportTCPRoute,
+	features.SupportUDPRoute,
+	features.SupportTLSRoute,
+	features.SupportReferenceGrant,
+	features.SupportListenerSet,
+
+	// Gateway
+	features.SupportGatewayAddressEmpty,
+	features.SupportGatewayPort8080,
+
+	// HTTPRoute
+	features.SupportHTTPRouteDestinationPortMatching,
+	features.SupportHTTPRouteMethodMatching,
+	features.SupportHTTPRouteQueryParamMatching,
+	features.SupportHTTPRoutePortRedirect,
+	features.SupportHTTPRouteSchemeRedirect,
+	features.SupportHTTPRouteHostRewrite,
+	features.SupportHTTPRoutePathRewrite,
+	features.SupportHTTPRouteResponseHeaderModification,
+	features.SupportHTTPRouteRequestMirror,
+	features.SupportHTTPRouteRequestPercentageMirror,
+	features.SupportHTTPRouteRequestTimeout,
+	features.SupportHTTPRouteBackendTimeout,
+	features.SupportHTTPRouteCORS,
+	features.SupportHTTPRouteBackendProtocolWebSocket,
+
+	// TLSRoute
+	features.SupportTLSRouteModeTerminate,
+)
+
+// SupportedGatewayFeatures returns the names of the supported Gateway API
+// features, sorted.
+func SupportedGatewayFeatures() []features.FeatureName {
+	return sets.List(supportedGatewayFeatures)
+}
+
+// GatewayClassSupportedFeatures returns the supported Gateway API features in the
+// form of the GatewayClass status, sorted by name.
+func GatewayClassSupportedFeatures() []gatewayv1.SupportedFeature {
+	names := SupportedGatewayFeatures()
+	supported := make([]gatewayv1.SupportedFeature, 0, len(names))
+	for _, name := range names {
+		supported = append(supported, gatewayv1.SupportedFeature{Name: gatewayv1.FeatureName(name)})
+	}
+	return supported
+}
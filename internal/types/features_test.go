@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+func TestGatewayClassSupportedFeatures(t *testing.T) {
+	known := make(map[features.FeatureName]bool, features.AllFeatures.Len())
+	for _, feature := range features.AllFeatures.UnsortedList() {
+		known[feature.Name] = true
+	}
+
+	supported := GatewayClassSupportedFeatures()
+	// the GatewayClass status accepts at most 64 features, sorted by name
+	assert.LessOrEqual(t, len(supported), 64)
+	assert.True(t, slices.IsSortedFunc(supported, func(a, b gatewayv1.SupportedFeature) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	}))
+	for i, name := range SupportedGatewayFeatures() {
+		assert.True(t, known[name], "unknown feature %s", name)
+		assert.Equal(t, string(name), string(supported[i].Name))
+	}
+}
+
+func TestPartiallySupportedFeaturesAreNotAdvertised(t *testing.T) {
+	for _, name := range []features.FeatureName{
+		features.SupportBackendTLSPolicy,
+		features.SupportHTTPRouteRetry,
+	} {
+		assert.NotContains(t, SupportedGatewayFeatures(), name)
+	}
+}
@@ -40,6 +40,7 @@ const (
 
 const (
 	KindGateway              = "Gateway"
+	KindListenerSet          = "ListenerSet"
 	KindHTTPRoute            = "HTTPRoute"
 	KindTCPRoute             = "TCPRoute"
 	KindUDPRoute             = "UDPRoute"
@@ -62,7 +63,9 @@ const (
 	KindHTTPRoutePolicy      = "HTTPRoutePolicy"
 	KindL4RoutePolicy        = "L4RoutePolicy"
 	KindBackendTrafficPolicy = "BackendTrafficPolicy"
+	KindBackendTLSPolicy     = "BackendTLSPolicy"
 	KindConsumer             = "Consumer"
+	KindConsumerGroup        = "ConsumerGroup"
 	KindPluginConfig         = "PluginConfig"
 	KindApisixUpstream       = "ApisixUpstream"
 )
@@ -120,10 +123,14 @@ func KindOf(obj any) string {
 		return KindL4RoutePolicy
 	case *v1alpha1.BackendTrafficPolicy:
 		return KindBackendTrafficPolicy
+	case *gatewayv1.BackendTLSPolicy:
+		return KindBackendTLSPolicy
 	case *v1alpha1.GatewayProxy:
 		return KindGatewayProxy
 	case *v1alpha1.Consumer:
 		return KindConsumer
+	case *v1alpha1.ConsumerGroup:
+		return KindConsumerGroup
 	case *v1alpha1.PluginConfig:
 		return KindPluginConfig
 	default:
@@ -232,6 +239,12 @@ func GvkOf(obj any) schema.GroupVersionKind {
 			Version: "v1alpha1",
 			Kind:    KindConsumer,
 		}
+	case *v1alpha1.ConsumerGroup:
+		return schema.GroupVersionKind{
+			Group:   "apisix.apache.org",
+			Version: "v1alpha1",
+			Kind:    KindConsumerGroup,
+		}
 	case *v1alpha1.PluginConfig:
 		return schema.GroupVersionKind{
 			Group:   "apisix.apache.org",
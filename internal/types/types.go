@@ -60,3 +60,16 @@ func (n *NamespacedNameKind) FromString(s string) error {
 	n.Name = parts[2]
 	return nil
 }
+
+// CircuitState is the state of the circuit breaker of a GatewayProxy config.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every push through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen skips every push until the cooldown passed.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one push through to probe the data plane, and skips the others
+	// until it tells how the data plane is.
+	CircuitHalfOpen CircuitState = "half_open"
+)
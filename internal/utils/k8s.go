@@ -20,13 +20,16 @@ package utils
 import (
 	"net"
 	"regexp"
+	"time"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -45,6 +48,22 @@ func NamespacedNameKind(obj client.Object) types.NamespacedNameKind {
 	}
 }
 
+// ListenerSetListeners returns the listeners of a ListenerSet as Gateway listeners.
+func ListenerSetListeners(ls *gatewayv1.ListenerSet) []gatewayv1.Listener {
+	listeners := make([]gatewayv1.Listener, 0, len(ls.Spec.Listeners))
+	for _, entry := range ls.Spec.Listeners {
+		listeners = append(listeners, gatewayv1.Listener{
+			Name:          entry.Name,
+			Hostname:      entry.Hostname,
+			Port:          entry.Port,
+			Protocol:      entry.Protocol,
+			TLS:           entry.TLS,
+			AllowedRoutes: entry.AllowedRoutes,
+		})
+	}
+	return listeners
+}
+
 func ValidateRemoteAddrs(remoteAddrs []string) error {
 	for _, addr := range remoteAddrs {
 		if ip := net.ParseIP(addr); ip == nil {
@@ -102,6 +121,15 @@ func ConditionStatus(status bool) metav1.ConditionStatus {
 	return metav1.ConditionFalse
 }
 
+// CredentialActive reports whether credential is accepted at now: from its notBefore, and
+// until its notAfter.
+func CredentialActive(credential v1alpha1.Credential, now time.Time) bool {
+	if credential.NotBefore != nil && now.Before(credential.NotBefore.Time) {
+		return false
+	}
+	return credential.NotAfter == nil || now.Before(credential.NotAfter.Time)
+}
+
 func GetIngressClassParametersNamespace(ingressClass networkingv1.IngressClass) string {
 	namespace := "default"
 	if ingressClass.Spec.Parameters.Namespace != nil {
@@ -48,7 +48,9 @@ type adcAdmissionValidator struct {
 
 func newADCAdmissionValidator(kubeClient client.Client, log logr.Logger) (*adcAdmissionValidator, error) {
 	defaultMode := string(config.ControllerConfig.ProviderConfig.Type)
-	cli, err := adcclient.New(log, defaultMode, config.ControllerConfig.ExecADCTimeout.Duration)
+	defaultExecutor := string(config.ControllerConfig.ProviderConfig.Executor)
+	cli, err := adcclient.New(log, defaultMode, defaultExecutor, config.ControllerConfig.ExecADCTimeout.Duration,
+		config.ControllerConfig.ProviderConfig.SyncConcurrency, false)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +133,7 @@ func (v *adcAdmissionValidator) buildTask(ctx context.Context, obj client.Object
 			return nil, err
 		}
 		result, err = v.translator.TranslateApisixConsumer(tctx, resource.DeepCopy())
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 		if err != nil {
 			return nil, err
 		}
@@ -145,7 +147,7 @@ func (v *adcAdmissionValidator) buildTask(ctx context.Context, obj client.Object
 			return nil, err
 		}
 		result, err = v.translator.TranslateConsumerV1alpha1(tctx, resource.DeepCopy())
-		resourceTypes = append(resourceTypes, adctypes.TypeConsumer)
+		resourceTypes = append(resourceTypes, adctypes.TypeConsumerGroup, adctypes.TypeConsumer)
 	case *apiv2.ApisixTls:
 		configs, err := v.buildIngressClassConfigs(ctx, resource.DeepCopy())
 		if err != nil {
@@ -229,6 +231,7 @@ func (v *adcAdmissionValidator) newTask(obj client.Object, configs map[internalt
 			Services:       result.Services,
 			SSLs:           result.SSL,
 			Consumers:      result.Consumers,
+			ConsumerGroups: result.ConsumerGroups,
 		},
 	}
 }
@@ -209,20 +209,24 @@ func (v *ConsumerCustomValidator) extractKeyAuthKeys(ctx context.Context, consum
 			continue
 		}
 
-		key, err := v.extractCredentialKey(ctx, consumer, credential)
+		credentialKeys, err := v.extractCredentialKeys(ctx, consumer, credential)
 		if err != nil {
 			return nil, err
 		}
-		if key == "" {
-			continue
+		for _, key := range credentialKeys {
+			if key == "" {
+				continue
+			}
+			keys[key] = struct{}{}
 		}
-		keys[key] = struct{}{}
 	}
 
 	return keys, nil
 }
 
-func (v *ConsumerCustomValidator) extractCredentialKey(ctx context.Context, consumer *apisixv1alpha1.Consumer, credential apisixv1alpha1.Credential) (string, error) {
+// extractCredentialKeys returns the keys a key-auth credential accepts: one, or the current
+// and previous ones when its Secret lists them.
+func (v *ConsumerCustomValidator) extractCredentialKeys(ctx context.Context, consumer *apisixv1alpha1.Consumer, credential apisixv1alpha1.Credential) ([]string, error) {
 	if credential.SecretRef != nil && credential.SecretRef.Name != "" {
 		namespace := consumer.Namespace
 		if credential.SecretRef.Namespace != nil && *credential.SecretRef.Namespace != "" {
@@ -237,10 +241,10 @@ func (v *ConsumerCustomValidator) extractCredentialKey(ctx context.Context, cons
 		if namespace != consumer.Namespace {
 			permitted, err := controller.CheckConsumerSecretRef(ctx, v.Client, consumer.Namespace, nn)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
 			if !permitted {
-				return "", nil
+				return nil, nil
 			}
 		}
 
@@ -248,15 +252,22 @@ func (v *ConsumerCustomValidator) extractCredentialKey(ctx context.Context, cons
 		err := v.Client.Get(ctx, nn, &secret)
 		if err != nil {
 			if k8serrors.IsNotFound(err) {
-				return "", nil
+				return nil, nil
 			}
-			return "", err
+			return nil, err
 		}
-		return string(secret.Data["key"]), nil
+		if len(credential.SecretRef.Keys) == 0 {
+			return []string{string(secret.Data["key"])}, nil
+		}
+		keys := make([]string, 0, len(credential.SecretRef.Keys))
+		for _, key := range credential.SecretRef.Keys {
+			keys = append(keys, string(secret.Data[key]))
+		}
+		return keys, nil
 	}
 
 	if len(credential.Config.Raw) == 0 {
-		return "", nil
+		return nil, nil
 	}
 
 	var cfg struct {
@@ -267,7 +278,7 @@ func (v *ConsumerCustomValidator) extractCredentialKey(ctx context.Context, cons
 		// credential so existing consumers with bad config are not suddenly denied.
 		consumerLog.V(1).Info("skipping duplicate key-auth check: malformed credential config",
 			"consumer", consumer.Name, "error", err)
-		return "", nil
+		return nil, nil
 	}
-	return cfg.Key, nil
+	return []string{cfg.Key}, nil
 }
@@ -374,6 +374,45 @@ func TestConsumerValidator_DenyDuplicateKeyAuthCredential(t *testing.T) {
 	require.NotContains(t, err.Error(), "shared-key")
 }
 
+// A previous key still accepted while clients rotate counts as a key of the consumer.
+func TestConsumerValidator_DenyDuplicatePreviousKeyAuthKey(t *testing.T) {
+	existing := &apisixv1alpha1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+		Spec: apisixv1alpha1.ConsumerSpec{
+			GatewayRef: apisixv1alpha1.GatewayRef{Name: "test-gateway"},
+			Credentials: []apisixv1alpha1.Credential{{
+				Type:   "key-auth",
+				Config: apiextensionsv1.JSON{Raw: []byte(`{"key":"shared-key"}`)},
+			}},
+		},
+	}
+	consumer := &apisixv1alpha1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: apisixv1alpha1.ConsumerSpec{
+			GatewayRef: apisixv1alpha1.GatewayRef{Name: "test-gateway"},
+			Credentials: []apisixv1alpha1.Credential{{
+				Type: "key-auth",
+				SecretRef: &apisixv1alpha1.SecretReference{
+					Name: "rotated-key",
+					Keys: []string{"key", "previous-key"},
+				},
+			}},
+		},
+	}
+
+	validator := buildConsumerValidator(t, existing, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rotated-key", Namespace: "default"},
+		Data: map[string][]byte{
+			"key":          []byte("new-key"),
+			"previous-key": []byte("shared-key"),
+		},
+	})
+
+	_, err := validator.ValidateCreate(context.Background(), consumer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate key-auth credential")
+}
+
 // The duplicate-key check must not become a cross-namespace oracle: a key-auth
 // credential whose secretRef points across namespaces without a ReferenceGrant
 // must respond the same whether the Secret exists with a colliding key or is
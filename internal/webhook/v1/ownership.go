@@ -17,7 +17,9 @@ package v1
 
 import (
 	"context"
+	"slices"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -51,6 +53,11 @@ func isHTTPRouteManaged(ctx context.Context, c client.Client, route *gatewayv1.H
 	if route == nil {
 		return false, nil
 	}
+	// Mesh routes attach to Services, which this controller owns once a mesh
+	// GatewayProxy is configured.
+	if _, ok := config.GetMeshGatewayProxy(); ok && slices.ContainsFunc(route.Spec.ParentRefs, isServiceParentRef) {
+		return true, nil
+	}
 	return routeReferencesManagedGateway(ctx, c, route.Spec.ParentRefs, route.Namespace)
 }
 
@@ -75,6 +82,11 @@ func isUDPRouteManaged(ctx context.Context, c client.Client, route *gatewayv1alp
 	return routeReferencesManagedGateway(ctx, c, route.Spec.ParentRefs, route.Namespace)
 }
 
+func isServiceParentRef(parent gatewayv1.ParentReference) bool {
+	return parent.Group != nil && *parent.Group == corev1.GroupName &&
+		parent.Kind != nil && string(*parent.Kind) == internaltypes.KindService
+}
+
 func routeReferencesManagedGateway(ctx context.Context, c client.Client, parents []gatewayv1.ParentReference, defaultNamespace string) (bool, error) {
 	for _, parent := range parents {
 		if parent.Name == "" {
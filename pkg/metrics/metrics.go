@@ -42,6 +42,16 @@ var (
 		[]string{"config_name", "resource_type", "status"},
 	)
 
+	// ADC sync duration histogram for every data plane endpoint a config is synced to
+	ADCEndpointSyncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "apisix_ingress_adc_endpoint_sync_duration_seconds",
+			Help:    "Time spent syncing a config to a single data plane endpoint",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"config_name", "server_addr", "status"},
+	)
+
 	// ADC execution errors counter
 	ADCExecutionErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -51,6 +61,89 @@ var (
 		[]string{"config_name", "error_type"},
 	)
 
+	// ADC staged rollout counter, by how the rollout ended
+	ADCRolloutTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apisix_ingress_adc_rollout_total",
+			Help: "Total number of staged rollouts through canary data plane endpoints",
+		},
+		[]string{"config_name", "result"},
+	)
+
+	// Latest configuration revision every data plane endpoint accepted, per config
+	ADCConfigRevision = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_adc_config_revision",
+			Help: "Latest configuration revision every data plane endpoint of a config accepted",
+		},
+		[]string{"config_name"},
+	)
+
+	// Whether a config is pinned to one of its revisions
+	ADCConfigPinned = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_adc_config_pinned",
+			Help: "Whether the data plane of a config is pinned to one of its revisions",
+		},
+		[]string{"config_name"},
+	)
+
+	// Rollbacks of a config to one of its revisions
+	ADCRollbackTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apisix_ingress_adc_rollback_total",
+			Help: "Total number of rollbacks of a config to one of its revisions",
+		},
+		[]string{"config_name", "status"},
+	)
+
+	// Resources the data plane of a config holds other than desired
+	ADCDriftedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_adc_drifted_resources",
+			Help: "Number of resources the data plane of a config holds other than desired, as of the latest drift check",
+		},
+		[]string{"config_name", "resource_type"},
+	)
+
+	// Objects coalesced into one sync of a config
+	ADCBatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "apisix_ingress_adc_batch_size",
+			Help:    "Number of objects whose changes were coalesced into one sync of a config",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 11),
+		},
+		[]string{"config_name"},
+	)
+
+	// Time from the first change of a batch until its sync completed
+	ADCBatchLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "apisix_ingress_adc_batch_latency_seconds",
+			Help:    "Time from the first change coalesced into a batch until the sync of the batch completed",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"config_name", "status"},
+	)
+
+	// Circuit breaker state of a config
+	ADCCircuitState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_adc_circuit_state",
+			Help: "State of the circuit breaker of a config, 1 for the state it is in",
+		},
+		[]string{"config_name", "state"},
+	)
+
+	// Pushes skipped because the circuit breaker of a config is open
+	ADCCircuitSkippedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apisix_ingress_adc_circuit_skipped_total",
+			Help: "Total number of pushes skipped because the circuit breaker of a config is open",
+		},
+		[]string{"config_name"},
+	)
+
 	// Status update channel queue length gauge
 	StatusUpdateQueueLength = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -76,7 +169,17 @@ func init() {
 	metrics.Registry.MustRegister(
 		ADCSyncDuration,
 		ADCSyncTotal,
+		ADCEndpointSyncDuration,
 		ADCExecutionErrors,
+		ADCRolloutTotal,
+		ADCConfigRevision,
+		ADCConfigPinned,
+		ADCRollbackTotal,
+		ADCDriftedResources,
+		ADCBatchSize,
+		ADCBatchLatency,
+		ADCCircuitState,
+		ADCCircuitSkippedTotal,
 		StatusUpdateQueueLength,
 		FileIODuration,
 	)
@@ -88,11 +191,68 @@ func RecordSyncDuration(configName, resourceType, status string, duration float6
 	ADCSyncTotal.WithLabelValues(configName, resourceType, status).Inc()
 }
 
+// RecordEndpointSyncDuration records the duration of syncing a config to a single data plane endpoint
+func RecordEndpointSyncDuration(configName, serverAddr, status string, duration float64) {
+	ADCEndpointSyncDuration.WithLabelValues(configName, serverAddr, status).Observe(duration)
+}
+
 // RecordExecutionError records an ADC execution error
 func RecordExecutionError(configName, errorType string) {
 	ADCExecutionErrors.WithLabelValues(configName, errorType).Inc()
 }
 
+// RecordRollout records how a staged rollout ended
+func RecordRollout(configName, result string) {
+	ADCRolloutTotal.WithLabelValues(configName, result).Inc()
+}
+
+// RecordConfigRevision records the latest revision every data plane endpoint of a config accepted
+func RecordConfigRevision(configName string, version int64) {
+	ADCConfigRevision.WithLabelValues(configName).Set(float64(version))
+}
+
+// RecordConfigPinned records whether a config is pinned to one of its revisions
+func RecordConfigPinned(configName string, pinned bool) {
+	value := 0.0
+	if pinned {
+		value = 1
+	}
+	ADCConfigPinned.WithLabelValues(configName).Set(value)
+}
+
+// RecordRollback records a rollback of a config to one of its revisions
+func RecordRollback(configName, status string) {
+	ADCRollbackTotal.WithLabelValues(configName, status).Inc()
+}
+
+// RecordDriftedResources records how many resources of each type the latest drift check
+// of a config found drifted, dropping the types it no longer found drifted
+func RecordDriftedResources(configName string, drifted map[string]int) {
+	ADCDriftedResources.DeletePartialMatch(prometheus.Labels{"config_name": configName})
+	for resourceType, count := range drifted {
+		ADCDriftedResources.WithLabelValues(configName, resourceType).Set(float64(count))
+	}
+}
+
+// RecordBatch records how many objects a batch of a config coalesced, and how long its
+// first change waited until the batch was synced
+func RecordBatch(configName, status string, size int, latency float64) {
+	ADCBatchSize.WithLabelValues(configName).Observe(float64(size))
+	ADCBatchLatency.WithLabelValues(configName, status).Observe(latency)
+}
+
+// RecordCircuitState records the state the circuit breaker of a config is in, dropping the
+// one it left
+func RecordCircuitState(configName, state string) {
+	ADCCircuitState.DeletePartialMatch(prometheus.Labels{"config_name": configName})
+	ADCCircuitState.WithLabelValues(configName, state).Set(1)
+}
+
+// RecordCircuitSkipped records a push skipped because the circuit breaker of a config is open
+func RecordCircuitSkipped(configName string) {
+	ADCCircuitSkippedTotal.WithLabelValues(configName).Inc()
+}
+
 // UpdateStatusQueueLength updates the status update queue length gauge
 func UpdateStatusQueueLength(length float64) {
 	StatusUpdateQueueLength.Set(length)
@@ -23,6 +23,8 @@ import (
 	"sigs.k8s.io/gateway-api/conformance"
 	conformancev1 "sigs.k8s.io/gateway-api/conformance/apis/v1"
 	"sigs.k8s.io/gateway-api/conformance/tests"
+
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
 var skippedTestsForSSL = []string{
@@ -38,6 +40,8 @@ func TestGatewayAPIConformance(t *testing.T) {
 	opts.Debug = true
 	opts.CleanupBaseResources = true
 	opts.GatewayClassName = gatewayClassName
+	// claim the same features the controller publishes on the GatewayClass status
+	opts.SupportedFeatures = internaltypes.SupportedGatewayFeatures()
 	opts.SkipTests = append(opts.SkipTests, skippedTestsForSSL...)
 	opts.Implementation = conformancev1.Implementation{
 		Organization: "APISIX",
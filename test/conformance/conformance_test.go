@@ -23,6 +23,8 @@ import (
 	"sigs.k8s.io/gateway-api/conformance"
 	conformancev1 "sigs.k8s.io/gateway-api/conformance/apis/v1"
 	"sigs.k8s.io/gateway-api/conformance/tests"
+
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
 // https://github.com/kubernetes-sigs/gateway-api/blob/5c5fc388829d24e8071071b01e8313ada8f15d9f/conformance/utils/suite/suite.go#L358.  SAN includes '*'
@@ -76,6 +78,8 @@ func TestGatewayAPIConformance(t *testing.T) {
 	opts.Debug = true
 	opts.CleanupBaseResources = true
 	opts.GatewayClassName = gatewayClassName
+	// claim the same features the controller publishes on the GatewayClass status
+	opts.SupportedFeatures = internaltypes.SupportedGatewayFeatures()
 	opts.SkipTests = append(opts.SkipTests, skippedTestsForSSL...)
 	opts.SkipTests = append(opts.SkipTests, skippedTestsForTLSPassthrough...)
 	opts.SkipTests = append(opts.SkipTests, skippedTestsForKnownGaps...)